@@ -0,0 +1,137 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	ghost "github.com/chronicleprotocol/oracle-suite/pkg/config/ghostnext"
+	"github.com/chronicleprotocol/oracle-suite/pkg/feed/audit"
+)
+
+type auditOptions struct {
+	Path  string
+	Model string
+	From  string
+	To    string
+}
+
+type auditResult struct {
+	audit.Entry
+	Verified    bool   `json:"verified"`
+	VerifyError string `json:"verifyError,omitempty"`
+}
+
+func NewAuditCmd(c *ghost.Config, f *cmd.FilesFlags) *cobra.Command {
+	var opts auditOptions
+	cc := &cobra.Command{
+		Use:   "audit",
+		Args:  cobra.NoArgs,
+		Short: "Search the audit log of signed data points and verify their signatures",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if err := f.Load(c); err != nil {
+				return err
+			}
+			path := opts.Path
+			if path == "" {
+				if c.Ghost.AuditLog == nil {
+					return errors.New("audit log is not configured, use the --path flag")
+				}
+				path = c.Ghost.AuditLog.Path
+			}
+			filter := audit.Filter{Model: opts.Model}
+			var err error
+			if filter.From, err = parseAuditTime(opts.From); err != nil {
+				return err
+			}
+			if filter.To, err = parseAuditTime(opts.To); err != nil {
+				return err
+			}
+			recoverers, err := c.Ghost.Recoverers()
+			if err != nil {
+				return err
+			}
+			var invalid int
+			err = audit.Read(path, filter, func(e audit.Entry) error {
+				res := auditResult{Entry: e, Verified: true}
+				if _, err := e.Verify(context.Background(), recoverers); err != nil {
+					res.Verified = false
+					res.VerifyError = err.Error()
+					invalid++
+				}
+				bts, err := json.Marshal(res)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(bts))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%d entries failed signature verification", invalid)
+			}
+			return nil
+		},
+	}
+	cc.Flags().StringVar(
+		&opts.Path,
+		"path",
+		"",
+		"path to the audit log file, overrides the path from the config file",
+	)
+	cc.Flags().StringVar(
+		&opts.Model,
+		"model",
+		"",
+		"show only entries for the given data model",
+	)
+	cc.Flags().StringVar(
+		&opts.From,
+		"from",
+		"",
+		"show only entries recorded at or after the given time (RFC3339 or Unix timestamp)",
+	)
+	cc.Flags().StringVar(
+		&opts.To,
+		"to",
+		"",
+		"show only entries recorded at or before the given time (RFC3339 or Unix timestamp)",
+	)
+	return cc
+}
+
+func parseAuditTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	var unix int64
+	if _, err := fmt.Sscanf(s, "%d", &unix); err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 or Unix timestamp", s)
+	}
+	return time.Unix(unix, 0), nil
+}
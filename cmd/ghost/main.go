@@ -31,6 +31,7 @@ func main() {
 	var config ghost.Config
 	c.AddCommand(
 		cmd.NewRunCmd(&config, &ff, &lf),
+		NewAuditCmd(&config, &ff),
 	)
 
 	if err := c.Execute(); err != nil {
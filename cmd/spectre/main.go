@@ -29,9 +29,14 @@ func main() {
 	c := cmd.NewRootCommand("spectre", suite.Version, &ff, &lf)
 
 	var config spectre.Config
-	c.AddCommand(
-		cmd.NewRunCmd(&config, &ff, &lf),
+	runCmd := cmd.NewRunCmd(&config, &ff, &lf)
+	runCmd.Flags().BoolVar(
+		&config.Spectre.DryRun,
+		"dry-run",
+		false,
+		"check contracts and simulate updates without sending transactions",
 	)
+	c.AddCommand(runCmd)
 
 	if err := c.Execute(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,74 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	"github.com/chronicleprotocol/oracle-suite/pkg/config/spire"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+func NewCaptureCmd(c *spire.Config, f *cmd.FilesFlags, l *cmd.LoggerFlags) *cobra.Command {
+	var output string
+	cc := &cobra.Command{
+		Use:   "capture [TOPIC...]",
+		Args:  cobra.MinimumNArgs(0),
+		Short: "Captures messages from the network to a file",
+		Long: `Captures every message received from the network, including its topic, author, raw data and metadata.
+Captured messages are written as newline-delimited JSON and can be replayed using the replay command.`,
+		RunE: func(_ *cobra.Command, topics []string) (err error) {
+			if err := f.Load(c); err != nil {
+				return err
+			}
+			if len(topics) == 0 {
+				topics = transport.AllMessagesMap.Keys()
+			}
+			var out io.Writer = os.Stdout
+			if output != "" {
+				file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				out = file
+			}
+			services, err := c.CaptureServices(l.Logger(), out, topics...)
+			if err != nil {
+				return err
+			}
+			ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
+			if err = services.Start(ctx); err != nil {
+				return err
+			}
+			return <-services.Wait()
+		},
+	}
+	cc.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"file to which messages are appended, standard output is used if not specified",
+	)
+	return cc
+}
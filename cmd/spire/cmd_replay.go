@@ -0,0 +1,102 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/chronicleprotocol/oracle-suite/cmd"
+	"github.com/chronicleprotocol/oracle-suite/pkg/config/spire"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/capture"
+)
+
+func NewReplayCmd(c *spire.Config, f *cmd.FilesFlags, l *cmd.LoggerFlags) *cobra.Command {
+	var (
+		speed     float64
+		broadcast bool
+	)
+	cc := &cobra.Command{
+		Use:   "replay FILE",
+		Args:  cobra.ExactArgs(1),
+		Short: "Replays messages captured using the capture command",
+		Long: `Replays messages captured using the capture command.
+
+By default, messages are replayed to a local transport used by the price store and the Spire agent. After the replay
+is finished, the agent keeps running, so the state of the store can be inspected using the pull command.
+
+If the --broadcast flag is used, messages are broadcast to the network using the configured transport, and
+the command exits after the replay is finished. Messages are then signed by the configured key instead of
+their original authors.`,
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			if err := f.Load(c); err != nil {
+				return err
+			}
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			services, err := c.ReplayServices(l.Logger(), broadcast)
+			if err != nil {
+				return err
+			}
+			if err = services.Start(ctx); err != nil {
+				return err
+			}
+			defer func() {
+				ctxCancel()
+				if sErr := <-services.Wait(); err == nil { // Ignore sErr if another error has already occurred.
+					err = sErr
+				}
+			}()
+			n, err := capture.Replay(ctx, capture.ReplayConfig{
+				Reader:    capture.NewReader(file),
+				Transport: services.Transport,
+				Messages:  services.Messages,
+				Speed:     speed,
+				Logger:    services.Logger,
+			})
+			if err != nil {
+				return err
+			}
+			services.Logger.
+				WithField("count", n).
+				Info("Replay finished")
+			if !broadcast {
+				<-ctx.Done()
+			}
+			return nil
+		},
+	}
+	cc.Flags().Float64Var(
+		&speed,
+		"speed",
+		1,
+		"replay speed relative to the original speed, 0 replays messages without delays",
+	)
+	cc.Flags().BoolVar(
+		&broadcast,
+		"broadcast",
+		false,
+		"broadcast messages using the configured transport instead of the local one",
+	)
+	return cc
+}
@@ -18,6 +18,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -94,6 +95,7 @@ func NewStreamCmd(c *spire.Config, f *cmd.FilesFlags, l *cmd.LoggerFlags) *cobra
 	}
 	cc.AddCommand(
 		NewStreamPricesCmd(c, f, l),
+		NewStreamAgentCmd(c, f, l),
 		NewTopicsCmd(),
 	)
 	return cc
@@ -172,3 +174,64 @@ func NewStreamPricesCmd(c *spire.Config, f *cmd.FilesFlags, l *cmd.LoggerFlags)
 	)
 	return cc
 }
+
+type streamAgentOptions struct {
+	FilterPair string
+	FilterFrom string
+}
+
+func NewStreamAgentCmd(c *spire.Config, f *cmd.FilesFlags, l *cmd.LoggerFlags) *cobra.Command {
+	var streamAgentOpts streamAgentOptions
+	cc := &cobra.Command{
+		Use:   "agent",
+		Args:  cobra.ExactArgs(0),
+		Short: "Prints data points as they are collected by the agent (require agent)",
+		RunE: func(_ *cobra.Command, _ []string) (err error) {
+			if err := f.Load(c); err != nil {
+				return err
+			}
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			services, err := c.ClientServices(l.Logger())
+			if err != nil {
+				return err
+			}
+			if err = services.Start(ctx); err != nil {
+				return err
+			}
+			defer func() {
+				ctxCancel()
+				if sErr := <-services.Wait(); err == nil { // Ignore sErr if another error has already occurred.
+					err = sErr
+				}
+			}()
+			ch, err := services.SpireClient.Stream(ctx, streamAgentOpts.FilterPair, streamAgentOpts.FilterFrom)
+			if err != nil {
+				return err
+			}
+			for dp := range ch {
+				jsonMsg, err := json.Marshal(dp)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(jsonMsg))
+			}
+			if ctx.Err() == nil {
+				return errors.New("connection to the agent has been closed")
+			}
+			return nil
+		},
+	}
+	cc.Flags().StringVar(
+		&streamAgentOpts.FilterFrom,
+		"filter.from",
+		"",
+		"",
+	)
+	cc.Flags().StringVar(
+		&streamAgentOpts.FilterPair,
+		"filter.pair",
+		"",
+		"",
+	)
+	return cc
+}
@@ -34,6 +34,9 @@ func main() {
 		NewStreamCmd(&config, &ff, &lf),
 		NewPullCmd(&config, &ff, &lf),
 		NewPushCmd(&config, &ff, &lf),
+		NewCaptureCmd(&config, &ff, &lf),
+		NewReplayCmd(&config, &ff, &lf),
+		NewBridgeCmd(&config, &ff, &lf),
 	)
 
 	var bootstrapConfig BootstrapConfig
@@ -18,25 +18,25 @@ gofer {
   origin "binance" {
     type = "tick_generic_jq"
     url  = "https://api.binance.com/api/v3/ticker/24hr"
-    jq   = ".[] | select(.symbol == ($ucbase + $ucquote)) | {price: .lastPrice, volume: .volume, time: (.closeTime / 1000)}"
+    jq   = ".[] | select(.symbol == ($ucbase + $ucquote)) | {price: .lastPrice, volume: .volume, time: (.closeTime / 1000), bid: .bidPrice, ask: .askPrice}"
   }
 
   origin "bitfinex" {
     type = "tick_generic_jq"
     url  = "https://api-pub.bitfinex.com/v2/tickers?symbols=ALL"
-    jq   = ".[] | select(.[0] == \"t\" + ($ucbase + $ucquote)) | {price: .[7], time: now|round, volume: .[8]}"
+    jq   = ".[] | select(.[0] == \"t\" + ($ucbase + $ucquote)) | {price: .[7], time: now|round, volume: .[8], bid: .[1], ask: .[3]}"
   }
 
   origin "bitstamp" {
     type = "tick_generic_jq"
     url  = "https://www.bitstamp.net/api/v2/ticker/$${lcbase}$${lcquote}"
-    jq   = "{price: .last, time: .timestamp, volume: .volume}"
+    jq   = "{price: .last, time: .timestamp, volume: .volume, bid: .bid, ask: .ask}"
   }
 
   origin "coinbase" {
     type = "tick_generic_jq"
     url  = "https://api.pro.coinbase.com/products/$${ucbase}-$${ucquote}/ticker"
-    jq   = "{price: .price, time: .time, volume: .volume}"
+    jq   = "{price: .price, time: .time, volume: .volume, bid: .bid, ask: .ask}"
   }
 
   origin "curve" {
@@ -60,19 +60,19 @@ gofer {
   origin "gemini" {
     type = "tick_generic_jq"
     url  = "https://api.gemini.com/v1/pubticker/$${lcbase}$${lcquote}"
-    jq   = "{price: .last, time: (.volume.timestamp/1000), volume: .volume[$ucquote]|tonumber}"
+    jq   = "{price: .last, time: (.volume.timestamp/1000), volume: .volume[$ucquote]|tonumber, bid: .bid, ask: .ask}"
   }
 
   origin "hitbtc" {
     type = "tick_generic_jq"
     url  = "https://api.hitbtc.com/api/2/public/ticker?symbols=$${ucbase}$${ucquote}"
-    jq   = "{price: .[0].last|tonumber, time: .[0].timestamp|strptime(\"%Y-%m-%dT%H:%M:%S.%jZ\")|mktime, volume: .[0].volumeQuote|tonumber}"
+    jq   = "{price: .[0].last|tonumber, time: .[0].timestamp|strptime(\"%Y-%m-%dT%H:%M:%S.%jZ\")|mktime, volume: .[0].volumeQuote|tonumber, bid: .[0].bid, ask: .[0].ask}"
   }
 
   origin "huobi" {
     type = "tick_generic_jq"
     url  = "https://api.huobi.pro/market/tickers"
-    jq   = ".data[] | select(.symbol == ($lcbase+$lcquote)) | {price: .close, volume: .vol, time: now|round}"
+    jq   = ".data[] | select(.symbol == ($lcbase+$lcquote)) | {price: .close, volume: .vol, time: now|round, bid: .bid, ask: .ask}"
   }
 
   origin "ishares" {
@@ -83,19 +83,19 @@ gofer {
   origin "kraken" {
     type = "tick_generic_jq"
     url  = "https://api.kraken.com/0/public/Ticker?pair=$${ucbase}/$${ucquote}"
-    jq   = "($ucbase + \"/\" + $ucquote) as $pair | {price: .result[$pair].c[0]|tonumber, time: now|round, volume: .result[$pair].v[0]|tonumber}"
+    jq   = "($ucbase + \"/\" + $ucquote) as $pair | {price: .result[$pair].c[0]|tonumber, time: now|round, volume: .result[$pair].v[0]|tonumber, bid: .result[$pair].b[0], ask: .result[$pair].a[0]}"
   }
 
   origin "kucoin" {
     type = "tick_generic_jq"
     url  = "https://api.kucoin.com/api/v1/market/orderbook/level1?symbol=$${ucbase}-$${ucquote}"
-    jq   = "{price: .data.price, time: (.data.time/1000)|round, volume: null}"
+    jq   = "{price: .data.price, time: (.data.time/1000)|round, volume: null, bid: .data.bestBid, ask: .data.bestAsk}"
   }
 
   origin "okx" {
     type = "tick_generic_jq"
     url  = "https://www.okx.com/api/v5/market/ticker?instId=$${ucbase}-$${ucquote}-SWAP"
-    jq   = "{price: .data[0].last|tonumber, time: (.data[0].ts|tonumber/1000), volume: .data[0].vol24h|tonumber}"
+    jq   = "{price: .data[0].last|tonumber, time: (.data[0].ts|tonumber/1000), volume: .data[0].vol24h|tonumber, bid: .data[0].bidPx, ask: .data[0].askPx}"
   }
 
   origin "rocketpool" {
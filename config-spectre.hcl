@@ -13,9 +13,13 @@ spectre {
       # Address of the Median contract.
       contract_addr = contract.value.oracle
 
-      # List of feeds that are allowed to be storing messages in storage. Other feeds are ignored.
+      # List of feeds expected on the Median contract. Optional, the feed set and quorum are read from
+      # the contract. If set, a warning is logged when the configured feeds differ from the contract.
       feeds = env("CFG_FEEDS", "") == "*" ? concat(var.feed_sets["prod"], var.feed_sets["stage"]) : try(var.feed_sets[env("CFG_FEEDS", "prod")], explode(",", env("CFG_FEEDS", "")))
 
+      # Time in seconds for which the feed set read from the Median contract is cached.
+      feeds_ttl = 600
+
       # Name of the pair to fetch the price for.
       data_model = replace(contract.key, "/", "")
 
@@ -56,4 +60,44 @@ spectre {
       interval = contract.value.interval
     }
   }
+
+  # Watchtower that verifies optimistic updates of the OptimisticScribe contract
+  # and challenges the ones with an invalid signature. Public keys of feeds are
+  # received from the network, or read from the lift transactions of the contract
+  # for feeds that do not send them. The RPC node must support eth_getLogs from
+  # the deployment block of the contract.
+  # watchtower {
+  #   ethereum_client = "default"
+  #   contract_addr   = "0x..."
+  #   interval        = 30
+  #   lookback_blocks = 1000
+  # }
+
+  # HTTP API that reports the state of every configured contract in JSON format.
+  # status_api {
+  #   listen_addr = "127.0.0.1:9100"
+  # }
+
+  # Coordination between multiple Spectre instances that update the same contracts.
+  # Relay addresses must be allowed to send messages by the transport.
+  # coordination {
+  #   ethereum_key   = "default"
+  #   relays         = ["0x...", "0x..."]
+  #   takeover_delay = 30
+  # }
+
+  # Transaction settings for the Ethereum client. All fields are optional.
+  tx_manager "default" {
+    # Percentile of priority fees paid in recent blocks used as the priority fee.
+    priority_fee_percentile = 50
+
+    # Multiplier applied to the next block base fee to calculate the maximum fee per gas.
+    base_fee_multiplier = 2
+
+    # Cap for the maximum fee per gas in gwei, including replacements.
+    max_fee = 2000
+
+    # Time in seconds after which a transaction that was not mined is replaced with higher fees.
+    replacement_interval = 60
+  }
 }
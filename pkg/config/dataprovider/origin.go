@@ -46,6 +46,11 @@ type configOriginStatic struct{}
 type configOriginTickGenericJQ struct {
 	URL string `hcl:"url"` // Do not use config.URL because it encodes $ sign
 	JQ  string `hcl:"jq"`
+
+	// TickIntervals enables tick intervals with bid and ask prices as
+	// bounds. Disabled by default, because nodes that do not support tick
+	// intervals cannot decode them.
+	TickIntervals bool `hcl:"tick_intervals,optional"`
 }
 
 type configOriginIShares struct {
@@ -163,11 +168,12 @@ func (c *configOrigin) configureOrigin(d Dependencies) (origin.Origin, error) {
 		return origin.NewStatic(), nil
 	case *configOriginTickGenericJQ:
 		origin, err := origin.NewTickGenericJQ(origin.TickGenericJQConfig{
-			URL:     o.URL,
-			Query:   o.JQ,
-			Headers: nil,
-			Client:  d.HTTPClient,
-			Logger:  d.Logger,
+			URL:           o.URL,
+			Query:         o.JQ,
+			TickIntervals: o.TickIntervals,
+			Headers:       nil,
+			Client:        d.HTTPClient,
+			Logger:        d.Logger,
 		})
 		if err != nil {
 			return nil, &hcl.Diagnostic{
@@ -0,0 +1,78 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package eip712
+
+import (
+	"fmt"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
+)
+
+// ConfigDomain contains the EIP-712 domain used to sign and verify data
+// points of a single data model.
+type ConfigDomain struct {
+	// DataModel is the name of the data model that uses the domain.
+	DataModel string `hcl:"data_model,label"`
+
+	// Name is the EIP-712 domain name.
+	Name string `hcl:"name"`
+
+	// Version is the EIP-712 domain version.
+	Version string `hcl:"version"`
+
+	// ChainID is the EIP-712 domain chain ID.
+	ChainID uint64 `hcl:"chain_id"`
+
+	// VerifyingContract is the EIP-712 domain verifying contract address.
+	VerifyingContract types.Address `hcl:"verifying_contract"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// Domains returns a map of EIP-712 domains indexed by data model names.
+func Domains(cfgs []ConfigDomain) (map[string]signer.EIP712Domain, error) {
+	domains := make(map[string]signer.EIP712Domain, len(cfgs))
+	for _, cfg := range cfgs {
+		if _, ok := domains[cfg.DataModel]; ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("EIP-712 domain for the %q data model is already defined", cfg.DataModel),
+				Subject:  cfg.Range.Ptr(),
+			}
+		}
+		if cfg.ChainID == 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Chain ID cannot be zero",
+				Subject:  cfg.Content.Attributes["chain_id"].Range.Ptr(),
+			}
+		}
+		domains[cfg.DataModel] = signer.EIP712Domain{
+			Name:              cfg.Name,
+			Version:           cfg.Version,
+			ChainID:           cfg.ChainID,
+			VerifyingContract: cfg.VerifyingContract,
+		}
+	}
+	return domains, nil
+}
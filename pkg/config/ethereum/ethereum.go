@@ -30,6 +30,8 @@ import (
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/web3signer"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter"
 )
@@ -82,19 +84,63 @@ type ConfigKey struct {
 	Address types.Address `hcl:"address"`
 
 	// KeystorePath is the path to the keystore directory.
-	KeystorePath string `hcl:"keystore_path"`
+	//
+	// Either KeystorePath or RemoteSigner must be provided.
+	KeystorePath string `hcl:"keystore_path,optional"`
 
 	// PassphraseFile is the path to the file containing the passphrase for the
 	// key. If empty, then the passphrase is not provided.
 	PassphraseFile string `hcl:"passphrase_file,optional"`
 
+	// RemoteSigner is the configuration of a remote signing service that
+	// holds the key.
+	//
+	// Either KeystorePath or RemoteSigner must be provided.
+	RemoteSigner *ConfigRemoteSigner `hcl:"remote_signer,block,optional"`
+
 	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
 
 	// Configured key:
 	key wallet.Key
 }
 
+// ConfigRemoteSigner contains the configuration for a remote signing service
+// compatible with the Web3Signer eth1 signing API.
+type ConfigRemoteSigner struct {
+	// URL is the base URL of the signing service.
+	URL config.URL `hcl:"url"`
+
+	// Identifier is the key identifier used by the signing service. If empty,
+	// the key address is used.
+	Identifier string `hcl:"identifier,optional"`
+
+	// Timeout is the timeout for signing requests, in seconds.
+	Timeout uint32 `hcl:"timeout,optional"`
+
+	// TLSServerName is the server name used to verify the hostname on the
+	// returned certificates from the server.
+	TLSServerName string `hcl:"tls_server_name,optional"`
+
+	// TLSCertFile is the path to PEM encoded client certificate file.
+	TLSCertFile string `hcl:"tls_cert_file,optional"`
+
+	// TLSKeyFile is the path to PEM encoded client private key file.
+	TLSKeyFile string `hcl:"tls_key_file,optional"`
+
+	// TLSRootCAFile is the path to PEM encoded root certificate file.
+	TLSRootCAFile string `hcl:"tls_root_ca_file,optional"`
+
+	// TLSInsecureSkipVerify skips the verification of the server's
+	// certificate chain and host name.
+	TLSInsecureSkipVerify bool `hcl:"tls_insecure_skip_verify,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
 // ConfigClient contains the configuration for an Ethereum client.
 type ConfigClient struct {
 	// Name is the unique name of the client that can be referenced by other
@@ -264,6 +310,47 @@ func (c *ConfigKey) Key(logger log.Logger) (wallet.Key, error) {
 		}
 	}
 
+	if len(c.KeystorePath) == 0 && c.RemoteSigner == nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Either keystore_path or remote_signer block is required",
+			Subject:  c.Range.Ptr(),
+		}
+	}
+	if len(c.KeystorePath) > 0 && c.RemoteSigner != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "The keystore_path and remote_signer block cannot be used together",
+			Subject:  c.RemoteSigner.Range.Ptr(),
+		}
+	}
+
+	// Create key.
+	var (
+		key wallet.Key
+		err error
+	)
+	if c.RemoteSigner != nil {
+		key, err = c.remoteKey()
+	} else {
+		key, err = c.keystoreKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.
+		WithField("name", c.Name).
+		WithField("address", key.Address().String()).
+		Info("Ethereum Key")
+
+	c.key = key
+	return key, nil
+}
+
+func (c *ConfigKey) keystoreKey() (wallet.Key, error) {
 	// Get passphrase.
 	passphrase, err := readAccountPassphrase(c.PassphraseFile)
 	if err != nil {
@@ -285,13 +372,37 @@ func (c *ConfigKey) Key(logger log.Logger) (wallet.Key, error) {
 			Subject:  c.Content.Attributes["keystore_path"].Range.Ptr(),
 		}
 	}
+	return key, nil
+}
 
-	logger.
-		WithField("name", c.Name).
-		WithField("address", key.Address().String()).
-		Info("Ethereum Key")
-
-	c.key = key
+func (c *ConfigKey) remoteKey() (wallet.Key, error) {
+	if c.Address == types.ZeroAddress {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Address is required when using a remote signer",
+			Subject:  c.Range.Ptr(),
+		}
+	}
+	key, err := web3signer.NewKey(web3signer.Config{
+		URL:                   c.RemoteSigner.URL.String(),
+		Address:               c.Address,
+		Identifier:            c.RemoteSigner.Identifier,
+		Timeout:               time.Second * time.Duration(c.RemoteSigner.Timeout),
+		TLSCertFile:           c.RemoteSigner.TLSCertFile,
+		TLSKeyFile:            c.RemoteSigner.TLSKeyFile,
+		TLSRootCAFile:         c.RemoteSigner.TLSRootCAFile,
+		TLSServerName:         c.RemoteSigner.TLSServerName,
+		TLSInsecureSkipVerify: c.RemoteSigner.TLSInsecureSkipVerify,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create remote signer key: %v", err),
+			Subject:  c.RemoteSigner.Range.Ptr(),
+		}
+	}
 	return key, nil
 }
 
@@ -395,8 +506,10 @@ func (c *ConfigClient) Client(logger log.Logger, keys KeyRegistry) (rpc.RPC, err
 		}
 	}
 
-	c.client = client
-	return client, nil
+	// The go-eth client does not support the eth_feeHistory call, which is
+	// used to estimate transaction fees.
+	c.client = ethereum.NewRPC(client, rpcTransport)
+	return c.client, nil
 }
 
 func (c *ConfigClient) transport(logger log.Logger) (transport.Transport, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/web3signer"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 )
 
@@ -30,6 +31,17 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, "./testdata/keystore", cfg.Keys[1].KeystorePath)
 				assert.Equal(t, "./testdata/keystore/passphrase", cfg.Keys[1].PassphraseFile)
 
+				assert.Equal(t, "key3", cfg.Keys[2].Name)
+				assert.Equal(t, "0x1234567890123456789012345678901234567890", cfg.Keys[2].Address.String())
+				assert.Equal(t, "https://signer.example", cfg.Keys[2].RemoteSigner.URL.String())
+
+				assert.Equal(t, "key4", cfg.Keys[3].Name)
+				assert.Equal(t, "https://signer.example", cfg.Keys[3].RemoteSigner.URL.String())
+				assert.Equal(t, "0x04aabbcc", cfg.Keys[3].RemoteSigner.Identifier)
+				assert.Equal(t, uint32(5), cfg.Keys[3].RemoteSigner.Timeout)
+				assert.Equal(t, "signer.example", cfg.Keys[3].RemoteSigner.TLSServerName)
+				assert.True(t, cfg.Keys[3].RemoteSigner.TLSInsecureSkipVerify)
+
 				assert.Equal(t, "client1", cfg.Clients[0].Name)
 				assert.Equal(t, "https://rpc1.example", cfg.Clients[0].RPCURLs[0].String())
 				assert.Equal(t, uint64(1), cfg.Clients[0].ChainID)
@@ -51,10 +63,12 @@ func TestConfig(t *testing.T) {
 				keys, diags := cfg.KeyRegistry(Dependencies{Logger: null.New()})
 				require.NoError(t, diags)
 
-				require.Len(t, keys, 3)
+				require.Len(t, keys, 5)
 				assert.NotNil(t, keys["rand_key"])
 				assert.Equal(t, "0xd18d7f6d9e349d1d6bf33702192019f166a7201e", keys["key1"].Address().String())
 				assert.Equal(t, "0x2d800d93b065ce011af83f316cef9f0d005b0aa4", keys["key2"].Address().String())
+				assert.Equal(t, "0x1234567890123456789012345678901234567890", keys["key3"].Address().String())
+				assert.IsType(t, &web3signer.Key{}, keys["key3"])
 			},
 		},
 		{
@@ -13,6 +13,26 @@ key "key2" {
   passphrase_file = "./testdata/keystore/passphrase"
 }
 
+# Remote signer without optionals
+key "key3" {
+  address = "0x1234567890123456789012345678901234567890"
+  remote_signer {
+    url = "https://signer.example"
+  }
+}
+
+# Remote signer with optionals
+key "key4" {
+  address = "0x2234567890123456789012345678901234567890"
+  remote_signer {
+    url                      = "https://signer.example"
+    identifier               = "0x04aabbcc"
+    timeout                  = 5
+    tls_server_name          = "signer.example"
+    tls_insecure_skip_verify = true
+  }
+}
+
 # Without optionals
 client "client1" {
   rpc_urls     = ["https://rpc1.example"]
@@ -19,16 +19,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/wallet"
 	"github.com/hashicorp/hcl/v2"
+	"golang.org/x/exp/slices"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 
+	eip712Config "github.com/chronicleprotocol/oracle-suite/pkg/config/eip712"
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/feed"
+	"github.com/chronicleprotocol/oracle-suite/pkg/feed/audit"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
@@ -41,12 +48,142 @@ type Config struct {
 
 	DataModels []string `hcl:"data_models"`
 
+	// SignatureScheme is the scheme used to sign data points. Supported
+	// values are "legacy" and "eip712". If empty, the legacy scheme is used.
+	SignatureScheme string `hcl:"signature_scheme,optional"`
+
+	// EIP712Domains is a list of EIP-712 domains for data models. Required
+	// for every data model if the "eip712" signature scheme is used.
+	EIP712Domains []eip712Config.ConfigDomain `hcl:"eip712_domain,block"`
+
+	// Schedules is a list of data model groups with their own intervals.
+	// Data models that are not listed in any schedule are broadcast using
+	// the Interval.
+	Schedules []ConfigSchedule `hcl:"schedule,block"`
+
+	// Deviations is a list of deviation-triggered broadcast configurations
+	// for data models. Data models without a deviation configuration are
+	// broadcast on every interval.
+	Deviations []ConfigDeviation `hcl:"deviation,block"`
+
+	// SamplingInterval is the interval in seconds at which data models with
+	// a deviation configuration are sampled. If zero, they are sampled on
+	// the interval of their schedule.
+	SamplingInterval uint32 `hcl:"sampling_interval,optional"`
+
+	// ConsistencyCheck is an optional configuration of the check that
+	// compares data points with data points of other feeds before they are
+	// broadcast.
+	ConsistencyCheck *ConfigConsistencyCheck `hcl:"consistency_check,block,optional"`
+
+	// AuditLog is an optional configuration of the log that records every
+	// signed data point.
+	AuditLog *ConfigAuditLog `hcl:"audit_log,block,optional"`
+
+	// Batch enables sending all data points of a single interval in one
+	// batch message instead of separate messages.
+	Batch bool `hcl:"batch,optional"`
+
+	// Topics is a list of topics on which data points are broadcast,
+	// "data_point/v1" and/or "data_point/v2". If empty, only the v1 topic
+	// is used.
+	Topics []string `hcl:"topics,optional"`
+
+	// Compact enables the compact mode of v2 messages, in which sub points
+	// are not sent.
+	Compact bool `hcl:"compact,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+
+	// Configured services:
+	feed      *feed.Feed
+	peerStore *store.Store
+	auditLog  *audit.FileLog
+}
+
+// ConfigSchedule configures a group of data models broadcast at the same
+// interval.
+type ConfigSchedule struct {
+	// Name is the name of the schedule, used in logs.
+	Name string `hcl:"name,label"`
+
+	// Interval is the interval at which to publish prices in seconds.
+	Interval uint32 `hcl:"interval"`
+
+	// Align aligns broadcasts to multiples of the interval since the Unix
+	// epoch, e.g. an interval of 3600 seconds broadcasts at every full hour.
+	Align bool `hcl:"align,optional"`
+
+	// Offset shifts aligned broadcasts by the given number of seconds.
+	Offset uint32 `hcl:"offset,optional"`
+
+	// DataModels is a list of data models broadcast by the schedule.
+	DataModels []string `hcl:"data_models"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
+}
+
+// ConfigDeviation configures deviation-triggered broadcasting for a single
+// data model.
+type ConfigDeviation struct {
+	// DataModel is the name of the data model.
+	DataModel string `hcl:"data_model,label"`
+
+	// Threshold is the minimum deviation from the last broadcast value
+	// in percent, that triggers a broadcast.
+	Threshold float64 `hcl:"threshold"`
 
-	// Configured service:
-	feed *feed.Feed
+	// Heartbeat is the maximum time between broadcasts in seconds.
+	Heartbeat uint32 `hcl:"heartbeat"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// ConfigConsistencyCheck configures the check that compares data points with
+// the median of data points received from other feeds.
+type ConfigConsistencyCheck struct {
+	// MaxDeviation is the maximum deviation from the median of other feeds
+	// in percent.
+	MaxDeviation float64 `hcl:"max_deviation"`
+
+	// MinPeers is the minimum number of data points from other feeds
+	// required to perform the check.
+	MinPeers int `hcl:"min_peers,optional"`
+
+	// MaxAge is the maximum age of data points from other feeds in seconds.
+	MaxAge uint32 `hcl:"max_age,optional"`
+
+	// Action is the action taken for data points that deviate too much.
+	// Supported values are "withhold" and "flag". If empty, "withhold" is
+	// used.
+	Action string `hcl:"action,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+// ConfigAuditLog configures the audit log of signed data points.
+type ConfigAuditLog struct {
+	// Path is the path to the audit log file.
+	Path string `hcl:"path"`
+
+	// MaxSize is the maximum size of the audit log file in megabytes before
+	// it is rotated.
+	MaxSize uint32 `hcl:"max_size,optional"`
+
+	// MaxFiles is the number of rotated audit log files to keep.
+	MaxFiles uint32 `hcl:"max_files,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
 }
 
 type Dependencies struct {
@@ -77,13 +214,54 @@ func (c *Config) ConfigureFeed(d Dependencies) (*feed.Feed, error) {
 			Subject:  c.Content.Attributes["ethereum_key"].Range.Ptr(),
 		}
 	}
+	signers, err := c.signers(ethereumKey)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := c.schedules()
+	if err != nil {
+		return nil, err
+	}
+	deviations, err := c.deviations()
+	if err != nil {
+		return nil, err
+	}
+	consistencyCheck, err := c.consistencyCheck(ethereumKey, d)
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := c.AuditLogService(d)
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range c.Topics {
+		if topic != messages.DataPointV1MessageName && topic != messages.DataPointV2MessageName {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Unsupported data point topic: %s", topic),
+				Subject:  c.Content.Attributes["topics"].Range.Ptr(),
+			}
+		}
+	}
 	cfg := feed.Config{
-		DataModels:   c.DataModels,
-		DataProvider: d.DataProvider,
-		Signers:      []datapoint.Signer{signer.NewTickSigner(ethereumKey)},
-		Transport:    d.Transport,
-		Logger:       d.Logger,
-		Interval:     timeutil.NewTicker(time.Second * time.Duration(c.Interval)),
+		DataModels:       c.DataModels,
+		DataProvider:     d.DataProvider,
+		Signers:          signers,
+		Transport:        d.Transport,
+		Logger:           d.Logger,
+		Interval:         timeutil.NewTicker(time.Second * time.Duration(c.Interval)),
+		Schedules:        schedules,
+		Deviations:       deviations,
+		SamplingInterval: c.samplingInterval(),
+
+		ConsistencyCheck: consistencyCheck,
+		Batch:            c.Batch,
+		Topics:           c.Topics,
+		Compact:          c.Compact,
+	}
+	if auditLog != nil {
+		cfg.AuditLog = auditLog
 	}
 	feedService, err := feed.New(cfg)
 	if err != nil {
@@ -97,3 +275,295 @@ func (c *Config) ConfigureFeed(d Dependencies) (*feed.Feed, error) {
 	c.feed = feedService
 	return feedService, nil
 }
+
+func (c *Config) signers(key wallet.Key) ([]datapoint.Signer, error) {
+	switch c.SignatureScheme {
+	case "", "legacy":
+		return []datapoint.Signer{
+			signer.NewTickSigner(key),
+			signer.NewTickIntervalSigner(key),
+			signer.NewNumericSigner(key),
+		}, nil
+	case "eip712":
+		domains, err := eip712Config.Domains(c.EIP712Domains)
+		if err != nil {
+			return nil, err
+		}
+		for _, model := range c.DataModels {
+			if _, ok := domains[model]; !ok {
+				return nil, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("EIP-712 domain for the %q data model is not configured", model),
+					Subject:  c.Content.Attributes["data_models"].Range.Ptr(),
+				}
+			}
+		}
+		// Tick intervals are not supported by the EIP-712 signer, so they
+		// are signed using the legacy scheme.
+		return []datapoint.Signer{
+			signer.NewEIP712Signer(key, domains),
+			signer.NewTickIntervalSigner(key),
+		}, nil
+	default:
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Unknown signature scheme: %s", c.SignatureScheme),
+			Subject:  c.Content.Attributes["signature_scheme"].Range.Ptr(),
+		}
+	}
+}
+
+func (c *Config) schedules() ([]feed.Schedule, error) {
+	var (
+		schedules []feed.Schedule
+		scheduled = make(map[string]bool)
+	)
+	for _, s := range c.Schedules {
+		if s.Interval == 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Interval cannot be zero",
+				Subject:  s.Content.Attributes["interval"].Range.Ptr(),
+			}
+		}
+		if s.Offset >= s.Interval {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Offset must be shorter than the interval",
+				Subject:  s.Content.Attributes["offset"].Range.Ptr(),
+			}
+		}
+		for _, model := range s.DataModels {
+			if !slices.Contains(c.DataModels, model) {
+				return nil, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Data model %q is not listed in data_models", model),
+					Subject:  s.Content.Attributes["data_models"].Range.Ptr(),
+				}
+			}
+			if scheduled[model] {
+				return nil, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Data model %q is already assigned to another schedule", model),
+					Subject:  s.Content.Attributes["data_models"].Range.Ptr(),
+				}
+			}
+			scheduled[model] = true
+		}
+		interval := time.Second * time.Duration(s.Interval)
+		ticker := timeutil.NewTicker(interval)
+		if s.Align {
+			ticker = timeutil.NewAlignedTicker(interval, time.Second*time.Duration(s.Offset))
+		}
+		schedules = append(schedules, feed.Schedule{
+			Name:       s.Name,
+			DataModels: s.DataModels,
+			Interval:   ticker,
+		})
+	}
+	return schedules, nil
+}
+
+// samplingInterval returns the ticker used to sample data models with
+// a deviation configuration, or nil if the sampling interval is not set.
+func (c *Config) samplingInterval() *timeutil.Ticker {
+	if c.SamplingInterval == 0 {
+		return nil
+	}
+	return timeutil.NewTicker(time.Second * time.Duration(c.SamplingInterval))
+}
+
+// modelInterval returns the broadcast interval of the data model in seconds.
+func (c *Config) modelInterval(model string) uint32 {
+	for _, s := range c.Schedules {
+		if slices.Contains(s.DataModels, model) {
+			return s.Interval
+		}
+	}
+	return c.Interval
+}
+
+func (c *Config) deviations() (map[string]feed.Deviation, error) {
+	deviations := make(map[string]feed.Deviation, len(c.Deviations))
+	for _, d := range c.Deviations {
+		if !slices.Contains(c.DataModels, d.DataModel) {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Data model %q is not listed in data_models", d.DataModel),
+				Subject:  d.Range.Ptr(),
+			}
+		}
+		if _, ok := deviations[d.DataModel]; ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Deviation for the %q data model is already defined", d.DataModel),
+				Subject:  d.Range.Ptr(),
+			}
+		}
+		if d.Threshold <= 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Threshold must be greater than zero",
+				Subject:  d.Content.Attributes["threshold"].Range.Ptr(),
+			}
+		}
+		interval := c.modelInterval(d.DataModel)
+		if c.SamplingInterval > 0 {
+			interval = c.SamplingInterval
+		}
+		if d.Heartbeat < interval {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Heartbeat cannot be shorter than the interval",
+				Subject:  d.Content.Attributes["heartbeat"].Range.Ptr(),
+			}
+		}
+		deviations[d.DataModel] = feed.Deviation{
+			Threshold: d.Threshold / 100, //nolint:gomnd
+			Heartbeat: time.Second * time.Duration(d.Heartbeat),
+		}
+	}
+	return deviations, nil
+}
+
+// Recoverers returns the recoverers for all supported signature schemes.
+func (c *Config) Recoverers() ([]datapoint.Recoverer, error) {
+	domains, err := eip712Config.Domains(c.EIP712Domains)
+	if err != nil {
+		return nil, err
+	}
+	return []datapoint.Recoverer{
+		signer.NewTickRecoverer(crypto.ECRecoverer),
+		signer.NewTickIntervalRecoverer(crypto.ECRecoverer),
+		signer.NewNumericRecoverer(crypto.ECRecoverer),
+		signer.NewEIP712Recoverer(crypto.ECRecoverer, domains),
+	}, nil
+}
+
+// PeerStore returns the store of data points received from other feeds.
+// It returns nil if the consistency check is not configured.
+func (c *Config) PeerStore(d Dependencies) (*store.Store, error) {
+	if c.ConsistencyCheck == nil {
+		return nil, nil
+	}
+	if c.peerStore != nil {
+		return c.peerStore, nil
+	}
+	recoverers, err := c.Recoverers()
+	if err != nil {
+		return nil, err
+	}
+	peerStore, err := store.New(store.Config{
+		Storage:    store.NewMemoryStorage(),
+		Transport:  d.Transport,
+		Models:     c.DataModels,
+		Recoverers: recoverers,
+		Logger:     d.Logger,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create the peer store service: %v", err),
+			Subject:  c.ConsistencyCheck.Range.Ptr(),
+		}
+	}
+	c.peerStore = peerStore
+	return peerStore, nil
+}
+
+func (c *Config) consistencyCheck(key wallet.Key, d Dependencies) (*feed.ConsistencyCheck, error) {
+	if c.ConsistencyCheck == nil {
+		return nil, nil
+	}
+	cc := c.ConsistencyCheck
+	if cc.MaxDeviation <= 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Maximum deviation must be greater than zero",
+			Subject:  cc.Content.Attributes["max_deviation"].Range.Ptr(),
+		}
+	}
+	if cc.MinPeers < 0 {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Minimum number of peers cannot be negative",
+			Subject:  cc.Content.Attributes["min_peers"].Range.Ptr(),
+		}
+	}
+	var withhold bool
+	switch cc.Action {
+	case "", "withhold":
+		withhold = true
+	case "flag":
+		withhold = false
+	default:
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Unknown action: %s", cc.Action),
+			Subject:  cc.Content.Attributes["action"].Range.Ptr(),
+		}
+	}
+	peerStore, err := c.PeerStore(d)
+	if err != nil {
+		return nil, err
+	}
+	return &feed.ConsistencyCheck{
+		Store:        peerStore,
+		Self:         key.Address(),
+		MaxDeviation: cc.MaxDeviation / 100, //nolint:gomnd
+		MinPeers:     cc.MinPeers,
+		MaxAge:       time.Second * time.Duration(cc.MaxAge),
+		Withhold:     withhold,
+	}, nil
+}
+
+// AuditLogService returns the log that records every signed data point.
+// It returns nil if the audit log is not configured. The log must be closed
+// after the feed is stopped.
+func (c *Config) AuditLogService(d Dependencies) (*audit.FileLog, error) {
+	if c.AuditLog == nil {
+		return nil, nil
+	}
+	if c.auditLog != nil {
+		return c.auditLog, nil
+	}
+	ethereumKey, ok := d.KeysRegistry[c.EthereumKey]
+	if !ok {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Ethereum key %q is not configured", c.EthereumKey),
+			Subject:  c.Content.Attributes["ethereum_key"].Range.Ptr(),
+		}
+	}
+	auditLog, err := audit.NewFileLog(audit.FileLogConfig{
+		Signer:   ethereumKey.Address(),
+		Path:     c.AuditLog.Path,
+		MaxSize:  int64(c.AuditLog.MaxSize) * 1024 * 1024, //nolint:gomnd
+		MaxFiles: int(c.AuditLog.MaxFiles),
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Invalid audit log configuration: %v", err),
+			Subject:  c.AuditLog.Range.Ptr(),
+		}
+	}
+	c.auditLog = auditLog
+	return auditLog, nil
+}
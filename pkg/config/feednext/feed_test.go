@@ -27,6 +27,37 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, "key", cfg.EthereumKey)
 				assert.Equal(t, uint32(60), cfg.Interval)
 				assert.Equal(t, []string{"ETH/USD", "BTC/USD"}, cfg.DataModels)
+				assert.False(t, cfg.Batch)
+			},
+		},
+		{
+			name: "topics",
+			path: "topics.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, []string{"data_point/v1", "data_point/v2"}, cfg.Topics)
+				assert.True(t, cfg.Compact)
+			},
+		},
+		{
+			name: "batch",
+			path: "batch.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.Batch)
+			},
+		},
+		{
+			name: "sampling",
+			path: "sampling.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				assert.Equal(t, uint32(5), cfg.SamplingInterval)
+				feed, err := cfg.ConfigureFeed(Dependencies{
+					KeysRegistry: ethereum.KeyRegistry{"key": &ethereumMocks.Key{}},
+					DataProvider: graph.NewProvider(nil, nil),
+					Transport:    local.New([]byte("test"), 1, nil),
+					Logger:       null.New(),
+				})
+				require.NoError(t, err)
+				assert.NotNil(t, feed)
 			},
 		},
 		{
@@ -0,0 +1,8 @@
+ethereum_key = "key"
+interval     = 60
+batch        = true
+
+data_models = [
+  "ETH/USD",
+  "BTC/USD",
+]
@@ -0,0 +1,13 @@
+ethereum_key      = "key"
+interval          = 60
+sampling_interval = 5
+
+data_models = [
+  "ETH/USD",
+  "BTC/USD",
+]
+
+deviation "ETH/USD" {
+  threshold = 0.5
+  heartbeat = 3600
+}
@@ -0,0 +1,9 @@
+ethereum_key = "key"
+interval     = 60
+topics       = ["data_point/v1", "data_point/v2"]
+compact      = true
+
+data_models = [
+  "ETH/USD",
+  "BTC/USD",
+]
@@ -18,6 +18,7 @@ package ghostnext
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/hashicorp/hcl/v2"
@@ -27,6 +28,7 @@ import (
 	feedConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feednext"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/feed"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 
@@ -68,15 +70,22 @@ func (c *Config) Services(baseLogger log.Logger) (pkgSupervisor.Service, error)
 	}
 	messageMap, err := pkgTransport.AllMessagesMap.SelectByTopic(
 		messages.DataPointV1MessageName,
+		messages.DataPointV2MessageName,
+		messages.DataPointBatchV1MessageName,
 	)
 	if err != nil {
 		return nil, err
 	}
+	recoverers, err := c.Ghost.Recoverers()
+	if err != nil {
+		return nil, err
+	}
 	transport, err := c.Transport.Transport(transportConfig.Dependencies{
-		Keys:     keys,
-		Clients:  clients,
-		Messages: messageMap,
-		Logger:   logger,
+		Keys:       keys,
+		Clients:    clients,
+		Messages:   messageMap,
+		Logger:     logger,
+		Recoverers: recoverers,
 	})
 	if err != nil {
 		return nil, err
@@ -88,29 +97,46 @@ func (c *Config) Services(baseLogger log.Logger) (pkgSupervisor.Service, error)
 	if err != nil {
 		return nil, err
 	}
-	feedService, err := c.Ghost.ConfigureFeed(feedConfig.Dependencies{
+	feedDeps := feedConfig.Dependencies{
 		KeysRegistry: keys,
 		DataProvider: dataProvider,
 		Transport:    transport,
 		Logger:       logger,
-	})
+	}
+	feedService, err := c.Ghost.ConfigureFeed(feedDeps)
+	if err != nil {
+		return nil, err
+	}
+	peerStore, err := c.Ghost.PeerStore(feedDeps)
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := c.Ghost.AuditLogService(feedDeps)
 	if err != nil {
 		return nil, err
 	}
-	return &Services{
+	services := &Services{
 		Feed:      feedService,
+		PeerStore: peerStore,
 		Transport: transport,
 		Logger:    logger,
-	}, nil
+	}
+	if auditLog != nil {
+		services.AuditLog = auditLog
+	}
+	return services, nil
 }
 
 // Services returns the services that are configured from the Config struct.
 type Services struct {
 	Feed      *feed.Feed
+	PeerStore *store.Store // Optional, used by the feed consistency check.
 	Transport pkgTransport.Service
 	Logger    log.Logger
+	AuditLog  io.Closer // Optional, closed after all services are stopped.
 
 	supervisor *pkgSupervisor.Supervisor
+	waitCh     chan error
 }
 
 // Start implements the supervisor.Service interface.
@@ -120,13 +146,43 @@ func (s *Services) Start(ctx context.Context) error {
 	}
 	s.supervisor = pkgSupervisor.New(s.Logger)
 	s.supervisor.Watch(s.Transport, s.Feed, sysmon.New(time.Minute, s.Logger))
+	if s.PeerStore != nil {
+		s.supervisor.Watch(s.PeerStore)
+	}
 	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
-	return s.supervisor.Start(ctx)
+	s.waitCh = make(chan error)
+	if err := s.supervisor.Start(ctx); err != nil {
+		_ = s.closeAuditLog()
+		close(s.waitCh)
+		return err
+	}
+	go s.shutdownHandler()
+	return nil
 }
 
 // Wait implements the supervisor.Service interface.
 func (s *Services) Wait() <-chan error {
-	return s.supervisor.Wait()
+	return s.waitCh
+}
+
+// shutdownHandler waits for all services to stop and then closes the audit
+// log, so that no data point is recorded after the log is closed.
+func (s *Services) shutdownHandler() {
+	defer close(s.waitCh)
+	err := <-s.supervisor.Wait()
+	if cErr := s.closeAuditLog(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		s.waitCh <- err
+	}
+}
+
+func (s *Services) closeAuditLog() error {
+	if s.AuditLog == nil {
+		return nil
+	}
+	return s.AuditLog.Close()
 }
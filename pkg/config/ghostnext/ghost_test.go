@@ -18,6 +18,7 @@ package ghostnext
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/config"
@@ -32,9 +33,33 @@ func TestConfig(t *testing.T) {
 		{
 			path: "config.hcl",
 			test: func(t *testing.T, cfg *Config) {
+				require.Len(t, cfg.Ghost.Schedules, 1)
+				assert.Equal(t, "slow", cfg.Ghost.Schedules[0].Name)
+				assert.Equal(t, uint32(3600), cfg.Ghost.Schedules[0].Interval)
+				assert.True(t, cfg.Ghost.Schedules[0].Align)
+				assert.Equal(t, uint32(300), cfg.Ghost.Schedules[0].Offset)
+				assert.Equal(t, []string{"ETH/USD"}, cfg.Ghost.Schedules[0].DataModels)
+
+				require.Len(t, cfg.Ghost.Deviations, 1)
+				assert.Equal(t, "BTC/USD", cfg.Ghost.Deviations[0].DataModel)
+				assert.Equal(t, 0.5, cfg.Ghost.Deviations[0].Threshold)
+				assert.Equal(t, uint32(3600), cfg.Ghost.Deviations[0].Heartbeat)
+
+				require.NotNil(t, cfg.Ghost.ConsistencyCheck)
+				assert.Equal(t, 5.0, cfg.Ghost.ConsistencyCheck.MaxDeviation)
+				assert.Equal(t, 3, cfg.Ghost.ConsistencyCheck.MinPeers)
+				assert.Equal(t, uint32(600), cfg.Ghost.ConsistencyCheck.MaxAge)
+				assert.Equal(t, "flag", cfg.Ghost.ConsistencyCheck.Action)
+
+				require.NotNil(t, cfg.Ghost.AuditLog)
+				assert.Equal(t, "/tmp/ghost-audit.log", cfg.Ghost.AuditLog.Path)
+				assert.Equal(t, uint32(10), cfg.Ghost.AuditLog.MaxSize)
+				assert.Equal(t, uint32(5), cfg.Ghost.AuditLog.MaxFiles)
+
 				services, err := cfg.Services(null.New())
 				require.NoError(t, err)
 				require.NotNil(t, services)
+				assert.NotNil(t, services.(*Services).PeerStore)
 			},
 		},
 	}
@@ -3,8 +3,34 @@ ghost {
   interval     = 60
 
   data_models = [
-    "BTC/USD"
+    "BTC/USD",
+    "ETH/USD"
   ]
+
+  schedule "slow" {
+    interval    = 3600
+    align       = true
+    offset      = 300
+    data_models = ["ETH/USD"]
+  }
+
+  audit_log {
+    path      = "/tmp/ghost-audit.log"
+    max_size  = 10
+    max_files = 5
+  }
+
+  consistency_check {
+    max_deviation = 5
+    min_peers     = 3
+    max_age       = 600
+    action        = "flag"
+  }
+
+  deviation "BTC/USD" {
+    threshold = 0.5
+    heartbeat = 3600
+  }
 }
 
 gofer {
@@ -17,6 +43,10 @@ gofer {
   data_model "BTC/USD" {
     origin "coinbase" { query = "BTC/USD" }
   }
+
+  data_model "ETH/USD" {
+    origin "coinbase" { query = "ETH/USD" }
+  }
 }
 
 ethereum {
@@ -17,17 +17,21 @@ package relay
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/rpc"
 	"github.com/defiweb/go-eth/types"
 	"github.com/hashicorp/hcl/v2"
 
+	eip712Config "github.com/chronicleprotocol/oracle-suite/pkg/config/eip712"
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/relay"
+	relayAPI "github.com/chronicleprotocol/oracle-suite/pkg/relay/api"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 
@@ -36,12 +40,21 @@ import (
 )
 
 type Services struct {
-	Relay      *relay.Relay
-	PriceStore *store.Store
-	MuSigStore *relay.MuSigStore
+	Relay       *relay.Relay
+	PriceStore  *store.Store
+	MuSigStore  *relay.MuSigStore
+	Coordinator *relay.Coordinator
+
+	// FeedKeyStore is nil if no Scribe contract or watchtower is
+	// configured.
+	FeedKeyStore *relay.FeedKeyStore
+
+	// StatusAPI is nil if the status API is not configured.
+	StatusAPI *relayAPI.StatusAPI
 }
 
 type Dependencies struct {
+	Keys      ethereumConfig.KeyRegistry
 	Clients   ethereumConfig.ClientRegistry
 	Transport transport.Service
 	Logger    log.Logger
@@ -57,12 +70,132 @@ type Config struct {
 	// OptimisticScribe is a list of OptimisticScribe contracts to watch.
 	OptimisticScribe []configCommon `hcl:"optimistic_scribe,block"`
 
+	// Watchtower is a list of OptimisticScribe contracts whose optimistic
+	// updates are verified and challenged if their signatures are invalid.
+	Watchtower []configWatchtower `hcl:"watchtower,block"`
+
+	// EIP712Domains is a list of EIP-712 domains used to verify data points
+	// signed using the EIP-712 signature scheme.
+	EIP712Domains []eip712Config.ConfigDomain `hcl:"eip712_domain,block"`
+
+	// TxManagers is a list of transaction manager configurations for
+	// Ethereum clients. Clients without a configuration use default
+	// settings.
+	TxManagers []configTxManager `hcl:"tx_manager,block"`
+
+	// Coordination is an optional configuration of the coordination between
+	// multiple relays that update the same contracts.
+	Coordination *configCoordination `hcl:"coordination,block,optional"`
+
+	// StatusAPI is an optional configuration of the HTTP API that reports
+	// the state of contracts updated by the relay.
+	StatusAPI *configStatusAPI `hcl:"status_api,block,optional"`
+
+	// DryRun enables the dry-run mode of the relay, in which no
+	// transactions are sent. It is set by a command line flag, not by the
+	// config file.
+	DryRun bool
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
 
 	// Configured services:
-	services *Services
+	services   *Services
+	txManagers map[string]*relay.TxManager
+}
+
+type configCoordination struct {
+	// EthereumKey is a name of the Ethereum key used by the transport to
+	// sign messages. Its address identifies this relay.
+	EthereumKey string `hcl:"ethereum_key"`
+
+	// Relays is a list of addresses of all coordinated relays, including
+	// this one. The addresses must be allowed to send messages by the
+	// transport.
+	Relays []types.Address `hcl:"relays"`
+
+	// TakeoverDelay is a time in seconds a relay waits for every relay with
+	// a higher priority before it updates a contract.
+	TakeoverDelay uint32 `hcl:"takeover_delay,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type configStatusAPI struct {
+	// ListenAddr is the address on which the status API will listen.
+	ListenAddr string `hcl:"listen_addr"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type configWatchtower struct {
+	// EthereumClient is a name of an Ethereum client to use.
+	EthereumClient string `hcl:"ethereum_client"`
+
+	// ContractAddr is an address of an OptimisticScribe contract.
+	ContractAddr types.Address `hcl:"contract_addr"`
+
+	// Interval is a time interval in seconds between checking for new
+	// optimistic updates.
+	Interval uint32 `hcl:"interval"`
+
+	// LookbackBlocks is a number of recent blocks searched for optimistic
+	// updates. It should cover the challenge period of the contract.
+	LookbackBlocks uint64 `hcl:"lookback_blocks,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type configTxManager struct {
+	// EthereumClient is a name of an Ethereum client used to send
+	// transactions.
+	EthereumClient string `hcl:",label"`
+
+	// GasLimitMultiplier is a multiplier applied to the estimated gas.
+	GasLimitMultiplier float64 `hcl:"gas_limit_multiplier,optional"`
+
+	// MaxGasLimit is a maximum gas limit of a transaction.
+	MaxGasLimit uint64 `hcl:"max_gas_limit,optional"`
+
+	// FeeHistoryBlocks is a number of recent blocks used to estimate the
+	// priority fee.
+	FeeHistoryBlocks uint64 `hcl:"fee_history_blocks,optional"`
+
+	// PriorityFeePercentile is a percentile of priority fees paid in recent
+	// blocks used as the priority fee.
+	PriorityFeePercentile float64 `hcl:"priority_fee_percentile,optional"`
+
+	// BaseFeeMultiplier is a multiplier applied to the next block base fee
+	// to calculate the maximum fee per gas.
+	BaseFeeMultiplier float64 `hcl:"base_fee_multiplier,optional"`
+
+	// MinPriorityFee is a minimum priority fee per gas in gwei.
+	MinPriorityFee float64 `hcl:"min_priority_fee,optional"`
+
+	// MaxFee is a cap for the maximum fee per gas in gwei.
+	MaxFee float64 `hcl:"max_fee,optional"`
+
+	// ReplacementInterval is a time in seconds after which a transaction
+	// that was not mined is replaced with a transaction with higher fees.
+	ReplacementInterval uint32 `hcl:"replacement_interval,optional"`
+
+	// ReplacementFeeBump is a minimum fee increase of a replacement
+	// transaction as a percentage point, e.g. 12.5 means 12.5%.
+	ReplacementFeeBump float64 `hcl:"replacement_fee_bump,optional"`
+
+	// MaxReplacements is a maximum number of replacements of a transaction.
+	MaxReplacements int `hcl:"max_replacements,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
 }
 
 type configCommon struct {
@@ -72,8 +205,14 @@ type configCommon struct {
 	// ContractAddr is an address of a Median contract.
 	ContractAddr types.Address `hcl:"contract_addr"`
 
-	// Pairs is a list of pairs to store in the price store.
-	Feeds []types.Address `hcl:"feeds"`
+	// Feeds is a list of expected feeds. Feeds of Median contracts are
+	// read from the contract, and a warning is logged if they differ from
+	// this list.
+	Feeds []types.Address `hcl:"feeds,optional"`
+
+	// FeedsTTL is a time in seconds after which the feed list and the
+	// quorum of a Median contract are read from the contract again.
+	FeedsTTL uint32 `hcl:"feeds_ttl,optional"`
 
 	// DataModel is a data model to use for the Median contract.
 	DataModel string `hcl:"data_model"`
@@ -122,12 +261,17 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 		opScribeDataModels = append(opScribeDataModels, cfg.DataModel)
 	}
 
+	recoverers, err := c.Recoverers()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a data point store service for all median contracts.
 	priceStoreSrv, err := store.New(store.Config{
 		Storage:    store.NewMemoryStorage(),
 		Transport:  d.Transport,
 		Models:     medianDataModels,
-		Recoverers: []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)},
+		Recoverers: recoverers,
 		Logger:     d.Logger,
 	})
 	if err != nil {
@@ -139,18 +283,91 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 		}
 	}
 
+	// Create the feed key store service used to verify Schnorr signatures.
+	var feedKeyStoreSrv *relay.FeedKeyStore
+	if len(c.Scribe) > 0 || len(c.OptimisticScribe) > 0 || len(c.Watchtower) > 0 {
+		feedKeyStoreSrv, err = relay.NewFeedKeyStore(relay.FeedKeyStoreConfig{
+			Transport: d.Transport,
+			Logger:    d.Logger,
+		})
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Failed to create the feed key store service: %v", err),
+				Subject:  &c.Range,
+			}
+		}
+	}
+
 	// Create MuSigStore service.
-	musigStoreSrv := relay.NewMuSigStore(relay.MuSigStoreConfig{
+	musigStoreCfg := relay.MuSigStoreConfig{
 		Transport:          d.Transport,
 		ScribeDataModels:   scribeDataModels,
 		OpScribeDataModels: opScribeDataModels,
 		Logger:             d.Logger,
-	})
+	}
+	if feedKeyStoreSrv != nil {
+		musigStoreCfg.FeedKeys = feedKeyStoreSrv
+	}
+	musigStoreSrv := relay.NewMuSigStore(musigStoreCfg)
+
+	// Create the coordinator service if relays coordinate updates.
+	var coordinatorSrv *relay.Coordinator
+	if c.Coordination != nil {
+		key, ok := d.Keys[c.Coordination.EthereumKey]
+		if !ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Ethereum key %q is not configured", c.Coordination.EthereumKey),
+				Subject:  c.Coordination.Content.Attributes["ethereum_key"].Range.Ptr(),
+			}
+		}
+		coordinatorSrv, err = relay.NewCoordinator(relay.CoordinatorConfig{
+			Transport:     d.Transport,
+			Self:          key.Address(),
+			Relays:        c.Coordination.Relays,
+			TakeoverDelay: time.Second * time.Duration(c.Coordination.TakeoverDelay),
+			Logger:        d.Logger,
+		})
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Failed to create the coordinator service: %v", err),
+				Subject:  c.Coordination.Range.Ptr(),
+			}
+		}
+	}
+
+	// Validate transaction manager configurations.
+	txManagerClients := make(map[string]bool)
+	for _, tm := range c.TxManagers {
+		if _, ok := d.Clients[tm.EthereumClient]; !ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Ethereum client %q is not configured", tm.EthereumClient),
+				Subject:  tm.Range.Ptr(),
+			}
+		}
+		if txManagerClients[tm.EthereumClient] {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Duplicate transaction manager for the %q client", tm.EthereumClient),
+				Subject:  tm.Range.Ptr(),
+			}
+		}
+		txManagerClients[tm.EthereumClient] = true
+	}
 
 	var (
 		medianCfgs   []relay.ConfigMedian
 		scribeCfgs   []relay.ConfigScribe
 		opScribeCfgs []relay.ConfigOptimisticScribe
+		watchtowers  []relay.ConfigWatchtower
 	)
 
 	for _, cfg := range c.Median {
@@ -171,12 +388,20 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 			WithField("address", cfg.ContractAddr).
 			Info("Contract configuration")
 
+		txManager, err := c.txManager(cfg.EthereumClient, client, d.Logger)
+		if err != nil {
+			return nil, err
+		}
+
 		medianCfgs = append(medianCfgs, relay.ConfigMedian{
 			DataModel:       cfg.DataModel,
 			ContractAddress: cfg.ContractAddr,
 			FeedAddresses:   cfg.Feeds,
+			FeedsTTL:        time.Second * time.Duration(cfg.FeedsTTL),
 			Client:          client,
+			TxManager:       txManager,
 			DataPointStore:  priceStoreSrv,
+			Coordinator:     coordinatorSrv,
 			Spread:          cfg.Spread,
 			Expiration:      time.Second * time.Duration(cfg.Expiration),
 			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
@@ -200,12 +425,20 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 			WithField("address", cfg.ContractAddr).
 			Info("Contract configuration")
 
+		txManager, err := c.txManager(cfg.EthereumClient, client, d.Logger)
+		if err != nil {
+			return nil, err
+		}
+
 		scribeCfgs = append(scribeCfgs, relay.ConfigScribe{
 			DataModel:       cfg.DataModel,
 			ContractAddress: cfg.ContractAddr,
 			FeedAddresses:   cfg.Feeds,
 			Client:          client,
+			TxManager:       txManager,
 			MuSigStore:      musigStoreSrv,
+			Coordinator:     coordinatorSrv,
+			FeedKeyStore:    feedKeyStoreSrv,
 			Spread:          cfg.Spread,
 			Expiration:      time.Second * time.Duration(cfg.Expiration),
 			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
@@ -229,23 +462,65 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 			WithField("address", cfg.ContractAddr).
 			Info("Contract configuration")
 
+		txManager, err := c.txManager(cfg.EthereumClient, client, d.Logger)
+		if err != nil {
+			return nil, err
+		}
+
 		opScribeCfgs = append(opScribeCfgs, relay.ConfigOptimisticScribe{
 			DataModel:       cfg.DataModel,
 			ContractAddress: cfg.ContractAddr,
 			FeedAddresses:   cfg.Feeds,
 			Client:          client,
+			TxManager:       txManager,
 			MuSigStore:      musigStoreSrv,
+			Coordinator:     coordinatorSrv,
+			FeedKeyStore:    feedKeyStoreSrv,
 			Spread:          cfg.Spread,
 			Expiration:      time.Second * time.Duration(cfg.Expiration),
 			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
 		})
 	}
 
+	for _, cfg := range c.Watchtower {
+		client, ok := d.Clients[cfg.EthereumClient]
+		if !ok {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Ethereum client %q is not configured", cfg.EthereumClient),
+				Subject:  cfg.Content.Attributes["ethereum_client"].Range.Ptr(),
+			}
+		}
+
+		logger.
+			WithField("client", cfg.EthereumClient).
+			WithField("contract", "Watchtower").
+			WithField("address", cfg.ContractAddr).
+			Info("Contract configuration")
+
+		txManager, err := c.txManager(cfg.EthereumClient, client, d.Logger)
+		if err != nil {
+			return nil, err
+		}
+
+		watchtowers = append(watchtowers, relay.ConfigWatchtower{
+			ContractAddress: cfg.ContractAddr,
+			Client:          client,
+			TxManager:       txManager,
+			FeedKeyStore:    feedKeyStoreSrv,
+			LookbackBlocks:  cfg.LookbackBlocks,
+			Ticker:          timeutil.NewTicker(time.Second * time.Duration(cfg.Interval)),
+		})
+	}
+
 	relaySrv, err := relay.New(relay.Config{
 		Medians:           medianCfgs,
 		Scribes:           scribeCfgs,
 		OptimisticScribes: opScribeCfgs,
+		Watchtowers:       watchtowers,
 		Logger:            d.Logger,
+		DryRun:            c.DryRun,
 	})
 	if err != nil {
 		return nil, &hcl.Diagnostic{
@@ -256,10 +531,101 @@ func (c *Config) Relay(d Dependencies) (*Services, error) {
 		}
 	}
 
+	var statusAPISrv *relayAPI.StatusAPI
+	if c.StatusAPI != nil {
+		statusAPISrv, err = relayAPI.New(relayAPI.Config{
+			StatusProvider: relaySrv,
+			Address:        c.StatusAPI.ListenAddr,
+			Logger:         d.Logger,
+		})
+		if err != nil {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   fmt.Sprintf("Failed to create the status API service: %v", err),
+				Subject:  c.StatusAPI.Range.Ptr(),
+			}
+		}
+	}
+
 	c.services = &Services{
-		Relay:      relaySrv,
-		PriceStore: priceStoreSrv,
-		MuSigStore: musigStoreSrv,
+		Relay:        relaySrv,
+		PriceStore:   priceStoreSrv,
+		MuSigStore:   musigStoreSrv,
+		Coordinator:  coordinatorSrv,
+		FeedKeyStore: feedKeyStoreSrv,
+		StatusAPI:    statusAPISrv,
 	}
 	return c.services, nil
 }
+
+// Recoverers returns the recoverers used to verify data points. EIP-712
+// domains are used to verify data points during the migration from the
+// legacy signature scheme.
+func (c *Config) Recoverers() ([]datapoint.Recoverer, error) {
+	domains, err := eip712Config.Domains(c.EIP712Domains)
+	if err != nil {
+		return nil, err
+	}
+	return []datapoint.Recoverer{
+		signer.NewTickRecoverer(crypto.ECRecoverer),
+		signer.NewEIP712Recoverer(crypto.ECRecoverer, domains),
+	}, nil
+}
+
+// txManager returns the transaction manager for the Ethereum client. The
+// same transaction manager is shared by all contracts that use the client,
+// so nonces are tracked in one place.
+func (c *Config) txManager(name string, client rpc.RPC, logger log.Logger) (*relay.TxManager, error) {
+	if txManager, ok := c.txManagers[name]; ok {
+		return txManager, nil
+	}
+	var (
+		cfg  configTxManager
+		rang = c.Range
+	)
+	for _, tm := range c.TxManagers {
+		if tm.EthereumClient == name {
+			cfg = tm
+			rang = tm.Range
+			break
+		}
+	}
+	txManagerCfg := relay.TxManagerConfig{
+		Client:                client,
+		GasLimitMultiplier:    cfg.GasLimitMultiplier,
+		MaxGasLimit:           cfg.MaxGasLimit,
+		FeeHistoryBlocks:      cfg.FeeHistoryBlocks,
+		PriorityFeePercentile: cfg.PriorityFeePercentile,
+		BaseFeeMultiplier:     cfg.BaseFeeMultiplier,
+		ReplacementInterval:   time.Second * time.Duration(cfg.ReplacementInterval),
+		ReplacementFeeBump:    cfg.ReplacementFeeBump / 100,
+		MaxReplacements:       cfg.MaxReplacements,
+		Logger:                logger,
+	}
+	if cfg.MinPriorityFee > 0 {
+		txManagerCfg.MinPriorityFee = gweiToWei(cfg.MinPriorityFee)
+	}
+	if cfg.MaxFee > 0 {
+		txManagerCfg.MaxFeePerGas = gweiToWei(cfg.MaxFee)
+	}
+	txManager, err := relay.NewTxManager(txManagerCfg)
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Failed to create the transaction manager for the %q client: %v", name, err),
+			Subject:  rang.Ptr(),
+		}
+	}
+	if c.txManagers == nil {
+		c.txManagers = make(map[string]*relay.TxManager)
+	}
+	c.txManagers[name] = txManager
+	return txManager, nil
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(1e9)).Int(nil)
+	return wei
+}
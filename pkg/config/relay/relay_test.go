@@ -26,6 +26,7 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, float64(1), cfg.Median[0].Spread)
 				assert.Equal(t, uint32(300), cfg.Median[0].Expiration)
 				assert.Equal(t, uint32(60), cfg.Median[0].Interval)
+				assert.Equal(t, uint32(300), cfg.Median[0].FeedsTTL)
 				assert.Equal(t, []types.Address{
 					types.MustAddressFromHex("0x0011223344556677889900112233445566778899"),
 					types.MustAddressFromHex("0x1122334455667788990011223344556677889900"),
@@ -52,6 +53,38 @@ func TestConfig(t *testing.T) {
 					types.MustAddressFromHex("0x4455667788990011223344556677889900112233"),
 					types.MustAddressFromHex("0x5566778899001122334455667788990011223344"),
 				}, cfg.OptimisticScribe[0].Feeds)
+
+				assert.Equal(t, "client3", cfg.Watchtower[0].EthereumClient)
+				assert.Equal(t, "0x3456789012345678901234567890123456789012", cfg.Watchtower[0].ContractAddr.String())
+				assert.Equal(t, uint32(30), cfg.Watchtower[0].Interval)
+				assert.Equal(t, uint64(500), cfg.Watchtower[0].LookbackBlocks)
+
+				assert.Equal(t, "BTC/USD", cfg.EIP712Domains[0].DataModel)
+				assert.Equal(t, "Chronicle", cfg.EIP712Domains[0].Name)
+				assert.Equal(t, "1", cfg.EIP712Domains[0].Version)
+				assert.Equal(t, uint64(1), cfg.EIP712Domains[0].ChainID)
+				assert.Equal(t, "0x2345678901234567890123456789012345678901", cfg.EIP712Domains[0].VerifyingContract.String())
+
+				assert.Equal(t, "client1", cfg.TxManagers[0].EthereumClient)
+				assert.Equal(t, 1.5, cfg.TxManagers[0].GasLimitMultiplier)
+				assert.Equal(t, uint64(500000), cfg.TxManagers[0].MaxGasLimit)
+				assert.Equal(t, uint64(20), cfg.TxManagers[0].FeeHistoryBlocks)
+				assert.Equal(t, float64(60), cfg.TxManagers[0].PriorityFeePercentile)
+				assert.Equal(t, float64(3), cfg.TxManagers[0].BaseFeeMultiplier)
+				assert.Equal(t, 0.1, cfg.TxManagers[0].MinPriorityFee)
+				assert.Equal(t, float64(500), cfg.TxManagers[0].MaxFee)
+				assert.Equal(t, uint32(30), cfg.TxManagers[0].ReplacementInterval)
+				assert.Equal(t, float64(15), cfg.TxManagers[0].ReplacementFeeBump)
+				assert.Equal(t, 3, cfg.TxManagers[0].MaxReplacements)
+
+				assert.Equal(t, "key1", cfg.Coordination.EthereumKey)
+				assert.Equal(t, uint32(45), cfg.Coordination.TakeoverDelay)
+				assert.Equal(t, []types.Address{
+					types.MustAddressFromHex("0x6677889900112233445566778899001122334455"),
+					types.MustAddressFromHex("0x7788990011223344556677889900112233445566"),
+				}, cfg.Coordination.Relays)
+
+				assert.Equal(t, "127.0.0.1:9100", cfg.StatusAPI.ListenAddr)
 			},
 		},
 	}
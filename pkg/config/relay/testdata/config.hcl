@@ -5,6 +5,7 @@ median {
   spread          = 1
   expiration      = 300
   interval        = 60
+  feeds_ttl       = 300
 
   feeds = [
     "0x0011223344556677889900112233445566778899",
@@ -37,3 +38,43 @@ optimistic_scribe {
     "0x5566778899001122334455667788990011223344",
   ]
 }
+
+watchtower {
+  ethereum_client = "client3"
+  contract_addr   = "0x3456789012345678901234567890123456789012"
+  interval        = 30
+  lookback_blocks = 500
+}
+
+eip712_domain "BTC/USD" {
+  name               = "Chronicle"
+  version            = "1"
+  chain_id           = 1
+  verifying_contract = "0x2345678901234567890123456789012345678901"
+}
+
+tx_manager "client1" {
+  gas_limit_multiplier    = 1.5
+  max_gas_limit           = 500000
+  fee_history_blocks      = 20
+  priority_fee_percentile = 60
+  base_fee_multiplier     = 3
+  min_priority_fee        = 0.1
+  max_fee                 = 500
+  replacement_interval    = 30
+  replacement_fee_bump    = 15
+  max_replacements        = 3
+}
+
+coordination {
+  ethereum_key   = "key1"
+  takeover_delay = 45
+  relays         = [
+    "0x6677889900112233445566778899001122334455",
+    "0x7788990011223344556677889900112233445566",
+  ]
+}
+
+status_api {
+  listen_addr = "127.0.0.1:9100"
+}
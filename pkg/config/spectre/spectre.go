@@ -29,6 +29,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/relay"
+	relayAPI "github.com/chronicleprotocol/oracle-suite/pkg/relay/api"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/sysmon"
@@ -50,11 +51,14 @@ type Config struct {
 
 // Services returns the services that are configured from the Config struct.
 type Services struct {
-	Relay      *relay.Relay
-	PriceStore *store.Store
-	MuSigStore *relay.MuSigStore
-	Transport  transport.Service
-	Logger     log.Logger
+	Relay        *relay.Relay
+	PriceStore   *store.Store
+	MuSigStore   *relay.MuSigStore
+	Coordinator  *relay.Coordinator
+	FeedKeyStore *relay.FeedKeyStore
+	StatusAPI    *relayAPI.StatusAPI
+	Transport    transport.Service
+	Logger       log.Logger
 
 	supervisor *supervisor.Supervisor
 }
@@ -72,6 +76,15 @@ func (s *Services) Start(ctx context.Context) error {
 		s.Relay,
 		sysmon.New(time.Minute, s.Logger),
 	)
+	if s.Coordinator != nil {
+		s.supervisor.Watch(s.Coordinator)
+	}
+	if s.FeedKeyStore != nil {
+		s.supervisor.Watch(s.FeedKeyStore)
+	}
+	if s.StatusAPI != nil {
+		s.supervisor.Watch(s.StatusAPI)
+	}
 	if l, ok := s.Logger.(supervisor.Service); ok {
 		s.supervisor.Watch(l)
 	}
@@ -102,26 +115,36 @@ func (c *Config) Services(baseLogger log.Logger) (supervisor.Service, error) {
 	}
 	messageMap, err := transport.AllMessagesMap.SelectByTopic(
 		messages.DataPointV1MessageName,
+		messages.DataPointV2MessageName,
+		messages.DataPointBatchV1MessageName,
+		messages.GreetV1MessageName,
 		messages.MuSigStartV1MessageName,
 		messages.MuSigTerminateV1MessageName,
 		messages.MuSigCommitmentV1MessageName,
 		messages.MuSigPartialSignatureV1MessageName,
 		messages.MuSigSignatureV1MessageName,
 		messages.MuSigOptimisticSignatureV1MessageName,
+		messages.RelayIntentV1MessageName,
 	)
 	if err != nil {
 		return nil, err
 	}
+	recoverers, err := c.Spectre.Recoverers()
+	if err != nil {
+		return nil, err
+	}
 	transportSrv, err := c.Transport.Transport(transportConfig.Dependencies{
-		Keys:     keys,
-		Clients:  clients,
-		Messages: messageMap,
-		Logger:   logger,
+		Keys:       keys,
+		Clients:    clients,
+		Messages:   messageMap,
+		Logger:     logger,
+		Recoverers: recoverers,
 	})
 	if err != nil {
 		return nil, err
 	}
 	srvs, err := c.Spectre.Relay(relayConfig.Dependencies{
+		Keys:      keys,
 		Clients:   clients,
 		Transport: transportSrv,
 		Logger:    logger,
@@ -130,10 +153,13 @@ func (c *Config) Services(baseLogger log.Logger) (supervisor.Service, error) {
 		return nil, err
 	}
 	return &Services{
-		Relay:      srvs.Relay,
-		PriceStore: srvs.PriceStore,
-		MuSigStore: srvs.MuSigStore,
-		Transport:  transportSrv,
-		Logger:     logger,
+		Relay:        srvs.Relay,
+		PriceStore:   srvs.PriceStore,
+		MuSigStore:   srvs.MuSigStore,
+		Coordinator:  srvs.Coordinator,
+		FeedKeyStore: srvs.FeedKeyStore,
+		StatusAPI:    srvs.StatusAPI,
+		Transport:    transportSrv,
+		Logger:       logger,
 	}, nil
 }
@@ -18,6 +18,7 @@ package spire
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/defiweb/go-eth/crypto"
@@ -27,6 +28,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
 
+	eip712Config "github.com/chronicleprotocol/oracle-suite/pkg/config/eip712"
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
@@ -36,7 +38,11 @@ import (
 	pkgSupervisor "github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/sysmon"
 	pkgTransport "github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/bridge"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/capture"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 )
 
 // Config is the configuration for Spire.
@@ -68,6 +74,14 @@ type ConfigSpire struct {
 	// prices.
 	EthereumKey string `hcl:"ethereum_key,optional"`
 
+	// EIP712Domains is a list of EIP-712 domains used to verify data points
+	// signed using the EIP-712 signature scheme.
+	EIP712Domains []eip712Config.ConfigDomain `hcl:"eip712_domain,block"`
+
+	// Bridge is an optional configuration of the bridge mode, in which
+	// messages are relayed between the LibP2P and WebAPI transports.
+	Bridge *ConfigBridge `hcl:"bridge,block,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
@@ -78,6 +92,22 @@ type ConfigSpire struct {
 	priceStore *store.Store
 }
 
+type ConfigBridge struct {
+	// Topics is a list of topics relayed between transports. If empty, all
+	// data point topics are relayed: v1 and v2 data points and data point
+	// batches.
+	//
+	// The bridge becomes the author of relayed messages on the transport
+	// level, so only messages that carry their own signatures can be
+	// relayed. Receiving nodes accept messages from bridges only on
+	// the transport.BridgeTopics.
+	Topics []string `hcl:"topics,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
 // ClientServices returns the services that are configured from the Config struct.
 type ClientServices struct {
 	SpireClient *spire.Client
@@ -104,6 +134,40 @@ type StreamServices struct {
 	supervisor *pkgSupervisor.Supervisor
 }
 
+// CaptureServices returns the services that are configured from the Config struct.
+type CaptureServices struct {
+	Transport pkgTransport.Service
+	Capturer  *capture.Capturer
+	Logger    log.Logger
+
+	supervisor *pkgSupervisor.Supervisor
+}
+
+// BridgeServices returns the services that are configured from the Config struct.
+type BridgeServices struct {
+	LibP2P pkgTransport.Service
+	WebAPI pkgTransport.Service
+	Bridge *bridge.Bridge
+	Logger log.Logger
+
+	supervisor *pkgSupervisor.Supervisor
+}
+
+// ReplayServices returns the services that are configured from the Config struct.
+type ReplayServices struct {
+	Transport pkgTransport.Service
+	Messages  pkgTransport.MessageMap
+
+	// SpireAgent and PriceStore are configured only if messages are
+	// replayed to the local transport.
+	SpireAgent *spire.Agent
+	PriceStore *store.Store
+
+	Logger log.Logger
+
+	supervisor *pkgSupervisor.Supervisor
+}
+
 // Start implements the supervisor.Service interface.
 func (s *ClientServices) Start(ctx context.Context) error {
 	if s.supervisor != nil {
@@ -158,6 +222,66 @@ func (s *StreamServices) Wait() <-chan error {
 	return s.supervisor.Wait()
 }
 
+// Start implements the supervisor.Service interface.
+func (s *CaptureServices) Start(ctx context.Context) error {
+	if s.supervisor != nil {
+		return fmt.Errorf("services already started")
+	}
+	s.supervisor = pkgSupervisor.New(s.Logger)
+	s.supervisor.Watch(s.Transport, s.Capturer, sysmon.New(time.Minute, s.Logger))
+	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
+		s.supervisor.Watch(l)
+	}
+	return s.supervisor.Start(ctx)
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *CaptureServices) Wait() <-chan error {
+	return s.supervisor.Wait()
+}
+
+// Start implements the supervisor.Service interface.
+func (s *BridgeServices) Start(ctx context.Context) error {
+	if s.supervisor != nil {
+		return fmt.Errorf("services already started")
+	}
+	s.supervisor = pkgSupervisor.New(s.Logger)
+	s.supervisor.Watch(s.LibP2P, s.WebAPI, s.Bridge, sysmon.New(time.Minute, s.Logger))
+	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
+		s.supervisor.Watch(l)
+	}
+	return s.supervisor.Start(ctx)
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *BridgeServices) Wait() <-chan error {
+	return s.supervisor.Wait()
+}
+
+// Start implements the supervisor.Service interface.
+func (s *ReplayServices) Start(ctx context.Context) error {
+	if s.supervisor != nil {
+		return fmt.Errorf("services already started")
+	}
+	s.supervisor = pkgSupervisor.New(s.Logger)
+	s.supervisor.Watch(s.Transport, sysmon.New(time.Minute, s.Logger))
+	if s.PriceStore != nil {
+		s.supervisor.Watch(s.PriceStore)
+	}
+	if s.SpireAgent != nil {
+		s.supervisor.Watch(s.SpireAgent)
+	}
+	if l, ok := s.Logger.(pkgSupervisor.Service); ok {
+		s.supervisor.Watch(l)
+	}
+	return s.supervisor.Start(ctx)
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *ReplayServices) Wait() <-chan error {
+	return s.supervisor.Wait()
+}
+
 // ClientServices returns the services configured for Spire.
 func (c *Config) ClientServices(baseLogger log.Logger) (*ClientServices, error) {
 	logger, err := c.Logger.Logger(loggerConfig.Dependencies{
@@ -200,15 +324,22 @@ func (c *Config) Services(baseLogger log.Logger) (pkgSupervisor.Service, error)
 	}
 	messageMap, err := pkgTransport.AllMessagesMap.SelectByTopic(
 		messages.DataPointV1MessageName,
+		messages.DataPointV2MessageName,
+		messages.DataPointBatchV1MessageName,
 	)
 	if err != nil {
 		return nil, err
 	}
+	recoverers, err := c.Spire.Recoverers()
+	if err != nil {
+		return nil, err
+	}
 	transport, err := c.Transport.Transport(transportConfig.Dependencies{
-		Keys:     keys,
-		Clients:  clients,
-		Messages: messageMap,
-		Logger:   logger,
+		Keys:       keys,
+		Clients:    clients,
+		Messages:   messageMap,
+		Logger:     logger,
+		Recoverers: recoverers,
 	})
 	if err != nil {
 		return nil, err
@@ -250,11 +381,16 @@ func (c *Config) StreamServices(baseLogger log.Logger, topics ...string) (*Strea
 	if err != nil {
 		return nil, err
 	}
+	recoverers, err := c.Spire.Recoverers()
+	if err != nil {
+		return nil, err
+	}
 	transport, err := c.Transport.Transport(transportConfig.Dependencies{
-		Keys:     keys,
-		Clients:  clients,
-		Messages: messageMap,
-		Logger:   logger,
+		Keys:       keys,
+		Clients:    clients,
+		Messages:   messageMap,
+		Logger:     logger,
+		Recoverers: recoverers,
 	})
 	if err != nil {
 		return nil, err
@@ -265,6 +401,158 @@ func (c *Config) StreamServices(baseLogger log.Logger, topics ...string) (*Strea
 	}, nil
 }
 
+// CaptureServices returns the services used to capture messages received
+// on the given topics to the writer.
+func (c *Config) CaptureServices(baseLogger log.Logger, w io.Writer, topics ...string) (*CaptureServices, error) {
+	services, err := c.StreamServices(baseLogger, topics...)
+	if err != nil {
+		return nil, err
+	}
+	capturer, err := capture.NewCapturer(capture.CapturerConfig{
+		Transport: services.Transport,
+		Topics:    topics,
+		Writer:    capture.NewWriter(w),
+		Logger:    services.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CaptureServices{
+		Transport: services.Transport,
+		Capturer:  capturer,
+		Logger:    services.Logger,
+	}, nil
+}
+
+// ReplayServices returns the services used to replay captured messages.
+//
+// If broadcast is true, messages are broadcast using the configured
+// transport. Otherwise, messages are replayed to the local transport that
+// is used by the price store and the Spire agent, so the state of the store
+// can be inspected using the Spire client.
+func (c *Config) ReplayServices(baseLogger log.Logger, broadcast bool) (*ReplayServices, error) {
+	if broadcast {
+		topics := pkgTransport.AllMessagesMap.Keys()
+		services, err := c.StreamServices(baseLogger, topics...)
+		if err != nil {
+			return nil, err
+		}
+		return &ReplayServices{
+			Transport: services.Transport,
+			Messages:  pkgTransport.AllMessagesMap,
+			Logger:    services.Logger,
+		}, nil
+	}
+	logger, err := c.Logger.Logger(loggerConfig.Dependencies{
+		AppName:    "spire",
+		BaseLogger: baseLogger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	transport := local.New(nil, 0, pkgTransport.AllMessagesMap)
+	priceStore, err := c.Spire.PriceStore(logger, transport)
+	if err != nil {
+		return nil, err
+	}
+	spireAgent, err := c.Spire.ConfigureAgent(logger, transport, priceStore)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayServices{
+		Transport:  transport,
+		Messages:   pkgTransport.AllMessagesMap,
+		SpireAgent: spireAgent,
+		PriceStore: priceStore,
+		Logger:     logger,
+	}, nil
+}
+
+// BridgeServices returns the services used to relay messages between
+// the LibP2P and WebAPI transports. Both transports must be configured.
+func (c *Config) BridgeServices(baseLogger log.Logger) (*BridgeServices, error) {
+	logger, err := c.Logger.Logger(loggerConfig.Dependencies{
+		AppName:    "spire",
+		BaseLogger: baseLogger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := c.Ethereum.KeyRegistry(ethereumConfig.Dependencies{Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+	clients, err := c.Ethereum.ClientRegistry(ethereumConfig.Dependencies{Logger: logger})
+	if err != nil {
+		return nil, err
+	}
+	topics := []string{
+		messages.DataPointV1MessageName,
+		messages.DataPointV2MessageName,
+		messages.DataPointBatchV1MessageName,
+	}
+	if c.Spire.Bridge != nil && len(c.Spire.Bridge.Topics) > 0 {
+		topics = c.Spire.Bridge.Topics
+		for _, topic := range topics {
+			if !sliceutil.Contains(pkgTransport.BridgeTopics, topic) {
+				return nil, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Validation error",
+					Detail:   fmt.Sprintf("Topic %q cannot be relayed by a bridge", topic),
+					Subject:  c.Spire.Bridge.Content.Attributes["topics"].Range.Ptr(),
+				}
+			}
+		}
+	}
+	messageMap, err := pkgTransport.AllMessagesMap.SelectByTopic(topics...)
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   fmt.Sprintf("Invalid bridge topics: %v", err),
+			Subject:  c.Spire.Bridge.Content.Attributes["topics"].Range.Ptr(),
+		}
+	}
+	recoverers, err := c.Spire.Recoverers()
+	if err != nil {
+		return nil, err
+	}
+	deps := transportConfig.Dependencies{
+		Keys:       keys,
+		Clients:    clients,
+		Messages:   messageMap,
+		Logger:     logger,
+		Recoverers: recoverers,
+	}
+	libP2P, err := c.Transport.LibP2PTransport(deps)
+	if err != nil {
+		return nil, err
+	}
+	webAPI, err := c.Transport.WebAPITransport(deps)
+	if err != nil {
+		return nil, err
+	}
+	b, err := bridge.New(bridge.Config{
+		Transports: []pkgTransport.Transport{libP2P, webAPI},
+		Topics:     topics,
+		Logger:     logger,
+	})
+	if err != nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Runtime error",
+			Detail:   fmt.Sprintf("Failed to create the bridge: %v", err),
+			Subject:  &c.Spire.Range,
+		}
+	}
+	return &BridgeServices{
+		LibP2P: libP2P,
+		WebAPI: webAPI,
+		Bridge: b,
+		Logger: logger,
+	}, nil
+}
+
 func (c *ConfigSpire) ConfigureAgent(
 	logger log.Logger,
 	transport pkgTransport.Service,
@@ -315,15 +603,33 @@ func (c *ConfigSpire) ConfigureClient(kr ethereumConfig.KeyRegistry) (*spire.Cli
 	return client, nil
 }
 
+// Recoverers returns the recoverers for all supported signature schemes.
+func (c *ConfigSpire) Recoverers() ([]datapoint.Recoverer, error) {
+	domains, err := eip712Config.Domains(c.EIP712Domains)
+	if err != nil {
+		return nil, err
+	}
+	return []datapoint.Recoverer{
+		signer.NewTickRecoverer(crypto.ECRecoverer),
+		signer.NewTickIntervalRecoverer(crypto.ECRecoverer),
+		signer.NewNumericRecoverer(crypto.ECRecoverer),
+		signer.NewEIP712Recoverer(crypto.ECRecoverer, domains),
+	}, nil
+}
+
 func (c *ConfigSpire) PriceStore(l log.Logger, t pkgTransport.Service) (*store.Store, error) {
 	if c.priceStore != nil {
 		return c.priceStore, nil
 	}
+	recoverers, err := c.Recoverers()
+	if err != nil {
+		return nil, err
+	}
 	priceStore, err := store.New(store.Config{
 		Storage:    store.NewMemoryStorage(),
 		Transport:  t,
 		Models:     c.Pairs,
-		Recoverers: []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)},
+		Recoverers: recoverers,
 		Logger:     l,
 	})
 	if err != nil {
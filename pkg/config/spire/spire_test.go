@@ -59,6 +59,34 @@ func TestConfig(t *testing.T) {
 				require.NotNil(t, services.Logger)
 			},
 		},
+		{
+			name: "bridge",
+			path: "bridge.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				services, err := cfg.BridgeServices(null.New())
+				require.NoError(t, err)
+				require.NotNil(t, services.LibP2P)
+				require.NotNil(t, services.WebAPI)
+				require.NotNil(t, services.Bridge)
+			},
+		},
+		{
+			name: "bridge with a topic that cannot be relayed",
+			path: "bridge.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				cfg.Spire.Bridge.Topics = []string{"event/v1"}
+				_, err := cfg.BridgeServices(null.New())
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "bridge without webapi",
+			path: "config.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				_, err := cfg.BridgeServices(null.New())
+				require.Error(t, err)
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
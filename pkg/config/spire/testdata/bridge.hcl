@@ -0,0 +1,34 @@
+spire {
+  ethereum_key    = "key1"
+  rpc_listen_addr = "127.0.0.1:9101"
+  rpc_agent_addr  = "127.0.0.1:9101"
+  pairs           = ["BTCUSD"]
+  feeds           = ["0x1234567890123456789012345678901234567890"]
+
+  bridge {
+    topics = ["data_point/v1"]
+  }
+}
+
+ethereum {
+  rand_keys = ["key1"]
+}
+
+transport {
+  libp2p {
+    feeds             = ["0x1234567890123456789012345678901234567890"]
+    listen_addrs      = ["/ip4/0.0.0.0/tcp/6000"]
+    disable_discovery = true
+    ethereum_key      = "key1"
+  }
+
+  webapi {
+    feeds        = ["0x1234567890123456789012345678901234567890"]
+    listen_addr  = "localhost:8080"
+    ethereum_key = "key1"
+
+    static_address_book {
+      addresses = ["localhost:8081"]
+    }
+  }
+}
@@ -5,15 +5,24 @@ libp2p {
   bootstrap_addrs    = ["/ip4/0.0.0.0/tcp/7000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"]
   direct_peers_addrs = ["/ip4/0.0.0.0/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"]
   blocked_addrs      = ["/ip4/0.0.0.0/tcp/9000"]
+  bridges            = ["0x6789012345678901234567890123456789012345"]
   disable_discovery  = true
   ethereum_key       = "key"
+
+  peerstore {
+    path      = "/var/lib/spire/peers.json"
+    max_peers = 100
+    ttl       = 3600
+  }
 }
 
 webapi {
   feeds             = ["0x3456789012345678901234567890123456789012", "0x4567890123456789012345678901234567890123"]
   listen_addr       = "localhost:8080"
   socks5_proxy_addr = "localhost:9050"
+  bridges           = ["0x6789012345678901234567890123456789012345"]
   ethereum_key      = "key"
+  serve_pull        = true
 
   ethereum_address_book {
     contract_addr   = "0x5678901234567890123456789012345678901234"
@@ -23,4 +32,9 @@ webapi {
   static_address_book {
     addresses = ["https://example.com/api/v1/endpoint"]
   }
+
+  pull {
+    producers = ["https://example.com"]
+    interval  = 120
+  }
 }
@@ -34,6 +34,7 @@ import (
 	suite "github.com/chronicleprotocol/oracle-suite"
 	"github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/chain"
@@ -44,11 +45,19 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
+// webAPIFlushInterval is the interval at which the WebAPI transport sends
+// messages to consumers.
+const webAPIFlushInterval = time.Minute
+
 type Dependencies struct {
 	Keys     ethereum.KeyRegistry
 	Clients  ethereum.ClientRegistry
 	Messages map[string]transport.Message
 	Logger   log.Logger
+
+	// Recoverers are used by the LibP2P transport to verify signatures of
+	// data point messages. Optional.
+	Recoverers []datapoint.Recoverer
 }
 
 type BootstrapDependencies struct {
@@ -63,8 +72,10 @@ type Config struct {
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
 
-	// Configured transport:
-	transport transport.Service
+	// Configured transports:
+	transport       transport.Service
+	libP2PTransport transport.Service
+	webAPITransport transport.Service
 }
 
 type libP2PConfig struct {
@@ -95,6 +106,11 @@ type libP2PConfig struct {
 	// multiaddress format.
 	BlockedAddrs []string `hcl:"blocked_addrs,optional"`
 
+	// Bridges is a list of Ethereum addresses of bridges that are allowed to
+	// relay messages signed by feeds. Messages from bridges are accepted only
+	// on the transport.BridgeTopics.
+	Bridges []types.Address `hcl:"bridges,optional"`
+
 	// DisableDiscovery disables node discovery. If enabled, the IP address of
 	// a node will not be broadcast to other peers. This option must be used
 	// together with `directPeersAddrs`.
@@ -104,6 +120,27 @@ type libP2PConfig struct {
 	// Required if the transport is used for sending messages.
 	EthereumKey string `hcl:"ethereum_key,optional"`
 
+	// Peerstore is the configuration of the on-disk peerstore. If set, known
+	// peers are saved to a file and redialed after a restart.
+	Peerstore *libP2PPeerstoreConfig `hcl:"peerstore,block,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type libP2PPeerstoreConfig struct {
+	// Path is the path to the file in which known peers are saved.
+	Path string `hcl:"path"`
+
+	// MaxPeers is the maximum number of saved peers. Peers with the highest
+	// score are kept. If zero, 500 peers are kept.
+	MaxPeers int `hcl:"max_peers,optional"`
+
+	// TTL is the time in seconds after which peers that were not seen are
+	// removed. If zero, peers are kept for a week.
+	TTL uint32 `hcl:"ttl,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
@@ -120,6 +157,11 @@ type webAPIConfig struct {
 	// localhost.
 	ListenAddr string `hcl:"listen_addr"`
 
+	// Bridges is a list of Ethereum addresses of bridges that are allowed to
+	// relay messages signed by feeds. Messages from bridges are accepted only
+	// on the transport.BridgeTopics.
+	Bridges []types.Address `hcl:"bridges,optional"`
+
 	// Socks5ProxyAddr is the address of the SOCKS5 proxy server. The address
 	// must be in the format `host:port`.
 	Socks5ProxyAddr string `hcl:"socks5_proxy_addr,optional"`
@@ -137,6 +179,28 @@ type webAPIConfig struct {
 	// StaticAddressBook is the configuration for the static address book.
 	StaticAddressBook *webAPIStaticAddressBook `hcl:"static_address_book,block,optional"`
 
+	// ServePull enables the endpoint that serves the last sent messages to
+	// consumers that pull them. Requires EthereumKey. The endpoint is not
+	// authenticated nor rate limited.
+	ServePull bool `hcl:"serve_pull,optional"`
+
+	// Pull is the configuration for pulling messages from producers.
+	Pull *webAPIPull `hcl:"pull,block,optional"`
+
+	// HCL fields:
+	Range   hcl.Range       `hcl:",range"`
+	Content hcl.BodyContent `hcl:",content"`
+}
+
+type webAPIPull struct {
+	// Producers is the list of addresses of producers from which messages
+	// will be pulled.
+	Producers []string `hcl:"producers"`
+
+	// Interval is the interval in seconds between pulls. If zero, the
+	// interval is 60 seconds. Must not be shorter than the flush interval.
+	Interval uint32 `hcl:"interval,optional"`
+
 	// HCL fields:
 	Range   hcl.Range       `hcl:",range"`
 	Content hcl.BodyContent `hcl:",content"`
@@ -171,14 +235,14 @@ func (c *Config) Transport(d Dependencies) (transport.Service, error) {
 	}
 	var transports []transport.Service
 	if c.LibP2P != nil {
-		t, err := c.configureLibP2P(d)
+		t, err := c.LibP2PTransport(d)
 		if err != nil {
 			return nil, err
 		}
 		transports = append(transports, t)
 	}
 	if c.WebAPI != nil {
-		t, err := c.configureWebAPI(d)
+		t, err := c.WebAPITransport(d)
 		if err != nil {
 			return nil, err
 		}
@@ -200,6 +264,50 @@ func (c *Config) Transport(d Dependencies) (transport.Service, error) {
 	return c.transport, nil
 }
 
+// LibP2PTransport returns the LibP2P transport. Unlike the Transport method,
+// it does not combine the LibP2P transport with other transports.
+func (c *Config) LibP2PTransport(d Dependencies) (transport.Service, error) {
+	if c.libP2PTransport != nil {
+		return c.libP2PTransport, nil
+	}
+	if c.LibP2P == nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "LibP2P transport must be configured.",
+			Subject:  &c.Range,
+		}
+	}
+	t, err := c.configureLibP2P(d)
+	if err != nil {
+		return nil, err
+	}
+	c.libP2PTransport = t
+	return t, nil
+}
+
+// WebAPITransport returns the WebAPI transport. Unlike the Transport method,
+// it does not combine the WebAPI transport with other transports.
+func (c *Config) WebAPITransport(d Dependencies) (transport.Service, error) {
+	if c.webAPITransport != nil {
+		return c.webAPITransport, nil
+	}
+	if c.WebAPI == nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "WebAPI transport must be configured.",
+			Subject:  &c.Range,
+		}
+	}
+	t, err := c.configureWebAPI(d)
+	if err != nil {
+		return nil, err
+	}
+	c.webAPITransport = t
+	return t, nil
+}
+
 func (c *Config) LibP2PBootstrap(d BootstrapDependencies) (transport.Service, error) {
 	if c.LibP2P == nil {
 		return nil, &hcl.Diagnostic{
@@ -308,14 +416,52 @@ func (c *Config) configureWebAPI(d Dependencies) (transport.Service, error) {
 		}
 	}
 
+	if c.WebAPI.ServePull && key == nil {
+		return nil, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Validation error",
+			Detail:   "Ethereum key must be configured to serve pulled messages.",
+			Subject:  c.WebAPI.Content.Attributes["serve_pull"].Range.Ptr(),
+		}
+	}
+
+	// Configure pull mode:
+	var (
+		pullAddressBook webapi.AddressBook
+		pullTicker      *timeutil.Ticker
+	)
+	if c.WebAPI.Pull != nil {
+		interval := time.Minute
+		if c.WebAPI.Pull.Interval > 0 {
+			interval = time.Second * time.Duration(c.WebAPI.Pull.Interval)
+		}
+		if interval < webAPIFlushInterval {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail: fmt.Sprintf(
+					"Pull interval must not be shorter than the flush interval (%d seconds).",
+					int(webAPIFlushInterval.Seconds()),
+				),
+				Subject: c.WebAPI.Pull.Content.Attributes["interval"].Range.Ptr(),
+			}
+		}
+		pullAddressBook = webapi.NewStaticAddressBook(c.WebAPI.Pull.Producers)
+		pullTicker = timeutil.NewTicker(interval)
+	}
+
 	// Configure transport:
 	webapiTransport, err := webapi.New(webapi.Config{
 		ListenAddr:      c.WebAPI.ListenAddr,
 		AddressBook:     addressBook,
 		Topics:          d.Messages,
 		AuthorAllowlist: c.WebAPI.Feeds,
-		FlushTicker:     timeutil.NewTicker(time.Minute),
+		BridgeAllowlist: c.WebAPI.Bridges,
+		FlushTicker:     timeutil.NewTicker(webAPIFlushInterval),
 		Signer:          key,
+		ServePull:       c.WebAPI.ServePull,
+		PullAddressBook: pullAddressBook,
+		PullTicker:      pullTicker,
 		Client:          httpClient,
 		Logger:          d.Logger,
 	})
@@ -363,12 +509,27 @@ func (c *Config) configureLibP2P(d Dependencies) (transport.Service, error) {
 		DirectPeersAddrs: c.LibP2P.DirectPeersAddrs,
 		BlockedAddrs:     c.LibP2P.BlockedAddrs,
 		AuthorAllowlist:  c.LibP2P.Feeds,
+		BridgeAllowlist:  c.LibP2P.Bridges,
 		Discovery:        !c.LibP2P.DisableDiscovery,
 		Signer:           key,
+		Recoverers:       d.Recoverers,
 		Logger:           d.Logger,
 		AppName:          "spire",
 		AppVersion:       suite.Version,
 	}
+	if c.LibP2P.Peerstore != nil {
+		if c.LibP2P.Peerstore.MaxPeers < 0 {
+			return nil, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Validation error",
+				Detail:   "Maximum number of peers must not be negative",
+				Subject:  c.LibP2P.Peerstore.Content.Attributes["max_peers"].Range.Ptr(),
+			}
+		}
+		cfg.PeerstorePath = c.LibP2P.Peerstore.Path
+		cfg.PeerstoreMaxPeers = c.LibP2P.Peerstore.MaxPeers
+		cfg.PeerstoreTTL = time.Duration(c.LibP2P.Peerstore.TTL) * time.Second
+	}
 	libP2PTransport, err := libp2p.New(cfg)
 	if err != nil {
 		return nil, &hcl.Diagnostic{
@@ -34,14 +34,19 @@ func TestConfig(t *testing.T) {
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/7000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"}, cfg.LibP2P.BootstrapAddrs)
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/8000/p2p/12D3KooWRfYU5FaY9SmJcRD5Ku7c1XMBRqV6oM4nsnGQ1QRakSJi"}, cfg.LibP2P.DirectPeersAddrs)
 				assert.Equal(t, []string{"/ip4/0.0.0.0/tcp/9000"}, cfg.LibP2P.BlockedAddrs)
+				assert.Equal(t, "0x6789012345678901234567890123456789012345", cfg.LibP2P.Bridges[0].String())
 				assert.Equal(t, true, cfg.LibP2P.DisableDiscovery)
 				assert.Equal(t, "key", cfg.LibP2P.EthereumKey)
+				assert.Equal(t, "/var/lib/spire/peers.json", cfg.LibP2P.Peerstore.Path)
+				assert.Equal(t, 100, cfg.LibP2P.Peerstore.MaxPeers)
+				assert.Equal(t, uint32(3600), cfg.LibP2P.Peerstore.TTL)
 
 				// WebAPI
 				assert.Equal(t, "0x3456789012345678901234567890123456789012", cfg.WebAPI.Feeds[0].String())
 				assert.Equal(t, "0x4567890123456789012345678901234567890123", cfg.WebAPI.Feeds[1].String())
 				assert.Equal(t, "localhost:8080", cfg.WebAPI.ListenAddr)
 				assert.Equal(t, "localhost:9050", cfg.WebAPI.Socks5ProxyAddr)
+				assert.Equal(t, "0x6789012345678901234567890123456789012345", cfg.WebAPI.Bridges[0].String())
 				assert.Equal(t, "key", cfg.WebAPI.EthereumKey)
 				assert.NotNil(t, cfg.WebAPI.EthereumAddressBook)
 				assert.NotNil(t, cfg.WebAPI.StaticAddressBook)
@@ -52,6 +57,11 @@ func TestConfig(t *testing.T) {
 
 				// StaticAddressBook
 				assert.Equal(t, []string{"https://example.com/api/v1/endpoint"}, cfg.WebAPI.StaticAddressBook.Addresses)
+
+				// Pull
+				assert.True(t, cfg.WebAPI.ServePull)
+				assert.Equal(t, []string{"https://example.com"}, cfg.WebAPI.Pull.Producers)
+				assert.Equal(t, uint32(120), cfg.WebAPI.Pull.Interval)
 			},
 		},
 		{
@@ -76,6 +86,23 @@ func TestConfig(t *testing.T) {
 				assert.NotNil(t, transport)
 			},
 		},
+		{
+			name: "pull interval shorter than flush interval",
+			path: "config.hcl",
+			test: func(t *testing.T, cfg *Config) {
+				key := &mocks.Key{}
+				key.On("Address").Return(types.AddressFromHex("0x1234567890123456789012345678901234567890"))
+				cfg.WebAPI.Pull.Interval = 30
+				_, err := cfg.Transport(Dependencies{
+					Keys:     ethereum.KeyRegistry{"key": key},
+					Clients:  ethereum.ClientRegistry{"client": &mocks.RPC{}},
+					Messages: nil,
+					Logger:   null.New(),
+				})
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "Pull interval must not be shorter than the flush interval")
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.path, func(t *testing.T) {
@@ -18,6 +18,7 @@ package datapoint
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -58,8 +59,37 @@ type Provider interface {
 	Models(ctx context.Context, models ...string) (map[string]Model, error)
 }
 
+// SignatureScheme identifies the method used to sign a data point.
+type SignatureScheme uint32
+
+const (
+	// SignatureSchemeLegacy is a scheme used by Oracle contracts. The packed
+	// data point is hashed and signed as an Ethereum personal message.
+	SignatureSchemeLegacy SignatureScheme = 0
+
+	// SignatureSchemeEIP712 is a scheme that uses EIP-712 typed data. The
+	// signature is bound to a domain that contains the chain ID and the
+	// verifying contract address.
+	SignatureSchemeEIP712 SignatureScheme = 1
+)
+
+// String implements the fmt.Stringer interface.
+func (s SignatureScheme) String() string {
+	switch s {
+	case SignatureSchemeLegacy:
+		return "legacy"
+	case SignatureSchemeEIP712:
+		return "eip712"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint32(s))
+	}
+}
+
 // Signer is responsible for signing data points.
 type Signer interface {
+	// Scheme returns the signature scheme used by the signer.
+	Scheme() SignatureScheme
+
 	// Supports returns true if the signer supports the given data point.
 	Supports(ctx context.Context, data Point) bool
 
@@ -67,12 +97,21 @@ type Signer interface {
 	Sign(ctx context.Context, model string, data Point) (*types.Signature, error)
 }
 
+// ErrUnsupportedModel is returned by signers and recoverers that support
+// the data point value, but are not configured for the data model.
+var ErrUnsupportedModel = errors.New("unsupported data model")
+
 // Recoverer is responsible for recovering addresses from signatures.
 type Recoverer interface {
+	// Scheme returns the signature scheme supported by the recoverer.
+	Scheme() SignatureScheme
+
 	// Supports returns true if the recoverer supports the given data point.
 	Supports(ctx context.Context, data Point) bool
 
-	// Recover recovers the address from the given signature.
+	// Recover recovers the address from the given signature. If the
+	// recoverer is not configured for the model, the returned error wraps
+	// ErrUnsupportedModel.
 	Recover(ctx context.Context, model string, data Point, signature types.Signature) (*types.Address, error)
 }
 
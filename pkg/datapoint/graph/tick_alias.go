@@ -10,7 +10,8 @@ import (
 
 // TickAliasNode is a node that aliases another tick's asset pair.
 //
-// It expects one node that returns a data point with an value.Tick value.
+// It expects one node that returns a data point with an value.Tick or
+// value.TickInterval value.
 type TickAliasNode struct {
 	alias value.Pair
 	node  Node
@@ -57,17 +58,23 @@ func (n *TickAliasNode) DataPoint() datapoint.Point {
 		}
 	}
 	point := n.node.DataPoint()
-	tick, ok := point.Value.(value.Tick)
-	if !ok {
+	var alias value.Value
+	switch tick := point.Value.(type) {
+	case value.Tick:
+		tick.Pair = n.alias
+		alias = tick
+	case value.TickInterval:
+		tick.Pair = n.alias
+		alias = tick
+	default:
 		return datapoint.Point{
 			Time:  time.Now(),
 			Meta:  n.Meta(),
-			Error: fmt.Errorf("invalid data point, expected value.Tick"),
+			Error: fmt.Errorf("invalid data point, expected value.Tick or value.TickInterval"),
 		}
 	}
-	tick.Pair = n.alias
 	return datapoint.Point{
-		Value:     tick,
+		Value:     alias,
 		Time:      point.Time,
 		SubPoints: []datapoint.Point{point},
 		Meta:      n.Meta(),
@@ -13,7 +13,10 @@ import (
 // TickIndirectNode is a node that calculates cross rate from the list of price
 // ticks from its nodes.
 //
-// It expects that all nodes return data points with value.Tick values.
+// It expects that all nodes return data points with value.Tick or
+// value.TickInterval values. If any of the nodes returns a value.TickInterval,
+// the result is also a value.TickInterval, with bounds calculated from
+// the bounds of all nodes.
 //
 // The order of nodes is important because prices are calculated from first
 // to last. Adjacent nodes must have one common asset.
@@ -52,11 +55,11 @@ func (n *TickIndirectNode) DataPoint() datapoint.Point {
 				Error: fmt.Errorf("invalid data point: %w", err),
 			}
 		}
-		if _, ok := point.Value.(value.Tick); !ok {
+		if _, _, ok := tickInterval(point.Value); !ok {
 			return datapoint.Point{
 				Time:  time.Now(),
 				Meta:  meta,
-				Error: fmt.Errorf("invalid data point value type: %T, expected value.Tick", point.Value),
+				Error: fmt.Errorf("invalid data point value type: %T, expected value.Tick or value.TickInterval", point.Value),
 			}
 		}
 	}
@@ -89,47 +92,42 @@ func crossRate(points []datapoint.Point) (datapoint.Point, error) {
 	for i := 0; i < len(points)-1; i++ {
 		ap := points[i]
 		bp := points[i+1]
-		at := ap.Value.(value.Tick)
-		bt := bp.Value.(value.Tick)
+		at, aIsInterval, _ := tickInterval(ap.Value)
+		bt, bIsInterval, _ := tickInterval(bp.Value)
 		var (
-			pair  value.Pair
-			price *bn.FloatNumber
+			pair value.Pair
+			res  value.TickInterval
 		)
 		switch {
 		case at.Pair.Quote == bt.Pair.Quote: // A/C, B/C
 			pair.Base = at.Pair.Base
 			pair.Quote = bt.Pair.Base
-			if bt.Price.Sign() > 0 {
-				price = at.Price.Div(bt.Price)
-			} else {
-				price = bn.Float(0)
-			}
+			res = divInterval(at, bt)
 		case at.Pair.Base == bt.Pair.Base: // C/A, C/B
 			pair.Base = at.Pair.Quote
 			pair.Quote = bt.Pair.Quote
-			if at.Price.Sign() > 0 {
-				price = bt.Price.Div(at.Price)
-			} else {
-				price = bn.Float(0)
-			}
+			res = divInterval(bt, at)
 		case at.Pair.Quote == bt.Pair.Base: // A/C, C/B
 			pair.Base = at.Pair.Base
 			pair.Quote = bt.Pair.Quote
-			price = at.Price.Mul(bt.Price)
+			res = mulInterval(at, bt)
 		case at.Pair.Base == bt.Pair.Quote: // C/A, B/C
 			pair.Base = at.Pair.Quote
 			pair.Quote = bt.Pair.Base
-			if at.Price.Sign() > 0 && bt.Price.Sign() > 0 {
-				price = bn.Float(1).Div(bt.Price).Div(at.Price)
-			} else {
-				price = bn.Float(0)
-			}
+			one := value.TickInterval{Price: bn.Float(1), Lower: bn.Float(1), Upper: bn.Float(1), Sources: bt.Sources}
+			res = divInterval(divInterval(one, bt), at)
 		default:
 			return ap, fmt.Errorf("unable to calculate cross rate for %s and %s", at.Pair, bt.Pair)
 		}
-		bt.Pair = pair
-		bt.Price = price
-		bp.Value = bt
+		res.Pair = pair
+		if aIsInterval || bIsInterval {
+			bp.Value = res
+		} else {
+			tick := bp.Value.(value.Tick)
+			tick.Pair = pair
+			tick.Price = res.Price
+			bp.Value = tick
+		}
 		if ap.Time.Before(bp.Time) {
 			bp.Time = ap.Time
 		}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// tickInterval converts a tick or a tick interval to a tick interval.
+//
+// Ticks are converted to intervals with both bounds equal to the price.
+// The second return value is true if the value is a tick interval, the third
+// one is false if the value is neither a tick nor a tick interval.
+func tickInterval(v value.Value) (value.TickInterval, bool, bool) {
+	switch v := v.(type) {
+	case value.Tick:
+		return v.Interval(), false, true
+	case value.TickInterval:
+		return v, true, true
+	}
+	return value.TickInterval{}, false, false
+}
+
+// zeroInterval returns an interval with zero price and bounds.
+func zeroInterval(i value.TickInterval) value.TickInterval {
+	i.Price = bn.Float(0)
+	i.Lower = bn.Float(0)
+	i.Upper = bn.Float(0)
+	return i
+}
+
+// mulInterval multiplies two intervals with positive bounds.
+func mulInterval(x, y value.TickInterval) value.TickInterval {
+	x.Price = x.Price.Mul(y.Price)
+	x.Lower = x.Lower.Mul(y.Lower)
+	x.Upper = x.Upper.Mul(y.Upper)
+	x.Sources = minSources(x.Sources, y.Sources)
+	return x
+}
+
+// divInterval divides two intervals with positive bounds. If the divisor
+// is zero or negative, an interval with zero price and bounds is returned.
+func divInterval(x, y value.TickInterval) value.TickInterval {
+	if y.Price.Sign() <= 0 || y.Lower.Sign() <= 0 {
+		return zeroInterval(x)
+	}
+	x.Price = x.Price.Div(y.Price)
+	x.Lower = x.Lower.Div(y.Upper)
+	x.Upper = x.Upper.Div(y.Lower)
+	x.Sources = minSources(x.Sources, y.Sources)
+	return x
+}
+
+// minSources returns the smaller number of sources.
+func minSources(x, y uint32) uint32 {
+	if x < y {
+		return x
+	}
+	return y
+}
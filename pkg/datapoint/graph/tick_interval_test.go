@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func intervalNode(base, quote string, price, lower, upper float64, sources uint32) *mockNode {
+	n := new(mockNode)
+	n.On("DataPoint").Return(datapoint.Point{
+		Value: value.TickInterval{
+			Pair:    value.Pair{Base: base, Quote: quote},
+			Price:   bn.Float(price),
+			Lower:   bn.Float(lower),
+			Upper:   bn.Float(upper),
+			Sources: sources,
+		},
+		Time: time.Unix(1234567890, 0),
+	})
+	return n
+}
+
+func tickNode(base, quote string, price float64) *mockNode {
+	n := new(mockNode)
+	n.On("DataPoint").Return(datapoint.Point{
+		Value: value.Tick{
+			Pair:      value.Pair{Base: base, Quote: quote},
+			Price:     bn.Float(price),
+			Volume24h: bn.Float(0),
+		},
+		Time: time.Unix(1234567890, 0),
+	})
+	return n
+}
+
+func TestTickMedianNode_Interval(t *testing.T) {
+	node := NewTickMedianNode(3)
+	require.NoError(t, node.AddNodes(
+		intervalNode("A", "B", 10, 9, 11, 1),
+		intervalNode("A", "B", 12, 11, 14, 2),
+		tickNode("A", "B", 8),
+	))
+	point := node.DataPoint()
+	require.NoError(t, point.Validate())
+	tick := point.Value.(value.TickInterval)
+	assert.Equal(t, 10.0, tick.Price.Float64())
+	assert.Equal(t, 8.0, tick.Lower.Float64())
+	assert.Equal(t, 14.0, tick.Upper.Float64())
+	assert.Equal(t, uint32(4), tick.Sources)
+}
+
+func TestTickMedianNode_OnlyTicks(t *testing.T) {
+	node := NewTickMedianNode(2)
+	require.NoError(t, node.AddNodes(tickNode("A", "B", 8), tickNode("A", "B", 10)))
+	_, ok := node.DataPoint().Value.(value.Tick)
+	assert.True(t, ok)
+}
+
+func TestTickIndirectNode_Interval(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []Node
+		pair    value.Pair
+		price   float64
+		lower   float64
+		upper   float64
+		sources uint32
+	}{
+		{
+			name:    "A/C, C/B",
+			nodes:   []Node{intervalNode("A", "C", 10, 8, 12, 3), intervalNode("C", "B", 2, 1, 4, 2)},
+			pair:    value.Pair{Base: "A", Quote: "B"},
+			price:   20,
+			lower:   8,
+			upper:   48,
+			sources: 2,
+		},
+		{
+			name:    "A/C, B/C",
+			nodes:   []Node{intervalNode("A", "C", 10, 8, 12, 3), tickNode("B", "C", 2)},
+			pair:    value.Pair{Base: "A", Quote: "B"},
+			price:   5,
+			lower:   4,
+			upper:   6,
+			sources: 1,
+		},
+		{
+			name:    "C/A, C/B",
+			nodes:   []Node{tickNode("C", "A", 2), intervalNode("C", "B", 10, 8, 12, 3)},
+			pair:    value.Pair{Base: "A", Quote: "B"},
+			price:   5,
+			lower:   4,
+			upper:   6,
+			sources: 1,
+		},
+		{
+			name:    "C/A, B/C",
+			nodes:   []Node{intervalNode("C", "A", 2, 1, 4, 2), intervalNode("B", "C", 4, 2, 8, 3)},
+			pair:    value.Pair{Base: "A", Quote: "B"},
+			price:   0.125,
+			lower:   0.03125,
+			upper:   0.5,
+			sources: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := NewTickIndirectNode()
+			require.NoError(t, node.AddNodes(tt.nodes...))
+			point := node.DataPoint()
+			require.NoError(t, point.Validate())
+			tick := point.Value.(value.TickInterval)
+			assert.Equal(t, tt.pair, tick.Pair)
+			assert.InDelta(t, tt.price, tick.Price.Float64(), 1e-9)
+			assert.InDelta(t, tt.lower, tick.Lower.Float64(), 1e-9)
+			assert.InDelta(t, tt.upper, tick.Upper.Float64(), 1e-9)
+			assert.Equal(t, tt.sources, tick.Sources)
+		})
+	}
+}
+
+func TestTickInvertNode_Interval(t *testing.T) {
+	node := NewTickInvertNode()
+	require.NoError(t, node.AddNodes(intervalNode("A", "B", 4, 2, 8, 3)))
+	point := node.DataPoint()
+	require.NoError(t, point.Validate())
+	tick := point.Value.(value.TickInterval)
+	assert.Equal(t, value.Pair{Base: "B", Quote: "A"}, tick.Pair)
+	assert.Equal(t, 0.25, tick.Price.Float64())
+	assert.Equal(t, 0.125, tick.Lower.Float64())
+	assert.Equal(t, 0.5, tick.Upper.Float64())
+	assert.Equal(t, uint32(3), tick.Sources)
+}
+
+func TestTickAliasNode_Interval(t *testing.T) {
+	node := NewTickAliasNode(value.Pair{Base: "X", Quote: "Y"})
+	require.NoError(t, node.AddNodes(intervalNode("A", "B", 4, 2, 8, 3)))
+	tick := node.DataPoint().Value.(value.TickInterval)
+	assert.Equal(t, value.Pair{Base: "X", Quote: "Y"}, tick.Pair)
+	assert.Equal(t, 4.0, tick.Price.Float64())
+}
@@ -12,7 +12,9 @@ import (
 // and the price is 1000, then the asset pair will be USD/BTC and the price
 // will be 0.001.
 //
-// It expects one node that returns a data point with an value.Tick value.
+// It expects one node that returns a data point with an value.Tick or
+// value.TickInterval value. In case of a tick interval, the bounds are
+// inverted as well.
 type TickInvertNode struct {
 	node Node
 }
@@ -58,20 +60,28 @@ func (n *TickInvertNode) DataPoint() datapoint.Point {
 		}
 	}
 	point := n.node.DataPoint()
-	tick, ok := point.Value.(value.Tick)
-	if !ok {
+	switch tick := point.Value.(type) {
+	case value.Tick:
+		tick.Pair = tick.Pair.Invert()
+		if tick.Price.Sign() != 0 {
+			tick.Price = tick.Price.Inv()
+			tick.Volume24h = tick.Volume24h.Div(tick.Price)
+		}
+		point.Value = tick
+	case value.TickInterval:
+		tick.Pair = tick.Pair.Invert()
+		if tick.Price.Sign() != 0 && tick.Lower.Sign() != 0 && tick.Upper.Sign() != 0 {
+			tick.Price = tick.Price.Inv()
+			tick.Lower, tick.Upper = tick.Upper.Inv(), tick.Lower.Inv()
+		}
+		point.Value = tick
+	default:
 		return datapoint.Point{
 			Time:  point.Time,
 			Meta:  n.Meta(),
-			Error: fmt.Errorf("invalid data point, expected value.Tick"),
+			Error: fmt.Errorf("invalid data point, expected value.Tick or value.TickInterval"),
 		}
 	}
-	tick.Pair = tick.Pair.Invert()
-	if tick.Price.Sign() != 0 {
-		tick.Price = tick.Price.Inv()
-		tick.Volume24h = tick.Volume24h.Div(tick.Price)
-	}
-	point.Value = tick
 	return point
 }
 
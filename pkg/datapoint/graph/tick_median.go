@@ -14,7 +14,10 @@ import (
 // TickMedianNode is a node that calculates median value from its
 // nodes.
 //
-// It expects that all nodes return data points with value.Tick values.
+// It expects that all nodes return data points with value.Tick or
+// value.TickInterval values. If any of the nodes returns a value.TickInterval,
+// the result is also a value.TickInterval, with bounds spanning the bounds
+// of all nodes.
 type TickMedianNode struct {
 	min   int
 	nodes []Node
@@ -44,10 +47,11 @@ func (n *TickMedianNode) Nodes() []Node {
 // DataPoint implements the Node interface.
 func (n *TickMedianNode) DataPoint() datapoint.Point {
 	var (
-		tm     time.Time
-		points []datapoint.Point
-		ticks  []value.Tick
-		prices []*bn.FloatNumber
+		tm        time.Time
+		points    []datapoint.Point
+		ticks     []value.TickInterval
+		prices    []*bn.FloatNumber
+		intervals bool
 	)
 
 	// Collect all data points from nodes and that can be used to calculate
@@ -64,14 +68,15 @@ func (n *TickMedianNode) DataPoint() datapoint.Point {
 		if err := point.Validate(); err != nil {
 			continue
 		}
-		tick, ok := point.Value.(value.Tick)
+		tick, isInterval, ok := tickInterval(point.Value)
 		if !ok {
 			return datapoint.Point{
 				Time:  time.Now(),
 				Meta:  n.Meta(),
-				Error: fmt.Errorf("invalid data point value, expected value.Tick"),
+				Error: fmt.Errorf("invalid data point value, expected value.Tick or value.TickInterval"),
 			}
 		}
+		intervals = intervals || isInterval
 		if len(ticks) > 0 && !ticks[len(ticks)-1].Pair.Equal(tick.Pair) {
 			return datapoint.Point{
 				Time:  time.Now(),
@@ -93,6 +98,16 @@ func (n *TickMedianNode) DataPoint() datapoint.Point {
 		}
 	}
 
+	// Return median tick interval.
+	if intervals {
+		return datapoint.Point{
+			Value:     medianInterval(ticks, median(prices)),
+			Time:      tm,
+			SubPoints: points,
+			Meta:      n.Meta(),
+		}
+	}
+
 	// Return median tick.
 	return datapoint.Point{
 		Value: value.Tick{
@@ -114,6 +129,27 @@ func (n *TickMedianNode) Meta() map[string]any {
 	}
 }
 
+// medianInterval returns a tick interval with the given median price and
+// bounds spanning the bounds of all ticks.
+func medianInterval(ticks []value.TickInterval, price *bn.FloatNumber) value.TickInterval {
+	res := value.TickInterval{
+		Pair:  ticks[0].Pair,
+		Price: price,
+		Lower: ticks[0].Lower,
+		Upper: ticks[0].Upper,
+	}
+	for _, t := range ticks {
+		if t.Lower.Cmp(res.Lower) < 0 {
+			res.Lower = t.Lower
+		}
+		if t.Upper.Cmp(res.Upper) > 0 {
+			res.Upper = t.Upper
+		}
+		res.Sources += t.Sources
+	}
+	return res
+}
+
 func median(xs []*bn.FloatNumber) *bn.FloatNumber {
 	count := len(xs)
 	if count == 0 {
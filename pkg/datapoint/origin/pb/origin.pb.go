@@ -83,6 +83,85 @@ func (x *Tick) GetVolume24H() []byte {
 	return nil
 }
 
+type TickInterval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pair    string `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`
+	Price   []byte `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Lower   []byte `protobuf:"bytes,3,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper   []byte `protobuf:"bytes,4,opt,name=upper,proto3" json:"upper,omitempty"`
+	Sources uint32 `protobuf:"varint,5,opt,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *TickInterval) Reset() {
+	*x = TickInterval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_origin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TickInterval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickInterval) ProtoMessage() {}
+
+func (x *TickInterval) ProtoReflect() protoreflect.Message {
+	mi := &file_origin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickInterval.ProtoReflect.Descriptor instead.
+func (*TickInterval) Descriptor() ([]byte, []int) {
+	return file_origin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TickInterval) GetPair() string {
+	if x != nil {
+		return x.Pair
+	}
+	return ""
+}
+
+func (x *TickInterval) GetPrice() []byte {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *TickInterval) GetLower() []byte {
+	if x != nil {
+		return x.Lower
+	}
+	return nil
+}
+
+func (x *TickInterval) GetUpper() []byte {
+	if x != nil {
+		return x.Upper
+	}
+	return nil
+}
+
+func (x *TickInterval) GetSources() uint32 {
+	if x != nil {
+		return x.Sources
+	}
+	return 0
+}
+
 var File_origin_proto protoreflect.FileDescriptor
 
 var file_origin_proto_rawDesc = []byte{
@@ -91,12 +170,20 @@ var file_origin_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
 	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
 	0x12, 0x1c, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x42, 0x3e,
-	0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x72,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x32, 0x34, 0x68, 0x22, 0x7e,
+	0x0a, 0x0c, 0x54, 0x69, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x69, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x75,
+	0x70, 0x70, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x42, 0x43,
+	0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x68, 0x72,
 	0x6f, 0x6e, 0x69, 0x63, 0x6c, 0x65, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x6f,
 	0x72, 0x61, 0x63, 0x6c, 0x65, 0x2d, 0x73, 0x75, 0x69, 0x74, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x64, 0x61, 0x74, 0x61, 0x2f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x61, 0x74, 0x61, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e,
+	0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -111,9 +198,10 @@ func file_origin_proto_rawDescGZIP() []byte {
 	return file_origin_proto_rawDescData
 }
 
-var file_origin_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_origin_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_origin_proto_goTypes = []interface{}{
-	(*Tick)(nil), // 0: Tick
+	(*Tick)(nil),         // 0: Tick
+	(*TickInterval)(nil), // 1: TickInterval
 }
 var file_origin_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -141,6 +229,18 @@ func file_origin_proto_init() {
 				return nil
 			}
 		}
+		file_origin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TickInterval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -148,7 +248,7 @@ func file_origin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_origin_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -40,6 +40,10 @@ type TickGenericJQConfig struct {
 	//   - price - a price
 	//   - time - a timestamp (optional)
 	//   - volume - a 24h volume (optional)
+	//   - bid - a best bid price (optional)
+	//   - ask - a best ask price (optional)
+	//
+	// Bid and ask prices are used only if TickIntervals is enabled.
 	//
 	// The JQ query may contain the following variables:
 	//   - $lcbase - lower case base asset
@@ -52,6 +56,18 @@ type TickGenericJQConfig struct {
 	// that can be parsed as a time.
 	Query string
 
+	// TickIntervals enables tick intervals. If enabled and both bid and ask
+	// prices are returned by the query, the data point value is
+	// a value.TickInterval with the bid and ask prices as bounds, and the
+	// price is optional, in which case the mid price is used. The volume is
+	// not included in tick intervals.
+	//
+	// Nodes that do not support the value.TickInterval type are not able to
+	// decode such data points, so it is disabled by default. Signatures of
+	// tick intervals cover their bounds, so they cannot be verified by Oracle
+	// contracts and relays do not use them.
+	TickIntervals bool
+
 	// Headers is a set of TickGenericHTTP headers that are sent with each request.
 	Headers http.Header
 
@@ -69,9 +85,10 @@ type TickGenericJQConfig struct {
 type TickGenericJQ struct {
 	http *TickGenericHTTP
 
-	rawQuery string
-	query    *gojq.Code
-	logger   log.Logger
+	rawQuery      string
+	query         *gojq.Code
+	tickIntervals bool
+	logger        log.Logger
 }
 
 // NewTickGenericJQ creates a new TickGenericJQ instance.
@@ -96,6 +113,14 @@ type TickGenericJQ struct {
 //   - price - a price
 //   - time - a timestamp (optional)
 //   - volume - a 24h volume (optional)
+//   - bid - a best bid price (optional)
+//   - ask - a best ask price (optional)
+//
+// If tick intervals are enabled and both bid and ask prices are returned,
+// the data point value is a value.TickInterval with the bid and ask prices
+// as bounds, and the price is optional, in which case the mid price is used.
+// The volume is not included in tick intervals. Otherwise, bid and ask
+// prices are ignored.
 //
 // The JQ query may contain the following variables:
 //   - $lcbase - lower case base asset
@@ -149,6 +174,7 @@ func NewTickGenericJQ(config TickGenericJQConfig) (*TickGenericJQ, error) {
 	jq.http = gh
 	jq.rawQuery = config.Query
 	jq.query = compiled
+	jq.tickIntervals = config.TickIntervals
 	jq.logger = config.Logger.WithField("tag", TickGenericJQLoggerTag)
 	return jq, nil
 }
@@ -185,6 +211,7 @@ func (g *TickGenericJQ) handle(
 
 		point := datapoint.Point{Time: time.Now()}
 		tick := value.Tick{Pair: pair}
+		var bid, ask *bn.FloatNumber
 		iter := g.query.RunWithContext(
 			ctx,
 			decoded,
@@ -220,6 +247,10 @@ func (g *TickGenericJQ) handle(
 					tick.Price = bn.Float(v)
 				case "volume":
 					tick.Volume24h = bn.Float(v)
+				case "bid":
+					bid = bn.Float(v)
+				case "ask":
+					ask = bn.Float(v)
 				case "time":
 					if tm, ok := anyToTime(v); ok {
 						point.Time = tm
@@ -231,12 +262,47 @@ func (g *TickGenericJQ) handle(
 		case int, int32, int64, uint, uint32, uint64, float32, float64:
 			tick.Price = bn.Float(v)
 		}
-		point.Value = tick
+		if g.tickIntervals {
+			point.Value = tickValue(tick, bid, ask)
+		} else {
+			point.Value = tick
+		}
 		points[pair] = point
 	}
 	return points, nil
 }
 
+// tickValue returns a value.TickInterval with the bid and ask prices as
+// bounds if both are known, otherwise it returns the tick. If the tick price
+// is not set, the mid price is used. The bounds are widened if the price is
+// outside the bid-ask range, e.g. when the last trade price is used.
+func tickValue(tick value.Tick, bid, ask *bn.FloatNumber) value.Value {
+	if bid == nil || ask == nil {
+		return tick
+	}
+	lower, upper := bid, ask
+	if lower.Cmp(upper) > 0 {
+		lower, upper = upper, lower
+	}
+	price := tick.Price
+	if price == nil {
+		price = lower.Add(upper).Div(bn.Float(2))
+	}
+	if price.Cmp(lower) < 0 {
+		lower = price
+	}
+	if price.Cmp(upper) > 0 {
+		upper = price
+	}
+	return value.TickInterval{
+		Pair:    tick.Pair,
+		Price:   price,
+		Lower:   lower,
+		Upper:   upper,
+		Sources: 1,
+	}
+}
+
 // anyToTime converts an arbitrary value to a time.Time.
 func anyToTime(v any) (time.Time, bool) {
 	switch v := v.(type) {
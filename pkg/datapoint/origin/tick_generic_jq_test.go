@@ -371,3 +371,109 @@ func TestGenericJQ_FetchDataPoints(t *testing.T) {
 		})
 	}
 }
+
+func TestGenericJQ_FetchDataPoints_BidAsk(t *testing.T) {
+	testCases := []struct {
+		name          string
+		query         string
+		responseBody  string
+		tickIntervals bool
+		expectedValue value.Value
+	}{
+		{
+			name:          "price, bid and ask",
+			query:         ".",
+			responseBody:  `{"price": "1000", "bid": "999", "ask": "1002"}`,
+			tickIntervals: true,
+			expectedValue: value.TickInterval{
+				Pair:    value.Pair{Base: "BTC", Quote: "USD"},
+				Price:   bn.Float(1000),
+				Lower:   bn.Float(999),
+				Upper:   bn.Float(1002),
+				Sources: 1,
+			},
+		},
+		{
+			name:          "mid price",
+			query:         "{bid: .bid, ask: .ask}",
+			responseBody:  `{"bid": "999", "ask": "1001"}`,
+			tickIntervals: true,
+			expectedValue: value.TickInterval{
+				Pair:    value.Pair{Base: "BTC", Quote: "USD"},
+				Price:   bn.Float(1000),
+				Lower:   bn.Float(999),
+				Upper:   bn.Float(1001),
+				Sources: 1,
+			},
+		},
+		{
+			name:          "price outside of bid-ask range",
+			query:         ".",
+			responseBody:  `{"price": "1003", "bid": "999", "ask": "1002"}`,
+			tickIntervals: true,
+			expectedValue: value.TickInterval{
+				Pair:    value.Pair{Base: "BTC", Quote: "USD"},
+				Price:   bn.Float(1003),
+				Lower:   bn.Float(999),
+				Upper:   bn.Float(1003),
+				Sources: 1,
+			},
+		},
+		{
+			name:         "tick intervals disabled",
+			query:        ".",
+			responseBody: `{"price": "1000", "bid": "999", "ask": "1002"}`,
+			expectedValue: value.Tick{
+				Pair:  value.Pair{Base: "BTC", Quote: "USD"},
+				Price: bn.Float(1000),
+			},
+		},
+		{
+			name:          "missing ask",
+			query:         "{price: .price, bid: .bid, ask: .ask}",
+			responseBody:  `{"price": "1000", "bid": "999"}`,
+			tickIntervals: true,
+			expectedValue: value.Tick{
+				Pair:  value.Pair{Base: "BTC", Quote: "USD"},
+				Price: bn.Float(1000),
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintln(w, tt.responseBody)
+			}))
+			defer server.Close()
+
+			gjq, err := NewTickGenericJQ(TickGenericJQConfig{
+				URL:           server.URL,
+				Query:         tt.query,
+				TickIntervals: tt.tickIntervals,
+				Logger:        null.New(),
+			})
+			require.NoError(t, err)
+
+			pair := value.Pair{Base: "BTC", Quote: "USD"}
+			points, err := gjq.FetchDataPoints(context.Background(), []any{pair})
+			require.NoError(t, err)
+			require.NoError(t, points[pair].Error)
+			require.NoError(t, points[pair].Validate())
+
+			switch expected := tt.expectedValue.(type) {
+			case value.Tick:
+				tick, ok := points[pair].Value.(value.Tick)
+				require.True(t, ok)
+				assert.Equal(t, expected.Price.String(), tick.Price.String())
+			case value.TickInterval:
+				interval, ok := points[pair].Value.(value.TickInterval)
+				require.True(t, ok)
+				assert.Equal(t, expected.Pair, interval.Pair)
+				assert.Equal(t, expected.Price.String(), interval.Price.String())
+				assert.Equal(t, expected.Lower.String(), interval.Lower.String())
+				assert.Equal(t, expected.Upper.String(), interval.Upper.String())
+				assert.Equal(t, expected.Sources, interval.Sources)
+			}
+		})
+	}
+}
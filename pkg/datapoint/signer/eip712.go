@@ -0,0 +1,218 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+var (
+	eip712DomainTypeHash    = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	eip712DataPointTypeHash = crypto.Keccak256([]byte("DataPoint(bytes32 wat,uint256 val,uint256 age,uint32 valueType)"))
+)
+
+// EIP712Domain is the EIP-712 domain used to sign data points of a single
+// data model.
+type EIP712Domain struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract types.Address
+}
+
+// Separator returns the EIP-712 domain separator.
+func (d EIP712Domain) Separator() types.Hash {
+	chainID := make([]byte, 32)
+	new(big.Int).SetUint64(d.ChainID).FillBytes(chainID)
+	contract := make([]byte, 32)
+	copy(contract[12:], d.VerifyingContract.Bytes())
+	return crypto.Keccak256(
+		eip712DomainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(d.Name)).Bytes(),
+		crypto.Keccak256([]byte(d.Version)).Bytes(),
+		chainID,
+		contract,
+	)
+}
+
+// dataSigner is implemented by keys that cannot sign a precomputed hash,
+// but can sign the keccak256 hash of the given data, like remote signers.
+type dataSigner interface {
+	SignData(data []byte) (*types.Signature, error)
+}
+
+// EIP712Signer signs numeric data points, including ticks, using EIP-712
+// typed data.
+//
+// Every data model must have its own domain, so the signature is valid
+// only for a specific chain and verifying contract.
+type EIP712Signer struct {
+	signer  wallet.Key
+	domains map[string]EIP712Domain
+}
+
+// NewEIP712Signer creates a new EIP712Signer instance. The domains map
+// contains the EIP-712 domain for each data model.
+func NewEIP712Signer(signer wallet.Key, domains map[string]EIP712Domain) *EIP712Signer {
+	return &EIP712Signer{signer: signer, domains: domains}
+}
+
+// Scheme implements the Signer interface.
+func (e *EIP712Signer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeEIP712
+}
+
+// Supports implements the Signer interface.
+func (e *EIP712Signer) Supports(_ context.Context, data datapoint.Point) bool {
+	return len(e.domains) > 0 && supportsEIP712(data)
+}
+
+// Sign implements the Signer interface.
+func (e *EIP712Signer) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	domain, ok := e.domains[model]
+	if !ok {
+		return nil, fmt.Errorf("EIP-712 domain is not configured for the %s model: %w", model, datapoint.ErrUnsupportedModel)
+	}
+	typ, num, err := numericValue(data)
+	if err != nil {
+		return nil, err
+	}
+	sep, hash := eip712Hash(domain, typ, model, num, data.Time)
+	var sig *types.Signature
+	if s, ok := e.signer.(dataSigner); ok {
+		sig, err = s.SignData(eip712Payload(sep, hash))
+	} else {
+		sig, err = e.signer.SignHash(crypto.Keccak256(eip712Payload(sep, hash)))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sig.V.Cmp(big.NewInt(27)) < 0 {
+		sig.V = new(big.Int).Add(sig.V, big.NewInt(27))
+	}
+	return sig, nil
+}
+
+// EIP712Recoverer recovers the signer address from a numeric data point
+// signed using EIP-712 typed data.
+type EIP712Recoverer struct {
+	recoverer crypto.Recoverer
+	domains   map[string]EIP712Domain
+}
+
+// NewEIP712Recoverer creates a new EIP712Recoverer instance. The domains map
+// contains the EIP-712 domain for each data model.
+func NewEIP712Recoverer(recoverer crypto.Recoverer, domains map[string]EIP712Domain) *EIP712Recoverer {
+	return &EIP712Recoverer{recoverer: recoverer, domains: domains}
+}
+
+// Scheme implements the Recoverer interface.
+func (e *EIP712Recoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeEIP712
+}
+
+// Supports implements the Recoverer interface.
+//
+// If no EIP-712 domains are configured, no data points are supported.
+func (e *EIP712Recoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	return len(e.domains) > 0 && supportsEIP712(data)
+}
+
+// Recover implements the Recoverer interface.
+func (e *EIP712Recoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	domain, ok := e.domains[model]
+	if !ok {
+		return nil, fmt.Errorf("EIP-712 domain is not configured for the %s model: %w", model, datapoint.ErrUnsupportedModel)
+	}
+	typ, num, err := numericValue(data)
+	if err != nil {
+		return nil, err
+	}
+	sep, hash := eip712Hash(domain, typ, model, num, data.Time)
+	return e.recoverer.RecoverHash(crypto.Keccak256(eip712Payload(sep, hash)), signature)
+}
+
+// supportsEIP712 returns true if the data point value is a registered
+// numeric value type other than a tick interval. Tick intervals are not
+// supported because the DataPoint struct does not include their bounds.
+func supportsEIP712(data datapoint.Point) bool {
+	if _, ok := data.Value.(value.TickInterval); ok {
+		return false
+	}
+	if _, ok := data.Value.(value.NumericValue); !ok {
+		return false
+	}
+	_, ok := value.TypeID(data.Value)
+	return ok
+}
+
+// eip712Hash returns the domain separator and the hash of the DataPoint
+// struct as defined in EIP-712:
+//
+//	DataPoint(bytes32 wat,uint256 val,uint256 age,uint32 valueType)
+func eip712Hash(
+	domain EIP712Domain,
+	typ uint32,
+	model string,
+	number *bn.FloatNumber,
+	time time.Time,
+) (types.Hash, types.Hash) {
+	// Asset name (wat):
+	wat := make([]byte, 32)
+	copy(wat, model)
+
+	// Value (val):
+	val := make([]byte, 32)
+	number.DecFixedPoint(contractPricePrecision).RawBigInt().FillBytes(val)
+
+	// Time (age):
+	age := make([]byte, 32)
+	binary.BigEndian.PutUint64(age[24:], uint64(time.Unix()))
+
+	// Value type (valueType):
+	valueType := make([]byte, 32)
+	binary.BigEndian.PutUint32(valueType[28:], typ)
+
+	return domain.Separator(), crypto.Keccak256(eip712DataPointTypeHash.Bytes(), wat, val, age, valueType)
+}
+
+// eip712Payload returns the data which keccak256 hash must be signed, that
+// is: "\x19\x01" ‖ domainSeparator ‖ hashStruct(message).
+func eip712Payload(separator, hash types.Hash) []byte {
+	payload := make([]byte, 66)
+	payload[0] = 0x19
+	payload[1] = 0x01
+	copy(payload[2:34], separator.Bytes())
+	copy(payload[34:66], hash.Bytes())
+	return payload
+}
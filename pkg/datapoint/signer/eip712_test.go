@@ -0,0 +1,161 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+var testDomain = EIP712Domain{
+	Name:              "Chronicle",
+	Version:           "1",
+	ChainID:           1,
+	VerifyingContract: types.MustAddressFromHex("0x1234567890123456789012345678901234567890"),
+}
+
+var testTickPoint = datapoint.Point{
+	Value: value.Tick{
+		Pair:      value.Pair{Base: "AAA", Quote: "BBB"},
+		Price:     bn.Float(42),
+		Volume24h: bn.Float(0),
+	},
+	Time: time.Unix(1605371361, 0),
+}
+
+// dataKey wraps a private key and exposes only the SignData method, like
+// a remote signer.
+type dataKey struct {
+	*wallet.PrivateKey
+}
+
+func (k dataKey) SignHash(_ types.Hash) (*types.Signature, error) {
+	panic("SignHash must not be used")
+}
+
+func (k dataKey) SignData(data []byte) (*types.Signature, error) {
+	return k.PrivateKey.SignHash(crypto.Keccak256(data))
+}
+
+func TestEIP712_Supports(t *testing.T) {
+	domains := map[string]EIP712Domain{"AAABBB": testDomain}
+	s := NewEIP712Signer(wallet.NewRandomKey(), domains)
+	assert.Equal(t, datapoint.SignatureSchemeEIP712, s.Scheme())
+	assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+	assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.StaticValue{}}))
+	assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.TickInterval{}}))
+
+	// Without domains, no data points are supported:
+	r := NewEIP712Recoverer(crypto.ECRecoverer, nil)
+	assert.False(t, r.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+}
+
+func TestEIP712_SignAndRecover(t *testing.T) {
+	tests := []struct {
+		name string
+		key  wallet.Key
+	}{
+		{name: "private key", key: wallet.NewRandomKey()},
+		{name: "data signer", key: dataKey{wallet.NewRandomKey()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domains := map[string]EIP712Domain{"AAABBB": testDomain}
+			s := NewEIP712Signer(tt.key, domains)
+			r := NewEIP712Recoverer(crypto.ECRecoverer, domains)
+
+			sig, err := s.Sign(context.Background(), "AAABBB", testTickPoint)
+			require.NoError(t, err)
+			assert.True(t, sig.V.Uint64() == 27 || sig.V.Uint64() == 28)
+
+			addr, err := r.Recover(context.Background(), "AAABBB", testTickPoint, *sig)
+			require.NoError(t, err)
+			assert.Equal(t, tt.key.Address(), *addr)
+
+			// The same signature must not be valid for another domain.
+			other := testDomain
+			other.ChainID = 2
+			r = NewEIP712Recoverer(crypto.ECRecoverer, map[string]EIP712Domain{"AAABBB": other})
+			addr, err = r.Recover(context.Background(), "AAABBB", testTickPoint, *sig)
+			require.NoError(t, err)
+			assert.NotEqual(t, tt.key.Address(), *addr)
+		})
+	}
+}
+
+func TestEIP712_MissingDomain(t *testing.T) {
+	s := NewEIP712Signer(wallet.NewRandomKey(), nil)
+	_, err := s.Sign(context.Background(), "AAABBB", testTickPoint)
+	assert.ErrorIs(t, err, datapoint.ErrUnsupportedModel)
+
+	r := NewEIP712Recoverer(crypto.ECRecoverer, map[string]EIP712Domain{"CCCDDD": testDomain})
+	_, err = r.Recover(context.Background(), "AAABBB", testTickPoint, types.Signature{})
+	assert.ErrorIs(t, err, datapoint.ErrUnsupportedModel)
+}
+
+func TestEIP712_Hash(t *testing.T) {
+	// Compare the hash with the go-ethereum implementation of EIP-712.
+	wat := [32]byte{}
+	copy(wat[:], "AAABBB")
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"DataPoint": {
+				{Name: "wat", Type: "bytes32"},
+				{Name: "val", Type: "uint256"},
+				{Name: "age", Type: "uint256"},
+				{Name: "valueType", Type: "uint32"},
+			},
+		},
+		PrimaryType: "DataPoint",
+		Domain: apitypes.TypedDataDomain{
+			Name:              testDomain.Name,
+			Version:           testDomain.Version,
+			ChainId:           math.NewHexOrDecimal256(int64(testDomain.ChainID)),
+			VerifyingContract: testDomain.VerifyingContract.String(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"wat":       wat[:],
+			"val":       new(big.Int).Mul(big.NewInt(42), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)),
+			"age":       big.NewInt(1605371361),
+			"valueType": big.NewInt(2),
+		},
+	}
+	expHash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+
+	sep, hash := eip712Hash(testDomain, 2, "AAABBB", bn.Float(42), time.Unix(1605371361, 0))
+	assert.Equal(t, expHash, crypto.Keccak256(eip712Payload(sep, hash)).Bytes())
+}
@@ -0,0 +1,172 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// NumericSigner signs data points with numeric values, other than ticks and
+// tick intervals.
+//
+// Ticks are not supported because they are signed by the TickSigner, which
+// produces signatures that are compatible with Oracle contracts. Tick
+// intervals are signed by the TickIntervalSigner, which also signs the
+// bounds of the interval.
+type NumericSigner struct {
+	signer wallet.Key
+}
+
+// NewNumericSigner creates a new NumericSigner instance.
+func NewNumericSigner(signer wallet.Key) *NumericSigner {
+	return &NumericSigner{signer: signer}
+}
+
+// Scheme implements the Signer interface.
+func (n *NumericSigner) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
+// Supports implements the Signer interface.
+func (n *NumericSigner) Supports(_ context.Context, data datapoint.Point) bool {
+	return supportsNumeric(data)
+}
+
+// Sign implements the Signer interface.
+func (n *NumericSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	hash, err := hashNumericPoint(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return n.signer.SignMessage(hash.Bytes())
+}
+
+// NumericRecoverer recovers the signer address from a numeric data point
+// and a signature.
+type NumericRecoverer struct {
+	recoverer crypto.Recoverer
+}
+
+// NewNumericRecoverer creates a new NumericRecoverer instance.
+func NewNumericRecoverer(recoverer crypto.Recoverer) *NumericRecoverer {
+	return &NumericRecoverer{recoverer: recoverer}
+}
+
+// Scheme implements the Recoverer interface.
+func (n *NumericRecoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
+// Supports implements the Recoverer interface.
+func (n *NumericRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	return supportsNumeric(data)
+}
+
+// Recover implements the Recoverer interface.
+func (n *NumericRecoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	hash, err := hashNumericPoint(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return n.recoverer.RecoverMessage(hash.Bytes(), signature)
+}
+
+// supportsNumeric returns true if the data point value is a registered
+// numeric value type other than a tick or a tick interval.
+func supportsNumeric(data datapoint.Point) bool {
+	switch data.Value.(type) {
+	case value.Tick, value.TickInterval:
+		return false
+	}
+	if _, ok := data.Value.(value.NumericValue); !ok {
+		return false
+	}
+	_, ok := value.TypeID(data.Value)
+	return ok
+}
+
+func hashNumericPoint(model string, data datapoint.Point) (types.Hash, error) {
+	typ, num, err := numericValue(data)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return hashNumeric(typ, model, num, data.Time), nil
+}
+
+// numericValue returns the registered type ID and the number of the data
+// point value.
+func numericValue(data datapoint.Point) (uint32, *bn.FloatNumber, error) {
+	typ, ok := value.TypeID(data.Value)
+	if !ok {
+		return 0, nil, fmt.Errorf("unregistered value type: %T", data.Value)
+	}
+	num := data.Value.(value.NumericValue).Number()
+	if num == nil {
+		return 0, nil, errors.New("value is nil")
+	}
+	if num.Sign() < 0 {
+		return 0, nil, errors.New("negative values are not supported")
+	}
+	return typ, num, nil
+}
+
+// hashNumeric is an equivalent of keccak256(abi.encodePacked(typ, val, age, wat))
+// in Solidity, where typ is the registered value type ID.
+//
+// The type ID is used as a hashing domain, so a signature for one value type
+// cannot be used as a signature for another value type, nor for a tick.
+func hashNumeric(typ uint32, model string, number *bn.FloatNumber, time time.Time) types.Hash {
+	// Value type (typ):
+	domain := make([]byte, 32)
+	binary.BigEndian.PutUint32(domain[28:], typ)
+
+	// Value (val):
+	val := make([]byte, 32)
+	number.DecFixedPoint(contractPricePrecision).RawBigInt().FillBytes(val)
+
+	// Time (age):
+	age := make([]byte, 32)
+	binary.BigEndian.PutUint64(age[24:], uint64(time.Unix()))
+
+	// Asset name (wat):
+	wat := make([]byte, 32)
+	copy(wat, model)
+
+	// Hash:
+	hash := make([]byte, 128)
+	copy(hash[0:32], domain)
+	copy(hash[32:64], val)
+	copy(hash[64:96], age)
+	copy(hash[96:128], wat)
+	return crypto.Keccak256(hash)
+}
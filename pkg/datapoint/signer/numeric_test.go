@@ -0,0 +1,111 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// Hash for the AAABBB model, with the static value set to 42 and the age to 1605371361:
+var staticHash = "0x46224605cbcd706256b27b8696a1eb2c4b9bf76d451236bcafe6973826cf1430"
+
+func TestNumeric_Supports(t *testing.T) {
+	t.Run("supported data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewNumericSigner(k)
+		assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.StaticValue{}}))
+	})
+	t.Run("unsupported tick data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewNumericSigner(k)
+		assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+	})
+	t.Run("unsupported tick interval data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewNumericSigner(k)
+		assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.TickInterval{}}))
+	})
+}
+
+func TestNumeric_Sign(t *testing.T) {
+	k := &mocks.Key{}
+	s := NewNumericSigner(k)
+
+	expSig := types.MustSignatureFromBytesPtr(bytes.Repeat([]byte{0xAA}, 65))
+	k.On("SignMessage", hexutil.MustHexToBytes(staticHash)).Return(expSig, nil).Once()
+
+	retSig, err := s.Sign(context.Background(), "AAABBB", datapoint.Point{
+		Value: value.StaticValue{Value: bn.Float(42)},
+		Time:  time.Unix(1605371361, 0),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, *expSig, *retSig)
+}
+
+func TestNumeric_Sign_Negative(t *testing.T) {
+	k := &mocks.Key{}
+	s := NewNumericSigner(k)
+
+	_, err := s.Sign(context.Background(), "AAABBB", datapoint.Point{
+		Value: value.StaticValue{Value: bn.Float(-42)},
+		Time:  time.Unix(1605371361, 0),
+	})
+	assert.Error(t, err)
+}
+
+func TestNumeric_Recover(t *testing.T) {
+	r := &mocks.Recoverer{}
+	s := NewNumericRecoverer(r)
+
+	msgSig := types.MustSignatureFromBytesPtr(bytes.Repeat([]byte{0xAA}, 65))
+	expAddr := types.MustAddressFromHexPtr("0x1234567890123456789012345678901234567890")
+	r.On("RecoverMessage", hexutil.MustHexToBytes(staticHash), *msgSig).Return(expAddr, nil).Once()
+
+	retAddr, err := s.Recover(context.Background(), "AAABBB", datapoint.Point{
+		Value: value.StaticValue{Value: bn.Float(42)},
+		Time:  time.Unix(1605371361, 0),
+	}, *msgSig)
+	require.NoError(t, err)
+
+	assert.Equal(t, *expAddr, *retAddr)
+}
+
+func TestHashNumeric(t *testing.T) {
+	assert.Equal(t, staticHash, hashNumeric(1, "AAABBB", bn.Float(42), time.Unix(1605371361, 0)).String())
+
+	// The same data must produce different hashes for different types
+	// and for ticks.
+	assert.NotEqual(
+		t,
+		hashNumeric(1, "AAABBB", bn.Float(42), time.Unix(1605371361, 0)),
+		hashNumeric(2, "AAABBB", bn.Float(42), time.Unix(1605371361, 0)),
+	)
+	assert.NotEqual(t, priceHash, hashNumeric(2, "AAABBB", bn.Float(42), time.Unix(1605371361, 0)).String())
+}
@@ -18,6 +18,7 @@ package signer
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/defiweb/go-eth/crypto"
@@ -45,6 +46,11 @@ func NewTickSigner(signer wallet.Key) *TickSigner {
 	return &TickSigner{signer: signer}
 }
 
+// Scheme implements the Signer interface.
+func (t *TickSigner) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
 // Supports implements the Signer interface.
 func (t *TickSigner) Supports(_ context.Context, data datapoint.Point) bool {
 	_, ok := data.Value.(value.Tick)
@@ -53,8 +59,12 @@ func (t *TickSigner) Supports(_ context.Context, data datapoint.Point) bool {
 
 // Sign implements the Signer interface.
 func (t *TickSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	tick, ok := data.Value.(value.Tick)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type: %T", data.Value)
+	}
 	return t.signer.SignMessage(
-		hashTick(model, data.Value.(value.Tick).Price, data.Time).Bytes(),
+		hashTick(model, tick.Price, data.Time).Bytes(),
 	)
 }
 
@@ -69,6 +79,11 @@ func NewTickRecoverer(recoverer crypto.Recoverer) *TickRecoverer {
 	return &TickRecoverer{recoverer: recoverer}
 }
 
+// Scheme implements the Recoverer interface.
+func (t *TickRecoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
 // Supports implements the Recoverer interface.
 func (t *TickRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
 	_, ok := data.Value.(value.Tick)
@@ -82,8 +97,12 @@ func (t *TickRecoverer) Recover(
 	data datapoint.Point,
 	signature types.Signature,
 ) (*types.Address, error) {
+	tick, ok := data.Value.(value.Tick)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type: %T", data.Value)
+	}
 	return t.recoverer.RecoverMessage(
-		hashTick(model, data.Value.(value.Tick).Price, data.Time).Bytes(),
+		hashTick(model, tick.Price, data.Time).Bytes(),
 		signature,
 	)
 }
@@ -0,0 +1,154 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+)
+
+// TickIntervalSigner signs tick interval data points.
+//
+// Unlike the TickSigner, the signature covers the bounds and the number of
+// sources of the interval, so they cannot be modified by relaying nodes.
+// Because of that, the signatures cannot be verified by Oracle contracts.
+type TickIntervalSigner struct {
+	signer wallet.Key
+}
+
+// NewTickIntervalSigner creates a new TickIntervalSigner instance.
+func NewTickIntervalSigner(signer wallet.Key) *TickIntervalSigner {
+	return &TickIntervalSigner{signer: signer}
+}
+
+// Scheme implements the Signer interface.
+func (t *TickIntervalSigner) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
+// Supports implements the Signer interface.
+func (t *TickIntervalSigner) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.TickInterval)
+	return ok
+}
+
+// Sign implements the Signer interface.
+func (t *TickIntervalSigner) Sign(_ context.Context, model string, data datapoint.Point) (*types.Signature, error) {
+	hash, err := hashTickIntervalPoint(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.signer.SignMessage(hash.Bytes())
+}
+
+// TickIntervalRecoverer recovers the signer address from a tick interval
+// data point and a signature.
+type TickIntervalRecoverer struct {
+	recoverer crypto.Recoverer
+}
+
+// NewTickIntervalRecoverer creates a new TickIntervalRecoverer instance.
+func NewTickIntervalRecoverer(recoverer crypto.Recoverer) *TickIntervalRecoverer {
+	return &TickIntervalRecoverer{recoverer: recoverer}
+}
+
+// Scheme implements the Recoverer interface.
+func (t *TickIntervalRecoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
+// Supports implements the Recoverer interface.
+func (t *TickIntervalRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
+	_, ok := data.Value.(value.TickInterval)
+	return ok
+}
+
+// Recover implements the Recoverer interface.
+func (t *TickIntervalRecoverer) Recover(
+	_ context.Context,
+	model string,
+	data datapoint.Point,
+	signature types.Signature,
+) (*types.Address, error) {
+	hash, err := hashTickIntervalPoint(model, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.recoverer.RecoverMessage(hash.Bytes(), signature)
+}
+
+func hashTickIntervalPoint(model string, data datapoint.Point) (types.Hash, error) {
+	interval, ok := data.Value.(value.TickInterval)
+	if !ok {
+		return types.Hash{}, fmt.Errorf("unsupported value type: %T", data.Value)
+	}
+	if interval.Price == nil || interval.Lower == nil || interval.Upper == nil {
+		return types.Hash{}, errors.New("price and bounds must be set")
+	}
+	if interval.Price.Sign() < 0 || interval.Lower.Sign() < 0 || interval.Upper.Sign() < 0 {
+		return types.Hash{}, errors.New("negative values are not supported")
+	}
+	typ, _ := value.TypeID(interval)
+	return hashTickInterval(typ, model, interval, data.Time), nil
+}
+
+// hashTickInterval is an equivalent of
+// keccak256(abi.encodePacked(typ, val, lower, upper, sources, age, wat))
+// in Solidity, where typ is the registered value type ID.
+//
+// The type ID is used as a hashing domain, the same way as in hashNumeric,
+// so a signature for a tick interval cannot be used for any other value.
+func hashTickInterval(typ uint32, model string, interval value.TickInterval, time time.Time) types.Hash {
+	// Value type (typ):
+	domain := make([]byte, 32)
+	binary.BigEndian.PutUint32(domain[28:], typ)
+
+	// Price (val):
+	val := make([]byte, 32)
+	interval.Price.DecFixedPoint(contractPricePrecision).RawBigInt().FillBytes(val)
+
+	// Lower bound (lower):
+	lower := make([]byte, 32)
+	interval.Lower.DecFixedPoint(contractPricePrecision).RawBigInt().FillBytes(lower)
+
+	// Upper bound (upper):
+	upper := make([]byte, 32)
+	interval.Upper.DecFixedPoint(contractPricePrecision).RawBigInt().FillBytes(upper)
+
+	// Number of sources (sources):
+	sources := make([]byte, 32)
+	binary.BigEndian.PutUint32(sources[28:], interval.Sources)
+
+	// Time (age):
+	age := make([]byte, 32)
+	binary.BigEndian.PutUint64(age[24:], uint64(time.Unix()))
+
+	// Asset name (wat):
+	wat := make([]byte, 32)
+	copy(wat, model)
+
+	return crypto.Keccak256(domain, val, lower, upper, sources, age, wat)
+}
@@ -0,0 +1,111 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// Hash for the AAABBB model, with the price set to 42, the bounds to 41 and 43,
+// the number of sources to 2 and the age to 1605371361:
+var intervalHash = "0x4d7fab85c2bb275bff4a16005b83bd7900f01e9ac6e368acf65ef08d221d1430"
+
+var testInterval = value.TickInterval{
+	Pair:    value.Pair{Base: "AAA", Quote: "BBB"},
+	Price:   bn.Float(42),
+	Lower:   bn.Float(41),
+	Upper:   bn.Float(43),
+	Sources: 2,
+}
+
+func TestTickInterval_Supports(t *testing.T) {
+	t.Run("supported data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewTickIntervalSigner(k)
+		assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.TickInterval{}}))
+	})
+	t.Run("unsupported tick data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewTickIntervalSigner(k)
+		assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
+	})
+}
+
+func TestTickInterval_Sign(t *testing.T) {
+	k := &mocks.Key{}
+	s := NewTickIntervalSigner(k)
+
+	expSig := types.MustSignatureFromBytesPtr(bytes.Repeat([]byte{0xAA}, 65))
+	k.On("SignMessage", hexutil.MustHexToBytes(intervalHash)).Return(expSig, nil).Once()
+
+	retSig, err := s.Sign(context.Background(), "AAABBB", datapoint.Point{
+		Value: testInterval,
+		Time:  time.Unix(1605371361, 0),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, *expSig, *retSig)
+}
+
+func TestTickInterval_Recover(t *testing.T) {
+	r := &mocks.Recoverer{}
+	s := NewTickIntervalRecoverer(r)
+
+	msgSig := types.MustSignatureFromBytesPtr(bytes.Repeat([]byte{0xAA}, 65))
+	expAddr := types.MustAddressFromHexPtr("0x1234567890123456789012345678901234567890")
+	r.On("RecoverMessage", hexutil.MustHexToBytes(intervalHash), *msgSig).Return(expAddr, nil).Once()
+
+	retAddr, err := s.Recover(context.Background(), "AAABBB", datapoint.Point{
+		Value: testInterval,
+		Time:  time.Unix(1605371361, 0),
+	}, *msgSig)
+	require.NoError(t, err)
+
+	assert.Equal(t, *expAddr, *retAddr)
+}
+
+func TestHashTickInterval(t *testing.T) {
+	tm := time.Unix(1605371361, 0)
+	hash := hashTickInterval(3, "AAABBB", testInterval, tm)
+	assert.Equal(t, intervalHash, hash.String())
+
+	// Any change of the bounds or the number of sources must change the hash:
+	for _, modify := range []func(i *value.TickInterval){
+		func(i *value.TickInterval) { i.Lower = bn.Float(40) },
+		func(i *value.TickInterval) { i.Upper = bn.Float(44) },
+		func(i *value.TickInterval) { i.Sources = 3 },
+	} {
+		interval := testInterval
+		modify(&interval)
+		assert.NotEqual(t, hash, hashTickInterval(3, "AAABBB", interval, tm))
+	}
+
+	// The hash must differ from the tick hash with the same price:
+	assert.NotEqual(t, hashTick("AAABBB", bn.Float(42), tm), hash)
+}
@@ -41,6 +41,11 @@ func TestTick_Supports(t *testing.T) {
 		s := NewTickSigner(k)
 		assert.True(t, s.Supports(context.Background(), datapoint.Point{Value: value.Tick{}}))
 	})
+	t.Run("unsupported tick interval data point", func(t *testing.T) {
+		k := &mocks.Key{}
+		s := NewTickSigner(k)
+		assert.False(t, s.Supports(context.Background(), datapoint.Point{Value: value.TickInterval{}}))
+	})
 	t.Run("unsupported data point", func(t *testing.T) {
 		k := &mocks.Key{}
 		s := NewTickSigner(k)
@@ -18,6 +18,7 @@ package store
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/defiweb/go-eth/types"
 
@@ -30,6 +31,10 @@ import (
 
 const LoggerTag = "DATA_POINT_STORE"
 
+// subscriptionBufferSize is the size of the buffer of the channels returned
+// by the Subscribe method.
+const subscriptionBufferSize = 128
+
 // Storage is underlying storage implementation for the Store.
 type Storage interface {
 	// Add adds a data point to the store.
@@ -48,18 +53,20 @@ type Storage interface {
 // StoredDataPoint is a struct which represents a data point stored in the
 // Store.
 type StoredDataPoint struct {
-	Model     string
-	DataPoint datapoint.Point
-	From      types.Address
-	Signature types.Signature
+	Model           string
+	DataPoint       datapoint.Point
+	From            types.Address
+	Signature       types.Signature
+	SignatureScheme datapoint.SignatureScheme
 }
 
 // LogFields returns a set of log fields for the data point.
 func (o StoredDataPoint) LogFields() log.Fields {
 	f := log.Fields{
-		"model":     o.Model,
-		"from":      o.From.String(),
-		"signature": o.Signature.String(),
+		"model":           o.Model,
+		"from":            o.From.String(),
+		"signature":       o.Signature.String(),
+		"signatureScheme": o.SignatureScheme.String(),
 	}
 	for k, v := range o.DataPoint.LogFields() {
 		f[k] = v
@@ -77,6 +84,9 @@ type Store struct {
 	transport  transport.Service
 	models     []string
 	recoverers []datapoint.Recoverer
+
+	subsMu sync.Mutex
+	subs   map[chan StoredDataPoint]struct{}
 }
 
 // Config is the configuration for Storage.
@@ -117,6 +127,7 @@ func New(cfg Config) (*Store, error) {
 		transport:  cfg.Transport,
 		models:     cfg.Models,
 		recoverers: cfg.Recoverers,
+		subs:       make(map[chan StoredDataPoint]struct{}),
 	}
 	return s, nil
 }
@@ -151,23 +162,45 @@ func (p *Store) Latest(ctx context.Context, model string) (map[types.Address]Sto
 	return p.storage.Latest(ctx, model)
 }
 
+// Subscribe returns a channel that receives every data point collected by
+// the store after the subscription is made. The channel is closed when the
+// given context is canceled.
+//
+// If a subscriber does not read from the channel fast enough, data points
+// that do not fit into the channel buffer are dropped.
+func (p *Store) Subscribe(ctx context.Context) <-chan StoredDataPoint {
+	ch := make(chan StoredDataPoint, subscriptionBufferSize)
+	p.subsMu.Lock()
+	p.subs[ch] = struct{}{}
+	p.subsMu.Unlock()
+	go func() {
+		<-ctx.Done()
+		p.subsMu.Lock()
+		delete(p.subs, ch)
+		close(ch)
+		p.subsMu.Unlock()
+	}()
+	return ch
+}
+
 func (p *Store) collectDataPoint(point *messages.DataPoint) {
 	for _, recoverer := range p.recoverers {
-		if recoverer.Supports(p.ctx, point.Value) {
+		if recoverer.Scheme() == point.SignatureScheme && recoverer.Supports(p.ctx, point.Value) {
 			from, err := recoverer.Recover(p.ctx, point.Model, point.Value, point.Signature)
 			if err != nil {
 				p.log.
 					WithError(err).
 					WithField("model", point.Model).
-					WithField("from", from).
 					WithFields(point.Value.LogFields()).
 					Error("Unable to recover address")
+				return
 			}
 			sdp := StoredDataPoint{
-				Model:     point.Model,
-				DataPoint: point.Value,
-				From:      *from,
-				Signature: point.Signature,
+				Model:           point.Model,
+				DataPoint:       point.Value,
+				From:            *from,
+				Signature:       point.Signature,
+				SignatureScheme: point.SignatureScheme,
 			}
 			if err := p.storage.Add(p.ctx, sdp); err != nil {
 				p.log.
@@ -179,15 +212,31 @@ func (p *Store) collectDataPoint(point *messages.DataPoint) {
 			p.log.
 				WithFields(sdp.LogFields()).
 				Info("Data point collected")
+			p.notifySubscribers(sdp)
 			return
 		}
 	}
 	p.log.
 		WithField("model", point.Model).
+		WithField("signatureScheme", point.SignatureScheme.String()).
 		WithFields(point.Value.LogFields()).
 		Error("Unable to find recoverer for the data point")
 }
 
+func (p *Store) notifySubscribers(sdp StoredDataPoint) {
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+	for ch := range p.subs {
+		select {
+		case ch <- sdp:
+		default:
+			p.log.
+				WithFields(sdp.LogFields()).
+				Warn("Subscriber is too slow, data point dropped")
+		}
+	}
+}
+
 func (p *Store) shouldCollect(model string) bool {
 	for _, a := range p.models {
 		if a == model {
@@ -199,12 +248,18 @@ func (p *Store) shouldCollect(model string) bool {
 
 func (p *Store) dataPointCollectorRoutine() {
 	dataPointCh := p.transport.Messages(messages.DataPointV1MessageName)
+	dataPointV2Ch := p.transport.Messages(messages.DataPointV2MessageName)
+	dataPointBatchCh := p.transport.Messages(messages.DataPointBatchV1MessageName)
 	for {
 		select {
 		case <-p.ctx.Done():
 			return
 		case msg := <-dataPointCh:
 			p.handlePointMessage(msg)
+		case msg := <-dataPointV2Ch:
+			p.handlePointV2Message(msg)
+		case msg := <-dataPointBatchCh:
+			p.handleBatchMessage(msg)
 		}
 	}
 }
@@ -238,3 +293,54 @@ func (p *Store) handlePointMessage(msg transport.ReceivedMessage) {
 	}
 	p.collectDataPoint(point)
 }
+
+func (p *Store) handlePointV2Message(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		p.log.
+			WithError(msg.Error).
+			Error("Unable to receive message")
+		return
+	}
+	point, ok := msg.Message.(*messages.DataPointV2)
+	if !ok {
+		p.log.
+			WithFields(msg.Fields()).
+			Error("Unexpected value returned from the transport layer")
+		return
+	}
+	if !p.shouldCollect(point.Model) {
+		p.log.
+			WithFields(msg.Fields()).
+			Warn("Data point rejected")
+		return
+	}
+	for _, dp := range point.DataPoints() {
+		p.collectDataPoint(dp)
+	}
+}
+
+func (p *Store) handleBatchMessage(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		p.log.
+			WithError(msg.Error).
+			Error("Unable to receive message")
+		return
+	}
+	batch, ok := msg.Message.(*messages.DataPointBatch)
+	if !ok {
+		p.log.
+			WithFields(msg.Fields()).
+			Error("Unexpected value returned from the transport layer")
+		return
+	}
+	for _, point := range batch.DataPoints {
+		if !p.shouldCollect(point.Model) {
+			p.log.
+				WithFields(msg.Fields()).
+				WithField("model", point.Model).
+				Warn("Data point rejected")
+			continue
+		}
+		p.collectDataPoint(point)
+	}
+}
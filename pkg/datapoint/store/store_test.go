@@ -34,6 +34,10 @@ func (s *stringValue) UnmarshalBinary(data []byte) error {
 
 type mockRecoverer struct{}
 
+func (r *mockRecoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
 func (r *mockRecoverer) Supports(_ context.Context, data datapoint.Point) bool {
 	return true
 }
@@ -140,3 +144,145 @@ func TestStore(t *testing.T) {
 	assert.Equal(t, "xxxyyy_val1", b[types.MustAddressFromHex("0x1111111111111111111111111111111111111111")].DataPoint.Value.Print())
 	assert.Equal(t, "xxxyyy_val2", b[types.MustAddressFromHex("0x2222222222222222222222222222222222222222")].DataPoint.Value.Print())
 }
+
+func TestStore_Batch(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	transport := local.New(
+		[]byte("test"),
+		0,
+		map[string]transport.Message{
+			messages.DataPointV1MessageName:      (*messages.DataPoint)(nil),
+			messages.DataPointBatchV1MessageName: (*messages.DataPointBatch)(nil),
+		},
+	)
+	require.NoError(t, transport.Start(ctx))
+
+	// Wait to be sure that the transport is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	store, err := New(Config{
+		Storage:    NewMemoryStorage(),
+		Transport:  transport,
+		Models:     []string{"AAABBB"},
+		Recoverers: []datapoint.Recoverer{&mockRecoverer{}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+
+	// Wait to be sure that the store is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(t, transport.Broadcast(
+		messages.DataPointBatchV1MessageName,
+		&messages.DataPointBatch{DataPoints: []*messages.DataPoint{aaabbb1, aaabbb2, xxxyyy1}},
+	))
+
+	// Wait to be sure that the store has processed the messages.
+	assert.Eventually(t, func() bool {
+		a, _ := store.Latest(context.Background(), "AAABBB")
+		return len(a) == 2
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Data points for models that are not collected must be skipped.
+	b, _ := store.Latest(context.Background(), "XXXYYY")
+	assert.Len(t, b, 0)
+}
+
+func TestStore_V2(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	transport := local.New(
+		[]byte("test"),
+		0,
+		map[string]transport.Message{
+			messages.DataPointV1MessageName: (*messages.DataPoint)(nil),
+			messages.DataPointV2MessageName: (*messages.DataPointV2)(nil),
+		},
+	)
+	require.NoError(t, transport.Start(ctx))
+
+	// Wait to be sure that the transport is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	store, err := New(Config{
+		Storage:    NewMemoryStorage(),
+		Transport:  transport,
+		Models:     []string{"AAABBB"},
+		Recoverers: []datapoint.Recoverer{&mockRecoverer{}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+
+	// Wait to be sure that the store is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	v2, err := messages.NewDataPointV2(aaabbb1)
+	require.NoError(t, err)
+	v2.Value.Time = time.Unix(1234567890, 500)
+	assert.NoError(t, transport.Broadcast(messages.DataPointV1MessageName, aaabbb2))
+	assert.NoError(t, transport.Broadcast(messages.DataPointV2MessageName, v2))
+
+	// Wait to be sure that the store has processed the messages.
+	assert.Eventually(t, func() bool {
+		a, _ := store.Latest(context.Background(), "AAABBB")
+		return len(a) == 2
+	}, 1*time.Second, 100*time.Millisecond)
+
+	// Sub-second precision of v2 timestamps must be preserved.
+	a, _ := store.Latest(context.Background(), "AAABBB")
+	p := a[types.MustAddressFromHex("0x1111111111111111111111111111111111111111")]
+	assert.Equal(t, "aaabbb_val1", p.DataPoint.Value.Print())
+	assert.Equal(t, 500, p.DataPoint.Time.Nanosecond())
+}
+
+func TestStore_Subscribe(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	transport := local.New(
+		[]byte("test"),
+		0,
+		map[string]transport.Message{messages.DataPointV1MessageName: (*messages.DataPoint)(nil)},
+	)
+	require.NoError(t, transport.Start(ctx))
+
+	// Wait to be sure that the transport is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	store, err := New(Config{
+		Storage:    NewMemoryStorage(),
+		Transport:  transport,
+		Models:     []string{"AAABBB"},
+		Recoverers: []datapoint.Recoverer{&mockRecoverer{}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Start(ctx))
+
+	subCtx, subCtxCancel := context.WithCancel(ctx)
+	ch := store.Subscribe(subCtx)
+
+	// Wait to be sure that the store is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	assert.NoError(t, transport.Broadcast(messages.DataPointV1MessageName, aaabbb1))
+	assert.NoError(t, transport.Broadcast(messages.DataPointV1MessageName, xxxyyy1))
+
+	select {
+	case sdp := <-ch:
+		assert.Equal(t, "AAABBB", sdp.Model)
+		assert.Equal(t, "aaabbb_val1", sdp.DataPoint.Value.Print())
+		assert.Equal(t, types.MustAddressFromHex("0x1111111111111111111111111111111111111111"), sdp.From)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for data point")
+	}
+
+	// The channel must be closed after the subscription context is canceled.
+	subCtxCancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
@@ -7,7 +7,7 @@ import (
 )
 
 // StaticNumberPrecision is a precision of static numbers.
-// Thw number is multiplied by this value before being marshaled.
+// The number is multiplied by this value before being marshaled.
 const StaticNumberPrecision = 1e18
 
 // StaticValue is a numeric value obtained from a static origin.
@@ -27,7 +27,7 @@ func (s StaticValue) Print() string {
 
 // MarshalBinary implements the Value interface.
 func (s StaticValue) MarshalBinary() ([]byte, error) {
-	return s.Value.Mul(TickPricePrecision).BigInt().Bytes(), nil
+	return s.Value.Mul(StaticNumberPrecision).BigInt().Bytes(), nil
 }
 
 // UnmarshalBinary implements the Value interface.
@@ -0,0 +1,20 @@
+package value
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestStaticValue_Marshalling(t *testing.T) {
+	v := StaticValue{Value: bn.Float(1.5)}
+	bin, err := v.MarshalBinary()
+	require.NoError(t, err)
+
+	var u StaticValue
+	require.NoError(t, u.UnmarshalBinary(bin))
+	assert.Equal(t, v.Value.String(), u.Value.String())
+}
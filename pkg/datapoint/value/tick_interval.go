@@ -0,0 +1,241 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package value
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/origin/pb"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// TickIntervalPrecision specified number of decimal places for tick interval
+// prices and bounds during marshaling.
+const TickIntervalPrecision = 18
+
+// TickInterval contains a price for a given asset pair together with the
+// lower and upper bounds of the price, e.g. a bid and ask prices or error
+// bounds calculated from multiple sources.
+//
+// Before using this data, you should check if it is valid by calling
+// TickInterval.Validate() method.
+//
+// During marshaling, the price and bounds are converted to fixed-point
+// numbers with the precision specified by TickIntervalPrecision constant.
+type TickInterval struct {
+	// Pair is an asset pair for which this price is calculated.
+	Pair Pair
+
+	// Price is a price for the given asset pair.
+	Price *bn.FloatNumber
+
+	// Lower is a lower bound of the price.
+	Lower *bn.FloatNumber
+
+	// Upper is an upper bound of the price.
+	Upper *bn.FloatNumber
+
+	// Sources is a number of sources used to calculate the price.
+	Sources uint32
+}
+
+// Interval returns a tick interval with both bounds equal to the tick price.
+func (t Tick) Interval() TickInterval {
+	return TickInterval{
+		Pair:    t.Pair,
+		Price:   t.Price,
+		Lower:   t.Price,
+		Upper:   t.Price,
+		Sources: 1,
+	}
+}
+
+// Tick returns a tick with the price of the interval.
+func (t TickInterval) Tick() Tick {
+	return Tick{
+		Pair:  t.Pair,
+		Price: t.Price,
+	}
+}
+
+// Spread returns the difference between the upper and lower bounds relative
+// to the price. For example, 0.01 means that the bounds are 1% of the price
+// apart.
+//
+// If the price is zero or nil, nil is returned.
+func (t TickInterval) Spread() *bn.FloatNumber {
+	if t.Price == nil || t.Lower == nil || t.Upper == nil || t.Price.Sign() == 0 {
+		return nil
+	}
+	return t.Upper.Sub(t.Lower).Div(t.Price)
+}
+
+// Number implements the NumericValue interface.
+func (t TickInterval) Number() *bn.FloatNumber {
+	return t.Price
+}
+
+// Print implements the Value interface.
+func (t TickInterval) Print() string {
+	return fmt.Sprintf(
+		"Pair=%s, Price=%s, Lower=%s, Upper=%s, Sources=%d",
+		t.Pair, t.Price, t.Lower, t.Upper, t.Sources,
+	)
+}
+
+// MarshalBinary implements the Value interface.
+func (t TickInterval) MarshalBinary() ([]byte, error) {
+	var (
+		priceBytes []byte
+		lowerBytes []byte
+		upperBytes []byte
+		err        error
+	)
+	if t.Price != nil {
+		priceBytes, err = t.Price.DecFixedPoint(TickIntervalPrecision).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if t.Lower != nil {
+		lowerBytes, err = t.Lower.DecFixedPoint(TickIntervalPrecision).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if t.Upper != nil {
+		upperBytes, err = t.Upper.DecFixedPoint(TickIntervalPrecision).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proto.Marshal(&pb.TickInterval{
+		Pair:    t.Pair.String(),
+		Price:   priceBytes,
+		Lower:   lowerBytes,
+		Upper:   upperBytes,
+		Sources: t.Sources,
+	})
+}
+
+// UnmarshalBinary implements the Value interface.
+func (t *TickInterval) UnmarshalBinary(bytes []byte) error {
+	pbTick := &pb.TickInterval{}
+	if err := proto.Unmarshal(bytes, pbTick); err != nil {
+		return err
+	}
+	pair, err := PairFromString(pbTick.Pair)
+	if err != nil {
+		return err
+	}
+	t.Pair = pair
+	t.Sources = pbTick.Sources
+	for _, f := range []struct {
+		bytes []byte
+		dst   **bn.FloatNumber
+	}{
+		{bytes: pbTick.Price, dst: &t.Price},
+		{bytes: pbTick.Lower, dst: &t.Lower},
+		{bytes: pbTick.Upper, dst: &t.Upper},
+	} {
+		if len(f.bytes) == 0 {
+			continue
+		}
+		n := &bn.DecFixedPointNumber{}
+		if err := n.UnmarshalBinary(f.bytes); err != nil {
+			return err
+		}
+		*f.dst = n.Float()
+	}
+	return nil
+}
+
+// Validate returns an error if the tick interval is invalid.
+func (t TickInterval) Validate() error {
+	if t.Pair.Empty() {
+		return fmt.Errorf("pair is not set")
+	}
+	if t.Price == nil {
+		return fmt.Errorf("price is nil")
+	}
+	if t.Price.Sign() <= 0 {
+		return fmt.Errorf("price is zero or negative")
+	}
+	if t.Price.IsInf() {
+		return fmt.Errorf("price is infinite")
+	}
+	if t.Lower == nil || t.Upper == nil {
+		return fmt.Errorf("bounds are nil")
+	}
+	if t.Lower.Sign() <= 0 {
+		return fmt.Errorf("lower bound is zero or negative")
+	}
+	if t.Upper.IsInf() {
+		return fmt.Errorf("upper bound is infinite")
+	}
+	if t.Lower.Cmp(t.Price) > 0 || t.Upper.Cmp(t.Price) < 0 {
+		return fmt.Errorf("price is out of bounds")
+	}
+	if t.Sources == 0 {
+		return fmt.Errorf("number of sources is zero")
+	}
+	return nil
+}
+
+func (t TickInterval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"pair":    t.Pair.String(),
+		"price":   floatString(t.Price),
+		"lower":   floatString(t.Lower),
+		"upper":   floatString(t.Upper),
+		"sources": t.Sources,
+	})
+}
+
+func (t *TickInterval) UnmarshalJSON(data []byte) error {
+	var result struct {
+		Pair    string `json:"pair"`
+		Price   string `json:"price"`
+		Lower   string `json:"lower"`
+		Upper   string `json:"upper"`
+		Sources uint32 `json:"sources"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	pair, err := PairFromString(result.Pair)
+	if err != nil {
+		return err
+	}
+	t.Pair = pair
+	t.Price = bn.Float(result.Price)
+	t.Lower = bn.Float(result.Lower)
+	t.Upper = bn.Float(result.Upper)
+	t.Sources = result.Sources
+	return nil
+}
+
+// floatString returns the string representation of the number or an empty
+// string if the number is nil.
+func floatString(f *bn.FloatNumber) string {
+	if f == nil {
+		return ""
+	}
+	return f.String()
+}
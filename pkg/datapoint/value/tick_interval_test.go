@@ -0,0 +1,79 @@
+package value
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestTickInterval_Validate(t *testing.T) {
+	valid := TickInterval{
+		Pair:    Pair{Base: "BTC", Quote: "USD"},
+		Price:   bn.Float(1000),
+		Lower:   bn.Float(999),
+		Upper:   bn.Float(1001),
+		Sources: 2,
+	}
+	testCases := []struct {
+		name          string
+		modify        func(t *TickInterval)
+		errorContains string
+	}{
+		{name: "valid tick interval", modify: func(t *TickInterval) {}},
+		{name: "pair is not set", modify: func(t *TickInterval) { t.Pair = Pair{} }, errorContains: "pair is not set"},
+		{name: "price is nil", modify: func(t *TickInterval) { t.Price = nil }, errorContains: "price is nil"},
+		{name: "price is zero", modify: func(t *TickInterval) { t.Price = bn.Float(0) }, errorContains: "price is zero or negative"},
+		{name: "bounds are nil", modify: func(t *TickInterval) { t.Lower = nil }, errorContains: "bounds are nil"},
+		{name: "lower bound is zero", modify: func(t *TickInterval) { t.Lower = bn.Float(0) }, errorContains: "lower bound is zero or negative"},
+		{name: "price below lower bound", modify: func(t *TickInterval) { t.Lower = bn.Float(1000.5) }, errorContains: "price is out of bounds"},
+		{name: "price above upper bound", modify: func(t *TickInterval) { t.Upper = bn.Float(999.5) }, errorContains: "price is out of bounds"},
+		{name: "no sources", modify: func(t *TickInterval) { t.Sources = 0 }, errorContains: "number of sources is zero"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ti := valid
+			tc.modify(&ti)
+			err := ti.Validate()
+			if tc.errorContains != "" {
+				assert.ErrorContains(t, err, tc.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTickInterval_Spread(t *testing.T) {
+	ti := TickInterval{Price: bn.Float(100), Lower: bn.Float(99), Upper: bn.Float(101)}
+	assert.Equal(t, 0.02, ti.Spread().Float64())
+	assert.Nil(t, TickInterval{}.Spread())
+}
+
+func TestTickInterval_Marshal(t *testing.T) {
+	ti := TickInterval{
+		Pair:    Pair{Base: "BTC", Quote: "USD"},
+		Price:   bn.Float(1000.5),
+		Lower:   bn.Float(999.25),
+		Upper:   bn.Float(1001.75),
+		Sources: 3,
+	}
+
+	// Binary:
+	bin, err := MarshalBinary(ti)
+	require.NoError(t, err)
+	v, err := UnmarshalBinary(bin)
+	require.NoError(t, err)
+	assert.Equal(t, ti.Print(), v.(TickInterval).Print())
+
+	// JSON:
+	j, err := json.Marshal(ti)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"pair":"BTC/USD","price":"1000.5","lower":"999.25","upper":"1001.75","sources":3}`, string(j))
+	var dec TickInterval
+	require.NoError(t, json.Unmarshal(j, &dec))
+	assert.Equal(t, ti.Print(), dec.Print())
+}
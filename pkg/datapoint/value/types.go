@@ -15,8 +15,9 @@ import (
 //
 //nolint:gomnd
 var registeredTypes = map[reflect.Type]uint32{
-	reflect.TypeOf((*StaticValue)(nil)): 0x00000001,
-	reflect.TypeOf((*Tick)(nil)):        0x00000002,
+	reflect.TypeOf((*StaticValue)(nil)):  0x00000001,
+	reflect.TypeOf((*Tick)(nil)):         0x00000002,
+	reflect.TypeOf((*TickInterval)(nil)): 0x00000003,
 }
 
 // Value is a data point value.
@@ -73,6 +74,17 @@ func RegisterType(value Value, id uint32) {
 	registeredTypes[rt] = id
 }
 
+// TypeID returns the registered ID of the given value type. If the type is
+// not registered, false is returned.
+func TypeID(value Value) (uint32, bool) {
+	rt := reflect.TypeOf(value)
+	if rt.Kind() != reflect.Ptr {
+		rt = reflect.PtrTo(rt)
+	}
+	id, ok := registeredTypes[rt]
+	return id, ok
+}
+
 // MarshalBinary serializes a Value to binary.
 //
 // The output includes the type ID followed by the binary representation of the
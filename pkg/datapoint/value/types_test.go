@@ -52,3 +52,16 @@ func TestUnmarshalBinary_UnknownType(t *testing.T) {
 	_, err := UnmarshalBinary(data)
 	assert.Error(t, err)
 }
+
+func TestTypeID(t *testing.T) {
+	id, ok := TypeID(Tick{})
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x00000002), id)
+
+	id, ok = TypeID(&mockValue{})
+	assert.True(t, ok)
+	assert.Equal(t, uint32(0x80000000), id)
+
+	_, ok = TypeID(struct{ Value }{})
+	assert.False(t, ok)
+}
@@ -29,6 +29,10 @@ func (r *Recoverer) RecoverTransaction(tx *types.Transaction) (*types.Address, e
 	return args.Get(0).(*types.Address), args.Error(1)
 }
 
+func (r *Recoverer) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
 func (r *Recoverer) Supports(_ context.Context, _ datapoint.Point) bool {
 	return true
 }
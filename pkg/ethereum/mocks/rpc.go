@@ -158,6 +158,11 @@ func (r *RPC) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (r *RPC) FeeHistory(ctx context.Context, blockCount uint64, newestBlock types.BlockNumber, rewardPercentiles []float64) (*types.FeeHistory, error) {
+	args := r.Called(ctx, blockCount, newestBlock, rewardPercentiles)
+	return args.Get(0).(*types.FeeHistory), args.Error(1)
+}
+
 func (r *RPC) SubscribeLogs(ctx context.Context, query types.FilterLogsQuery) (chan types.Log, error) {
 	args := r.Called(ctx, query)
 	return args.Get(0).(chan types.Log), args.Error(1)
@@ -31,6 +31,13 @@ var multiCallContracts = map[uint64]types.Address{
 	xdaiChainID:    types.MustAddressFromHex("0xb5b692a88bdfc81ca69dcb1d924f59f0413a602a"),
 }
 
+// multiCall3Contract is the address of the Multicall3 contract, which is
+// deployed at the same address on most chains. It is used for chains not
+// listed in multiCallContracts.
+//
+// https://github.com/mds1/multicall
+var multiCall3Contract = types.MustAddressFromHex("0xca11bde05977b3631167028862be2a173976ca11")
+
 func MultiCall(
 	ctx context.Context,
 	client rpc.RPC,
@@ -61,7 +68,7 @@ func MultiCall(
 	}
 	multicallContract, ok := multiCallContracts[chainID]
 	if !ok {
-		return nil, fmt.Errorf("multicall: unsupported chain id %d", chainID)
+		multicallContract = multiCall3Contract
 	}
 	callata, err := multicallMethod.EncodeArgs(multicallCalls)
 	if err != nil {
@@ -0,0 +1,71 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"context"
+
+	"github.com/defiweb/go-eth/rpc"
+	"github.com/defiweb/go-eth/rpc/transport"
+	"github.com/defiweb/go-eth/types"
+)
+
+// FeeHistoryProvider is implemented by RPC clients that support the
+// eth_feeHistory call.
+type FeeHistoryProvider interface {
+	// FeeHistory performs eth_feeHistory RPC call.
+	FeeHistory(
+		ctx context.Context,
+		blockCount uint64,
+		newestBlock types.BlockNumber,
+		rewardPercentiles []float64,
+	) (*types.FeeHistory, error)
+}
+
+// RPC extends the go-eth RPC client with calls that are not implemented
+// by the go-eth package.
+type RPC struct {
+	rpc.RPC
+	transport transport.Transport
+}
+
+// NewRPC returns a new RPC client that uses the given transport for calls
+// that are not implemented by the client.
+func NewRPC(client rpc.RPC, transport transport.Transport) *RPC {
+	return &RPC{RPC: client, transport: transport}
+}
+
+// FeeHistory implements the FeeHistoryProvider interface.
+func (r *RPC) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	newestBlock types.BlockNumber,
+	rewardPercentiles []float64,
+) (*types.FeeHistory, error) {
+	var res types.FeeHistory
+	err := r.transport.Call(
+		ctx,
+		&res,
+		"eth_feeHistory",
+		types.NumberFromUint64(blockCount),
+		newestBlock,
+		rewardPercentiles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
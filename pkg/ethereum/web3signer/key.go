@@ -0,0 +1,322 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package web3signer provides a wallet.Key implementation that delegates
+// signing to a remote signing service compatible with the Web3Signer eth1
+// signing API.
+package web3signer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+)
+
+const (
+	signPath       = "/api/v1/eth1/sign/"
+	defaultTimeout = 10 * time.Second
+	maxResponseLen = 1024
+)
+
+// ErrSignHashNotSupported is returned by Key.SignHash. The Web3Signer eth1
+// API always hashes the data before signing, so it is not possible to sign
+// an already computed hash.
+var ErrSignHashNotSupported = errors.New("web3signer: signing raw hashes is not supported")
+
+// Key is a wallet.Key implementation that uses a remote Web3Signer-compatible
+// service to sign data. The private key never leaves the signing service.
+//
+// The key uses the "/api/v1/eth1/sign/{identifier}" endpoint, which signs
+// keccak256 hash of the provided data. Messages are prefixed according to
+// EIP-191 and transactions are encoded to their signing payload before being
+// sent to the service, so that the produced signatures are the same as
+// those produced by a local key.
+type Key struct {
+	address   types.Address
+	url       string
+	client    *http.Client
+	recoverer crypto.Recoverer
+}
+
+// Config is the configuration for the Key.
+type Config struct {
+	// URL is the base URL of the signing service, e.g. "https://signer:9000".
+	URL string
+
+	// Address is the address of the key managed by the signing service.
+	// Every signature returned by the service is verified against this
+	// address.
+	Address types.Address
+
+	// Identifier is the key identifier used in the signing endpoint path.
+	// If empty, the address is used.
+	Identifier string
+
+	// Timeout is the timeout for HTTP requests. If zero, the default value
+	// is used (10 seconds).
+	//
+	// Ignored if Client is not nil.
+	Timeout time.Duration
+
+	// TLSCertFile is the path to the PEM encoded client certificate file.
+	TLSCertFile string
+
+	// TLSKeyFile is the path to the PEM encoded client private key file.
+	TLSKeyFile string
+
+	// TLSRootCAFile is the path to the PEM encoded root certificate file
+	// used to verify the server certificate.
+	TLSRootCAFile string
+
+	// TLSServerName is the server name used to verify the hostname on the
+	// returned certificates from the server.
+	TLSServerName string
+
+	// TLSInsecureSkipVerify disables server certificate verification.
+	TLSInsecureSkipVerify bool
+
+	// Client is an optional custom HTTP client. If provided, TLS options and
+	// Timeout are ignored.
+	Client *http.Client
+}
+
+// NewKey returns a new instance of the Key.
+func NewKey(cfg Config) (*Key, error) {
+	if len(cfg.URL) == 0 {
+		return nil, errors.New("web3signer: URL must be provided")
+	}
+	if cfg.Address == types.ZeroAddress {
+		return nil, errors.New("web3signer: address must be provided")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if len(cfg.Identifier) == 0 {
+		cfg.Identifier = cfg.Address.String()
+	}
+	client := cfg.Client
+	if client == nil {
+		tlsConfig, err := newTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client = &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		}
+	}
+	return &Key{
+		address:   cfg.Address,
+		url:       strings.TrimSuffix(cfg.URL, "/") + signPath + cfg.Identifier,
+		client:    client,
+		recoverer: crypto.ECRecoverer,
+	}, nil
+}
+
+// Address implements the wallet.Key interface.
+func (k *Key) Address() types.Address {
+	return k.address
+}
+
+// SignHash implements the wallet.Key interface.
+//
+// Signing raw hashes is not supported by the Web3Signer eth1 API, hence this
+// method always returns ErrSignHashNotSupported.
+func (k *Key) SignHash(_ types.Hash) (*types.Signature, error) {
+	return nil, ErrSignHashNotSupported
+}
+
+// SignMessage implements the wallet.Key interface.
+func (k *Key) SignMessage(data []byte) (*types.Signature, error) {
+	sig, err := k.sign(crypto.AddMessagePrefix(data))
+	if err != nil {
+		return nil, err
+	}
+	if sig.V.Cmp(big.NewInt(27)) < 0 {
+		sig.V = new(big.Int).Add(sig.V, big.NewInt(27))
+	}
+	if !k.VerifyMessage(data, *sig) {
+		return nil, errors.New("web3signer: signature does not match the key address")
+	}
+	return sig, nil
+}
+
+// SignTransaction implements the wallet.Key interface.
+func (k *Key) SignTransaction(tx *types.Transaction) error {
+	if tx.From != nil && *tx.From != k.address {
+		return fmt.Errorf("web3signer: invalid signer address: %s", tx.From)
+	}
+	payload, err := signingPayload(tx)
+	if err != nil {
+		return err
+	}
+	sig, err := k.sign(payload)
+	if err != nil {
+		return err
+	}
+	sv, sr, ss := sig.V, sig.R, sig.S
+	if sv.Cmp(big.NewInt(27)) >= 0 {
+		sv = new(big.Int).Sub(sv, big.NewInt(27))
+	}
+	switch tx.Type {
+	case types.LegacyTxType:
+		// The condition must match the one used in signingPayload,
+		// otherwise the signature cannot be recovered.
+		if hasEIP155ChainID(tx) {
+			sv = new(big.Int).Add(sv, new(big.Int).SetUint64(*tx.ChainID*2))
+			sv = new(big.Int).Add(sv, big.NewInt(35))
+		} else {
+			sv = new(big.Int).Add(sv, big.NewInt(27))
+		}
+	case types.AccessListTxType:
+	case types.DynamicFeeTxType:
+	default:
+		return fmt.Errorf("web3signer: unsupported transaction type: %d", tx.Type)
+	}
+	from := k.address
+	signed := *tx
+	signed.From = &from
+	signed.Signature = types.SignatureFromVRSPtr(sv, sr, ss)
+	addr, err := k.recoverer.RecoverTransaction(&signed)
+	if err != nil {
+		return fmt.Errorf("web3signer: unable to verify transaction signature: %w", err)
+	}
+	if *addr != k.address {
+		return errors.New("web3signer: signature does not match the key address")
+	}
+	*tx = signed
+	return nil
+}
+
+// SignData signs the keccak256 hash of the given data. It may be used
+// instead of SignHash when the data that is hashed is known, e.g. to sign
+// EIP-712 typed data.
+func (k *Key) SignData(data []byte) (*types.Signature, error) {
+	sig, err := k.sign(data)
+	if err != nil {
+		return nil, err
+	}
+	if sig.V.Cmp(big.NewInt(27)) < 0 {
+		sig.V = new(big.Int).Add(sig.V, big.NewInt(27))
+	}
+	if !k.VerifyHash(crypto.Keccak256(data), *sig) {
+		return nil, errors.New("web3signer: signature does not match the key address")
+	}
+	return sig, nil
+}
+
+// VerifyHash implements the wallet.Key interface.
+func (k *Key) VerifyHash(hash types.Hash, sig types.Signature) bool {
+	addr, err := k.recoverer.RecoverHash(hash, sig)
+	if err != nil {
+		return false
+	}
+	return *addr == k.address
+}
+
+// VerifyMessage implements the wallet.Key interface.
+func (k *Key) VerifyMessage(data []byte, sig types.Signature) bool {
+	addr, err := k.recoverer.RecoverMessage(data, sig)
+	if err != nil {
+		return false
+	}
+	return *addr == k.address
+}
+
+// sign sends the data to the signing service and returns the signature of
+// the keccak256 hash of the data.
+func (k *Key) sign(data []byte) (*types.Signature, error) {
+	body, err := json.Marshal(signRequest{Data: hexutil.BytesToHex(data)})
+	if err != nil {
+		return nil, err
+	}
+	res, err := k.client.Post(k.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("web3signer: request failed: %w", err)
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxResponseLen))
+	if err != nil {
+		return nil, fmt.Errorf("web3signer: unable to read response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"web3signer: unexpected status code %d: %s",
+			res.StatusCode,
+			strings.TrimSpace(string(resBody)),
+		)
+	}
+	// The service responds with a hex encoded signature, either as plain
+	// text or as a JSON string.
+	bin, err := hexutil.HexToBytes(strings.Trim(strings.TrimSpace(string(resBody)), `"`))
+	if err != nil {
+		return nil, fmt.Errorf("web3signer: invalid signature: %w", err)
+	}
+	if len(bin) != 65 {
+		return nil, fmt.Errorf("web3signer: invalid signature length: %d", len(bin))
+	}
+	sig, err := types.SignatureFromBytes(bin)
+	if err != nil {
+		return nil, fmt.Errorf("web3signer: invalid signature: %w", err)
+	}
+	return &sig, nil
+}
+
+type signRequest struct {
+	Data string `json:"data"`
+}
+
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.TLSServerName,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify, //nolint:gosec
+	}
+	if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("web3signer: unable to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if cfg.TLSRootCAFile != "" {
+		caCert, err := os.ReadFile(cfg.TLSRootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("web3signer: unable to read root CA file: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("web3signer: no valid certificates found in root CA file")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	return tlsConfig, nil
+}
+
+var _ wallet.Key = (*Key)(nil)
@@ -0,0 +1,179 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web3signer
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signerServer is a local stand-in for the Web3Signer eth1 signing API.
+func signerServer(t *testing.T, key *wallet.PrivateKey) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != signPath+key.Address().String() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req signRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		data, err := hexutil.HexToBytes(req.Data)
+		require.NoError(t, err)
+		sig, err := key.SignHash(crypto.Keccak256(data))
+		require.NoError(t, err)
+		sig.V = new(big.Int).Add(sig.V, big.NewInt(27))
+		_, _ = w.Write([]byte(hexutil.BytesToHex(sig.Bytes())))
+	}))
+}
+
+func TestKey_SignMessage(t *testing.T) {
+	local := wallet.NewRandomKey()
+	srv := signerServer(t, local)
+	defer srv.Close()
+
+	key, err := NewKey(Config{URL: srv.URL, Address: local.Address()})
+	require.NoError(t, err)
+
+	sig, err := key.SignMessage([]byte("hello"))
+	require.NoError(t, err)
+
+	expSig, err := local.SignMessage([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, expSig.Bytes(), sig.Bytes())
+	assert.True(t, key.VerifyMessage([]byte("hello"), *sig))
+}
+
+func TestKey_SignTransaction(t *testing.T) {
+	local := wallet.NewRandomKey()
+	srv := signerServer(t, local)
+	defer srv.Close()
+
+	key, err := NewKey(Config{URL: srv.URL, Address: local.Address()})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{
+			name: "legacy",
+			tx: (&types.Transaction{}).
+				SetType(types.LegacyTxType).
+				SetTo(types.MustAddressFromHex("0x1234567890123456789012345678901234567890")).
+				SetGasLimit(100000).
+				SetGasPrice(big.NewInt(1000000000)).
+				SetNonce(1).
+				SetChainID(1),
+		},
+		{
+			name: "dynamic fee",
+			tx: (&types.Transaction{}).
+				SetType(types.DynamicFeeTxType).
+				SetTo(types.MustAddressFromHex("0x1234567890123456789012345678901234567890")).
+				SetInput([]byte{1, 2, 3}).
+				SetGasLimit(100000).
+				SetMaxFeePerGas(big.NewInt(2000000000)).
+				SetMaxPriorityFeePerGas(big.NewInt(1000000000)).
+				SetNonce(2).
+				SetChainID(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remoteTx := *tt.tx
+			localTx := *tt.tx
+			require.NoError(t, key.SignTransaction(&remoteTx))
+			require.NoError(t, local.SignTransaction(&localTx))
+
+			remoteRaw, err := remoteTx.Raw()
+			require.NoError(t, err)
+			localRaw, err := localTx.Raw()
+			require.NoError(t, err)
+			assert.Equal(t, localRaw, remoteRaw)
+			assert.Equal(t, local.Address(), *remoteTx.From)
+		})
+	}
+}
+
+func TestKey_SignTransaction_LegacyZeroChainID(t *testing.T) {
+	local := wallet.NewRandomKey()
+	srv := signerServer(t, local)
+	defer srv.Close()
+
+	key, err := NewKey(Config{URL: srv.URL, Address: local.Address()})
+	require.NoError(t, err)
+
+	tx := (&types.Transaction{}).
+		SetType(types.LegacyTxType).
+		SetTo(types.MustAddressFromHex("0x1234567890123456789012345678901234567890")).
+		SetGasLimit(100000).
+		SetGasPrice(big.NewInt(1000000000)).
+		SetNonce(1).
+		SetChainID(0)
+	require.NoError(t, key.SignTransaction(tx))
+
+	// Without EIP-155, the V value must be 27 or 28.
+	assert.True(t, tx.Signature.V.Cmp(big.NewInt(27)) == 0 || tx.Signature.V.Cmp(big.NewInt(28)) == 0)
+}
+
+func TestKey_SignHash(t *testing.T) {
+	key, err := NewKey(Config{URL: "http://localhost", Address: wallet.NewRandomKey().Address()})
+	require.NoError(t, err)
+
+	_, err = key.SignHash(types.Hash{})
+	assert.ErrorIs(t, err, ErrSignHashNotSupported)
+}
+
+func TestKey_WrongAddress(t *testing.T) {
+	srv := signerServer(t, wallet.NewRandomKey())
+	defer srv.Close()
+
+	// The server uses a different key, so the request path does not match.
+	key, err := NewKey(Config{URL: srv.URL, Address: wallet.NewRandomKey().Address()})
+	require.NoError(t, err)
+
+	_, err = key.SignMessage([]byte("hello"))
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "404"))
+}
+
+func TestKey_SignatureMismatch(t *testing.T) {
+	local := wallet.NewRandomKey()
+	srv := signerServer(t, local)
+	defer srv.Close()
+
+	// The identifier points to the server key, but the expected address
+	// is different, so the returned signature must be rejected.
+	key, err := NewKey(Config{
+		URL:        srv.URL,
+		Address:    wallet.NewRandomKey().Address(),
+		Identifier: local.Address().String(),
+	})
+	require.NoError(t, err)
+
+	_, err = key.SignMessage([]byte("hello"))
+	assert.Error(t, err)
+}
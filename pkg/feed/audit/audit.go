@@ -0,0 +1,342 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+// DefaultMaxSize is the default maximum size of the audit log file in bytes
+// before it is rotated.
+const DefaultMaxSize = 100 * 1024 * 1024
+
+// DefaultMaxFiles is the default number of rotated audit log files to keep.
+const DefaultMaxFiles = 10
+
+// maxLineSize is the maximum size of a single audit log entry.
+const maxLineSize = 16 * 1024 * 1024
+
+// Entry is a single audit log entry that describes a signed data point.
+type Entry struct {
+	// Time is the time when the entry was recorded.
+	Time time.Time `json:"time"`
+
+	// Model is the data model of the data point.
+	Model string `json:"model"`
+
+	// Signer is the address of the feed that signed the data point.
+	Signer types.Address `json:"signer"`
+
+	// Signature is the data point signature.
+	Signature types.Signature `json:"signature"`
+
+	// SignatureScheme is the scheme used to create the signature.
+	SignatureScheme datapoint.SignatureScheme `json:"signatureScheme"`
+
+	// Value is the binary representation of the data point value, including
+	// its type ID. Together with Timestamp, it is used to verify the
+	// signature.
+	Value []byte `json:"value"`
+
+	// Timestamp is the data point time.
+	Timestamp int64 `json:"timestamp"`
+
+	// Point is the full data point, including the sub points trace.
+	Point json.RawMessage `json:"point"`
+
+	// BroadcastError is the error returned by the transport, if any.
+	BroadcastError string `json:"broadcastError,omitempty"`
+}
+
+// NewEntry creates a new audit log entry for a signed data point.
+func NewEntry(signer types.Address, msg *messages.DataPoint, broadcastErr error) (Entry, error) {
+	val, err := value.MarshalBinary(msg.Value.Value)
+	if err != nil {
+		return Entry{}, err
+	}
+	point, err := json.Marshal(msg.Value)
+	if err != nil {
+		return Entry{}, err
+	}
+	e := Entry{
+		Time:            time.Now().UTC(),
+		Model:           msg.Model,
+		Signer:          signer,
+		Signature:       msg.Signature,
+		SignatureScheme: msg.SignatureScheme,
+		Value:           val,
+		Timestamp:       msg.Value.Time.Unix(),
+		Point:           point,
+	}
+	if broadcastErr != nil {
+		e.BroadcastError = broadcastErr.Error()
+	}
+	return e, nil
+}
+
+// DataPoint returns the data point which signature was recorded. The data
+// point contains only the value and time, which are used to create the
+// signature.
+func (e Entry) DataPoint() (datapoint.Point, error) {
+	val, err := value.UnmarshalBinary(e.Value)
+	if err != nil {
+		return datapoint.Point{}, err
+	}
+	return datapoint.Point{Value: val, Time: time.Unix(e.Timestamp, 0)}, nil
+}
+
+// Verify recovers the signer address from the recorded signature and
+// compares it with the recorded signer address.
+func (e Entry) Verify(ctx context.Context, recoverers []datapoint.Recoverer) (*types.Address, error) {
+	point, err := e.DataPoint()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range recoverers {
+		if r.Scheme() != e.SignatureScheme || !r.Supports(ctx, point) {
+			continue
+		}
+		addr, err := r.Recover(ctx, e.Model, point, e.Signature)
+		if err != nil {
+			return nil, err
+		}
+		if *addr != e.Signer {
+			return addr, fmt.Errorf("signature belongs to %s, expected %s", addr, e.Signer)
+		}
+		return addr, nil
+	}
+	return nil, fmt.Errorf("unable to find recoverer for the %s signature scheme", e.SignatureScheme)
+}
+
+// FileLog is an append-only audit log stored in a file. The file is rotated
+// when it exceeds the maximum size. Rotated files have a numeric suffix,
+// where a higher number means an older file.
+type FileLog struct {
+	mu sync.Mutex
+
+	signer   types.Address
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// FileLogConfig is the configuration for FileLog.
+type FileLogConfig struct {
+	// Signer is the address of the feed that signs data points.
+	Signer types.Address
+
+	// Path is the path to the audit log file.
+	Path string
+
+	// MaxSize is the maximum size of the file in bytes before it is rotated.
+	// If zero, DefaultMaxSize is used.
+	MaxSize int64
+
+	// MaxFiles is the number of rotated files to keep. If zero,
+	// DefaultMaxFiles is used.
+	MaxFiles int
+}
+
+// NewFileLog creates a new FileLog instance.
+func NewFileLog(cfg FileLogConfig) (*FileLog, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("path must not be empty")
+	}
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = DefaultMaxFiles
+	}
+	return &FileLog{
+		signer:   cfg.Signer,
+		path:     cfg.Path,
+		maxSize:  cfg.MaxSize,
+		maxFiles: cfg.MaxFiles,
+	}, nil
+}
+
+// Record implements the feed.AuditLog interface.
+func (l *FileLog) Record(msg *messages.DataPoint, broadcastErr error) error {
+	e, err := NewEntry(l.signer, msg, broadcastErr)
+	if err != nil {
+		return err
+	}
+	return l.Append(e)
+}
+
+// Append appends the entry to the audit log.
+func (l *FileLog) Append(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			return err
+		}
+	}
+	if l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// Close closes the audit log file.
+func (l *FileLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+func (l *FileLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	s, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.file = f
+	l.size = s.Size()
+	return nil
+}
+
+func (l *FileLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	l.file = nil
+	_ = os.Remove(rotatedPath(l.path, l.maxFiles))
+	for i := l.maxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedPath(l.path, i), rotatedPath(l.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(l.path, rotatedPath(l.path, 1)); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+// Filter is used to select entries from the audit log.
+type Filter struct {
+	// Model is the data model to select. If empty, all models are selected.
+	Model string
+
+	// From selects entries recorded at or after the given time. Ignored if
+	// zero.
+	From time.Time
+
+	// To selects entries recorded at or before the given time. Ignored if
+	// zero.
+	To time.Time
+}
+
+// Match returns true if the entry matches the filter.
+func (f Filter) Match(e Entry) bool {
+	if f.Model != "" && f.Model != e.Model {
+		return false
+	}
+	if !f.From.IsZero() && e.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && e.Time.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// Read reads entries matching the filter from the audit log and its rotated
+// files, from the oldest to the newest, and calls fn for each of them.
+func Read(path string, filter Filter, fn func(Entry) error) error {
+	var paths []string
+	for i := 1; ; i++ {
+		if _, err := os.Stat(rotatedPath(path, i)); err != nil {
+			break
+		}
+		paths = append([]string{rotatedPath(path, i)}, paths...)
+	}
+	paths = append(paths, path)
+	for _, p := range paths {
+		if err := readFile(p, filter, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readFile(path string, filter Filter, fn func(Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, maxLineSize)
+	for n := 1; s.Scan(); n++ {
+		var e Entry
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		if !filter.Match(e) {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
+
+func rotatedPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
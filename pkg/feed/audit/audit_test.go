@@ -0,0 +1,107 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/wallet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func signedMessage(t *testing.T, key wallet.Key, model string, price float64) *messages.DataPoint {
+	point := datapoint.Point{
+		Value: value.Tick{Pair: value.Pair{Base: "AAA", Quote: "BBB"}, Price: bn.Float(price)},
+		Time:  time.Unix(1234567890, 0),
+		SubPoints: []datapoint.Point{{
+			Value: value.Tick{Pair: value.Pair{Base: "AAA", Quote: "BBB"}, Price: bn.Float(price)},
+			Time:  time.Unix(1234567890, 0),
+			Meta:  map[string]any{"origin": "test"},
+		}},
+	}
+	sig, err := signer.NewTickSigner(key).Sign(context.Background(), model, point)
+	require.NoError(t, err)
+	return &messages.DataPoint{Model: model, Value: point, Signature: *sig}
+}
+
+func TestFileLog(t *testing.T) {
+	key := wallet.NewRandomKey()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	l, err := NewFileLog(FileLogConfig{Signer: key.Address(), Path: path, MaxSize: 1024, MaxFiles: 100})
+	require.NoError(t, err)
+
+	for i := 1; i <= 10; i++ {
+		model := "AAABBB"
+		if i%2 == 0 {
+			model = "CCCDDD"
+		}
+		var broadcastErr error
+		if i == 3 {
+			broadcastErr = errors.New("broadcast failed")
+		}
+		require.NoError(t, l.Record(signedMessage(t, key, model, float64(i)), broadcastErr))
+	}
+	require.NoError(t, l.Close())
+
+	// File must be rotated.
+	assert.FileExists(t, path+".1")
+
+	recoverers := []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)}
+	var prices []float64
+	err = Read(path, Filter{Model: "AAABBB"}, func(e Entry) error {
+		addr, err := e.Verify(context.Background(), recoverers)
+		require.NoError(t, err)
+		assert.Equal(t, key.Address(), *addr)
+		assert.Contains(t, string(e.Point), `"ticks"`)
+		if e.BroadcastError != "" {
+			assert.Equal(t, "broadcast failed", e.BroadcastError)
+		}
+		p, err := e.DataPoint()
+		require.NoError(t, err)
+		prices = append(prices, p.Value.(value.Tick).Price.Float64())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 3, 5, 7, 9}, prices)
+
+	// Filter by time.
+	var n int
+	require.NoError(t, Read(path, Filter{To: time.Unix(0, 0)}, func(e Entry) error {
+		n++
+		return nil
+	}))
+	assert.Equal(t, 0, n)
+}
+
+func TestEntry_Verify_InvalidSigner(t *testing.T) {
+	key := wallet.NewRandomKey()
+	e, err := NewEntry(wallet.NewRandomKey().Address(), signedMessage(t, key, "AAABBB", 1), nil)
+	require.NoError(t, err)
+	_, err = e.Verify(context.Background(), []datapoint.Recoverer{signer.NewTickRecoverer(crypto.ECRecoverer)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package feed
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// PeerStore provides the latest data points received from other feeds.
+type PeerStore interface {
+	// Latest returns the latest data points from all addresses.
+	Latest(ctx context.Context, model string) (map[types.Address]store.StoredDataPoint, error)
+}
+
+// ConsistencyCheck compares data points with the median of data points
+// received from other feeds before they are broadcast.
+//
+// Only data points with numeric values are checked.
+type ConsistencyCheck struct {
+	// Store provides data points received from other feeds.
+	Store PeerStore
+
+	// Self is the address of the feed. Data points from this address are
+	// ignored.
+	Self types.Address
+
+	// MaxDeviation is the maximum relative deviation from the median of
+	// other feeds, e.g. 0.05 means 5%.
+	MaxDeviation float64
+
+	// MinPeers is the minimum number of data points from other feeds
+	// required to perform the check. If there are fewer data points, the
+	// check is skipped.
+	MinPeers int
+
+	// MaxAge is the maximum age of data points from other feeds. Older data
+	// points are ignored. If zero, data points are not filtered by age.
+	MaxAge time.Duration
+
+	// Withhold specifies whether data points that deviate too much should be
+	// withheld. If false, such data points are broadcast with the
+	// ConsistencyMetaKey meta field set to the deviation.
+	Withhold bool
+}
+
+// ConsistencyMetaKey is the meta key added to data points that deviate from
+// the median of other feeds by more than the allowed deviation.
+const ConsistencyMetaKey = "consistency_deviation"
+
+// checkConsistency compares the data point with the median of other feeds.
+//
+// It returns the data point, which may be flagged in meta, and false if the
+// data point must not be broadcast.
+func (f *Feed) checkConsistency(model string, point datapoint.Point, now time.Time) (datapoint.Point, bool) {
+	c := f.consistency
+	if c == nil {
+		return point, true
+	}
+	num, ok := point.Value.(value.NumericValue)
+	if !ok || num.Number() == nil {
+		return point, true
+	}
+	peers, err := c.Store.Latest(f.ctx, model)
+	if err != nil {
+		f.log.
+			WithError(err).
+			WithField("model", model).
+			Error("Unable to get data points from other feeds")
+		return point, true
+	}
+	var prices []*bn.FloatNumber
+	for addr, peer := range peers {
+		if addr == c.Self {
+			continue
+		}
+		if c.MaxAge > 0 && now.Sub(peer.DataPoint.Time) > c.MaxAge {
+			continue
+		}
+		peerNum, ok := peer.DataPoint.Value.(value.NumericValue)
+		if !ok || peerNum.Number() == nil {
+			continue
+		}
+		prices = append(prices, peerNum.Number())
+	}
+	if len(prices) == 0 || len(prices) < c.MinPeers {
+		f.log.
+			WithField("model", model).
+			WithField("peers", len(prices)).
+			Debug("Not enough data points from other feeds to perform consistency check")
+		return point, true
+	}
+	med := median(prices)
+	if med.Sign() == 0 {
+		return point, true
+	}
+	deviation := num.Number().Sub(med).Div(med).Abs().Float64()
+	if deviation <= c.MaxDeviation {
+		return point, true
+	}
+	f.log.
+		WithField("model", model).
+		WithField("peers", len(prices)).
+		WithField("peerMedian", med.String()).
+		WithField("deviation", deviation).
+		WithField("maxDeviation", c.MaxDeviation).
+		WithField("withheld", c.Withhold).
+		WithFields(point.LogFields()).
+		Error("Data point deviates from the median of other feeds")
+	if c.Withhold {
+		return point, false
+	}
+	meta := make(map[string]any, len(point.Meta)+1)
+	for k, v := range point.Meta {
+		meta[k] = v
+	}
+	meta[ConsistencyMetaKey] = deviation
+	point.Meta = meta
+	return point, true
+}
+
+func median(xs []*bn.FloatNumber) *bn.FloatNumber {
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].Cmp(xs[j]) < 0
+	})
+	if len(xs)%2 == 0 {
+		return xs[len(xs)/2-1].Add(xs[len(xs)/2]).Div(bn.Float(2))
+	}
+	return xs[len(xs)/2]
+}
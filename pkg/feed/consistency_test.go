@@ -0,0 +1,101 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
+)
+
+type mockPeerStore map[types.Address]store.StoredDataPoint
+
+func (m mockPeerStore) Latest(_ context.Context, _ string) (map[types.Address]store.StoredDataPoint, error) {
+	return m, nil
+}
+
+func TestFeed_checkConsistency(t *testing.T) {
+	now := time.Unix(1000, 0)
+	self := types.MustAddressFromHex("0x0000000000000000000000000000000000000001")
+	peer := func(price float64, age time.Duration) store.StoredDataPoint {
+		return store.StoredDataPoint{
+			DataPoint: datapoint.Point{
+				Value: value.Tick{Pair: value.Pair{Base: "AAA", Quote: "BBB"}, Price: bn.Float(price)},
+				Time:  now.Add(-age),
+			},
+		}
+	}
+	peers := mockPeerStore{
+		self: peer(200, 0),
+		types.MustAddressFromHex("0x0000000000000000000000000000000000000002"): peer(100, 0),
+		types.MustAddressFromHex("0x0000000000000000000000000000000000000003"): peer(101, 0),
+		types.MustAddressFromHex("0x0000000000000000000000000000000000000004"): peer(102, 0),
+		types.MustAddressFromHex("0x0000000000000000000000000000000000000005"): peer(200, time.Hour),
+	}
+	tests := []struct {
+		name      string
+		price     float64
+		withhold  bool
+		minPeers  int
+		expectOK  bool
+		expectTag bool
+	}{
+		{name: "consistent", price: 103, withhold: true, minPeers: 3, expectOK: true},
+		{name: "withheld", price: 110, withhold: true, minPeers: 3, expectOK: false},
+		{name: "flagged", price: 110, withhold: false, minPeers: 3, expectOK: true, expectTag: true},
+		{name: "not enough peers", price: 110, withhold: true, minPeers: 4, expectOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed, err := New(Config{
+				DataModels: []string{"AAABBB"},
+				Transport:  local.New([]byte("test"), 0, map[string]transport.Message{}),
+				Interval:   timeutil.NewTicker(0),
+				ConsistencyCheck: &ConsistencyCheck{
+					Store:        peers,
+					Self:         self,
+					MaxDeviation: 0.05,
+					MinPeers:     tt.minPeers,
+					MaxAge:       time.Minute,
+					Withhold:     tt.withhold,
+				},
+			})
+			require.NoError(t, err)
+			feed.ctx = context.Background()
+
+			point := datapoint.Point{
+				Value: value.Tick{Pair: value.Pair{Base: "AAA", Quote: "BBB"}, Price: bn.Float(tt.price)},
+				Time:  now,
+			}
+			point, ok := feed.checkConsistency("AAABBB", point, now)
+			assert.Equal(t, tt.expectOK, ok)
+			_, tagged := point.Meta[ConsistencyMetaKey]
+			assert.Equal(t, tt.expectTag, tagged)
+		})
+	}
+}
@@ -18,10 +18,17 @@ package feed
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
@@ -37,11 +44,70 @@ type Feed struct {
 	waitCh chan error
 	log    log.Logger
 
+	mu           sync.Mutex
 	dataProvider datapoint.Provider
-	dataModels   []string
+	schedules    []Schedule
 	signers      []datapoint.Signer
 	transport    transport.Service
-	interval     *timeutil.Ticker
+	deviations   map[string]Deviation
+	consistency  *ConsistencyCheck
+	auditLog     AuditLog
+	batch        bool
+	compact      bool
+	publishV1    bool
+	publishV2    bool
+	last         map[string]lastBroadcast
+}
+
+// AuditLog records every signed data point together with the result of
+// its broadcast.
+type AuditLog interface {
+	// Record records the signed data point. The broadcastErr is the error
+	// returned by the transport, or nil if the broadcast succeeded.
+	Record(msg *messages.DataPoint, broadcastErr error) error
+}
+
+// DefaultSchedule is the name of the schedule for data models that are not
+// assigned to any other schedule.
+const DefaultSchedule = "default"
+
+// SamplingSchedule is the name of the schedule for data models with
+// a deviation, if the Config.SamplingInterval is set.
+const SamplingSchedule = "sampling"
+
+// Schedule is a group of data models that are broadcast using the same
+// ticker.
+type Schedule struct {
+	// Name is the name of the schedule, used in logs.
+	Name string
+
+	// DataModels is a list of data models broadcast by the schedule.
+	DataModels []string
+
+	// Interval describes how often data points of the data models should
+	// be sent to the network.
+	Interval *timeutil.Ticker
+}
+
+// Deviation describes when a data point of a data model should be broadcast.
+//
+// The data point is broadcast if its value deviates from the last broadcast
+// value by at least Threshold, or if Heartbeat has elapsed since the last
+// broadcast. Only data points with numeric values are compared, other data
+// points are broadcast on every interval.
+type Deviation struct {
+	// Threshold is a minimum relative deviation from the last broadcast
+	// value, e.g. 0.01 means 1%.
+	Threshold float64
+
+	// Heartbeat is a maximum time between broadcasts.
+	Heartbeat time.Duration
+}
+
+// lastBroadcast is the last broadcast value of a data model.
+type lastBroadcast struct {
+	value *bn.FloatNumber
+	time  time.Time
 }
 
 // Config is the configuration for the Feed.
@@ -60,8 +126,55 @@ type Config struct {
 	Transport transport.Service
 
 	// Interval describes how often data points should be sent to the network.
+	// It is used for data models that are not assigned to any schedule in
+	// Schedules.
+	//
+	// If a data model has a deviation configured in Deviations, the interval
+	// describes how often the data point is sampled, and it is sent only
+	// if the deviation or heartbeat condition is met, unless
+	// SamplingInterval is set.
 	Interval *timeutil.Ticker
 
+	// Schedules is an optional list of schedules with their own intervals.
+	// Every data model listed in a schedule must also be listed in
+	// DataModels, and may be assigned to only one schedule.
+	Schedules []Schedule
+
+	// Deviations is an optional map of data models to the deviation
+	// conditions that must be met to broadcast the data point.
+	Deviations map[string]Deviation
+
+	// SamplingInterval is an optional ticker that describes how often data
+	// points of data models with a deviation configured in Deviations are
+	// sampled. If set, these data models are removed from their schedules
+	// and sampled using this ticker instead, so a deviation can trigger
+	// a broadcast between heartbeats. If nil, they are sampled on the
+	// interval of their schedule.
+	SamplingInterval *timeutil.Ticker
+
+	// ConsistencyCheck is an optional check that compares data points with
+	// data points of other feeds before they are broadcast.
+	ConsistencyCheck *ConsistencyCheck
+
+	// AuditLog is an optional log that records every signed data point.
+	AuditLog AuditLog
+
+	// Batch enables sending all data points of a schedule tick in
+	// DataPointBatch messages instead of separate DataPoint messages.
+	// It applies only to the v1 topic.
+	Batch bool
+
+	// Topics is a list of topics on which data points are broadcast.
+	// Supported topics are messages.DataPointV1MessageName and
+	// messages.DataPointV2MessageName. Both topics may be used at the same
+	// time to support receivers during the migration to the v2 format.
+	// If empty, data points are broadcast only on the v1 topic.
+	Topics []string
+
+	// Compact enables the compact mode of v2 data point messages, in which
+	// sub points are not sent.
+	Compact bool
+
 	// Logger is a current logger interface used by the Feed.
 	// If nil, null logger will be used.
 	Logger log.Logger
@@ -78,14 +191,36 @@ func New(cfg Config) (*Feed, error) {
 	if cfg.Logger == nil {
 		cfg.Logger = null.New()
 	}
+	if cfg.ConsistencyCheck != nil && cfg.ConsistencyCheck.Store == nil {
+		return nil, errors.New("consistency check store must not be nil")
+	}
+	schedules, err := schedules(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Topics) == 0 {
+		cfg.Topics = []string{messages.DataPointV1MessageName}
+	}
+	for _, topic := range cfg.Topics {
+		if topic != messages.DataPointV1MessageName && topic != messages.DataPointV2MessageName {
+			return nil, fmt.Errorf("unsupported data point topic: %s", topic)
+		}
+	}
 	g := &Feed{
 		waitCh:       make(chan error),
 		log:          cfg.Logger.WithField("tag", LoggerTag),
 		dataProvider: cfg.DataProvider,
-		dataModels:   cfg.DataModels,
+		schedules:    schedules,
 		signers:      cfg.Signers,
 		transport:    cfg.Transport,
-		interval:     cfg.Interval,
+		deviations:   cfg.Deviations,
+		consistency:  cfg.ConsistencyCheck,
+		auditLog:     cfg.AuditLog,
+		batch:        cfg.Batch,
+		compact:      cfg.Compact,
+		publishV1:    slices.Contains(cfg.Topics, messages.DataPointV1MessageName),
+		publishV2:    slices.Contains(cfg.Topics, messages.DataPointV2MessageName),
+		last:         make(map[string]lastBroadcast),
 	}
 	return g, nil
 }
@@ -100,8 +235,19 @@ func (f *Feed) Start(ctx context.Context) error {
 	}
 	f.log.Debug("Starting")
 	f.ctx = ctx
-	f.interval.Start(f.ctx)
-	go f.broadcasterRoutine()
+	for _, s := range f.schedules {
+		f.log.
+			WithFields(log.Fields{
+				"schedule": s.Name,
+				"models":   s.DataModels,
+				"interval": s.Interval.Duration().String(),
+				"aligned":  s.Interval.Aligned(),
+				"offset":   s.Interval.Offset().String(),
+			}).
+			Info("Broadcast schedule")
+		s.Interval.Start(f.ctx)
+		go f.broadcasterRoutine(s)
+	}
 	go f.contextCancelHandler()
 	return nil
 }
@@ -111,8 +257,95 @@ func (f *Feed) Wait() <-chan error {
 	return f.waitCh
 }
 
-// broadcast sends data point to the network.
-func (f *Feed) broadcast(model string, point datapoint.Point) {
+// shouldBroadcast returns true if the data point should be broadcast
+// according to the deviation configured for the data model.
+func (f *Feed) shouldBroadcast(model string, point datapoint.Point, now time.Time) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dev, ok := f.deviations[model]
+	if !ok {
+		return true
+	}
+	num, ok := point.Value.(value.NumericValue)
+	if !ok || num.Number() == nil {
+		return true
+	}
+	last, ok := f.last[model]
+	if !ok {
+		return true
+	}
+	if now.Sub(last.time) >= dev.Heartbeat {
+		return true
+	}
+	if last.value.Sign() == 0 {
+		return num.Number().Sign() != 0
+	}
+	diff := num.Number().Sub(last.value).Div(last.value).Abs()
+	return diff.Cmp(bn.Float(dev.Threshold)) >= 0
+}
+
+// updateLast stores the broadcast value of the data model, so it can be
+// used to calculate the deviation of the next data point.
+func (f *Feed) updateLast(model string, point datapoint.Point, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.deviations[model]; !ok {
+		return
+	}
+	num, ok := point.Value.(value.NumericValue)
+	if !ok || num.Number() == nil {
+		return
+	}
+	f.last[model] = lastBroadcast{value: num.Number(), time: now}
+}
+
+// broadcast sends signed data points to the network in separate v1
+// messages. It returns the broadcast error of every message.
+func (f *Feed) broadcast(msgs []*messages.DataPoint) []error {
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		errs[i] = f.transport.Broadcast(messages.DataPointV1MessageName, msg)
+		if errs[i] != nil {
+			f.log.
+				WithError(errs[i]).
+				WithFields(msg.LogFields()).
+				Error("Unable to broadcast data point")
+		} else {
+			f.log.
+				WithFields(msg.LogFields()).
+				Info("Data point broadcast")
+		}
+	}
+	return errs
+}
+
+// broadcastV2 sends all signatures of a data point to the network in a
+// single v2 message.
+func (f *Feed) broadcastV2(msgs []*messages.DataPoint) error {
+	msg, err := messages.NewDataPointV2(msgs...)
+	if err != nil {
+		f.log.
+			WithError(err).
+			Error("Unable to create data point message")
+		return err
+	}
+	msg.Compact = f.compact
+	if err := f.transport.Broadcast(messages.DataPointV2MessageName, msg); err != nil {
+		f.log.
+			WithError(err).
+			WithFields(msg.LogFields()).
+			Error("Unable to broadcast data point")
+		return err
+	}
+	f.log.
+		WithFields(msg.LogFields()).
+		Info("Data point broadcast")
+	return nil
+}
+
+// sign signs the data point using every signer that supports it.
+func (f *Feed) sign(model string, point datapoint.Point) []*messages.DataPoint {
+	var msgs []*messages.DataPoint
 	found := false
 	for _, signer := range f.signers {
 		if !signer.Supports(f.ctx, point) {
@@ -125,22 +358,14 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 				WithError(err).
 				WithFields(point.LogFields()).
 				Error("Unable to sign data point")
+			continue
 		}
-		msg := &messages.DataPoint{
-			Model:     model,
-			Value:     point,
-			Signature: *sig,
-		}
-		if err := f.transport.Broadcast(messages.DataPointV1MessageName, msg); err != nil {
-			f.log.
-				WithError(err).
-				WithFields(msg.LogFields()).
-				Error("Unable to broadcast data point")
-		} else {
-			f.log.
-				WithFields(msg.LogFields()).
-				Info("Data point broadcast")
-		}
+		msgs = append(msgs, &messages.DataPoint{
+			Model:           model,
+			Value:           point,
+			Signature:       *sig,
+			SignatureScheme: signer.Scheme(),
+		})
 	}
 	if !found {
 		f.log.
@@ -148,39 +373,241 @@ func (f *Feed) broadcast(model string, point datapoint.Point) {
 			WithFields(point.LogFields()).
 			Warn("Unable to find handler for data point")
 	}
+	return msgs
+}
+
+// record records the signed data point in the audit log, if configured.
+//
+// The data point is recorded once, even if it is broadcast on both v1 and
+// v2 topics. The v1Err and v2Err are the broadcast errors on these topics,
+// or nil if the broadcast succeeded or the topic is not used.
+func (f *Feed) record(msg *messages.DataPoint, v1Err, v2Err error) {
+	if f.auditLog == nil {
+		return
+	}
+	var broadcastErr error
+	switch {
+	case f.publishV1 && f.publishV2:
+		var errs []error
+		if v1Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", messages.DataPointV1MessageName, v1Err))
+		}
+		if v2Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", messages.DataPointV2MessageName, v2Err))
+		}
+		broadcastErr = errors.Join(errs...)
+	case f.publishV2:
+		broadcastErr = v2Err
+	default:
+		broadcastErr = v1Err
+	}
+	if err := f.auditLog.Record(msg, broadcastErr); err != nil {
+		f.log.
+			WithError(err).
+			WithFields(msg.LogFields()).
+			Error("Unable to record data point in the audit log")
+	}
+}
+
+// pendingDataPoint is a data point waiting to be sent in a batch.
+type pendingDataPoint struct {
+	model string
+	point datapoint.Point
+	now   time.Time
+	msgs  []*messages.DataPoint
+
+	// v2Err is the error of the v2 broadcast of the data point, if the v2
+	// topic is used. It is recorded in the audit log together with the
+	// result of the batch broadcast.
+	v2Err error
+}
+
+// broadcastBatch sends signed data points to the network in DataPointBatch
+// messages. Data points that do not fit into a single message are split
+// into multiple batches.
+func (f *Feed) broadcastBatch(pending []pendingDataPoint) {
+	var (
+		msgs  []*messages.DataPoint
+		errs  = make(map[*messages.DataPoint]error)
+		chunk = messages.MaxDataPointBatchSize
+	)
+	for _, p := range pending {
+		msgs = append(msgs, p.msgs...)
+	}
+	for len(msgs) > 0 {
+		n := chunk
+		if len(msgs) < n {
+			n = len(msgs)
+		}
+		batch := &messages.DataPointBatch{DataPoints: msgs[:n]}
+		msgs = msgs[n:]
+		err := f.transport.Broadcast(messages.DataPointBatchV1MessageName, batch)
+		for _, msg := range batch.DataPoints {
+			errs[msg] = err
+		}
+		if err != nil {
+			f.log.
+				WithError(err).
+				WithField("size", len(batch.DataPoints)).
+				Error("Unable to broadcast data point batch")
+			continue
+		}
+		f.log.
+			WithField("size", len(batch.DataPoints)).
+			Info("Data point batch broadcast")
+	}
+	for _, p := range pending {
+		sent := f.publishV2 && p.v2Err == nil
+		for _, msg := range p.msgs {
+			f.record(msg, errs[msg], p.v2Err)
+			if errs[msg] == nil {
+				sent = true
+			}
+		}
+		if sent {
+			f.updateLast(p.model, p.point, p.now)
+		}
+	}
 }
 
-func (f *Feed) broadcasterRoutine() {
+func (f *Feed) broadcasterRoutine(s Schedule) {
 	for {
 		select {
 		case <-f.ctx.Done():
 			return
-		case <-f.interval.TickCh():
+		case <-s.Interval.TickCh():
 			// Fetch all data points from the provider to update them
 			// at once.
-			_, err := f.dataProvider.DataPoints(f.ctx, f.dataModels...)
+			_, err := f.dataProvider.DataPoints(f.ctx, s.DataModels...)
 			if err != nil {
 				f.log.
 					WithError(err).
+					WithField("schedule", s.Name).
 					Error("Unable to update data points")
 				continue
 			}
 
 			// Send data points to the network.
-			for _, model := range f.dataModels {
+			var pending []pendingDataPoint
+			for _, model := range s.DataModels {
 				point, err := f.dataProvider.DataPoint(f.ctx, model)
 				if err != nil {
 					f.log.
 						WithError(err).
+						WithField("schedule", s.Name).
 						Error("Unable to get data points")
 					continue
 				}
-				f.broadcast(model, point)
+				now := time.Now()
+				if !f.shouldBroadcast(model, point, now) {
+					f.log.
+						WithField("model", model).
+						WithFields(point.LogFields()).
+						Debug("Data point deviation is below threshold, skipping broadcast")
+					continue
+				}
+				point, ok := f.checkConsistency(model, point, now)
+				if !ok {
+					continue
+				}
+				msgs := f.sign(model, point)
+				if len(msgs) == 0 {
+					continue
+				}
+				var v2Err error
+				if f.publishV2 {
+					v2Err = f.broadcastV2(msgs)
+				}
+				if f.publishV1 && f.batch {
+					// Data points are recorded in the audit log after
+					// the batch is sent.
+					pending = append(pending, pendingDataPoint{model: model, point: point, now: now, msgs: msgs, v2Err: v2Err})
+					continue
+				}
+				sent := f.publishV2 && v2Err == nil
+				v1Errs := make([]error, len(msgs))
+				if f.publishV1 {
+					v1Errs = f.broadcast(msgs)
+				}
+				for i, msg := range msgs {
+					f.record(msg, v1Errs[i], v2Err)
+					if f.publishV1 && v1Errs[i] == nil {
+						sent = true
+					}
+				}
+				if sent {
+					f.updateLast(model, point, now)
+				}
+			}
+			if len(pending) > 0 {
+				f.broadcastBatch(pending)
 			}
 		}
 	}
 }
 
+// schedules returns the list of schedules from the configuration. Data
+// models that are not assigned to any schedule are added to the default
+// schedule that uses the Config.Interval ticker. If the
+// Config.SamplingInterval is set, data models with a deviation are moved
+// to the sampling schedule.
+func schedules(cfg Config) ([]Schedule, error) {
+	var (
+		res       []Schedule
+		scheduled = make(map[string]string)
+		sampled   []string
+	)
+	isSampled := func(model string) bool {
+		if cfg.SamplingInterval == nil {
+			return false
+		}
+		_, ok := cfg.Deviations[model]
+		return ok
+	}
+	for _, s := range cfg.Schedules {
+		if s.Interval == nil {
+			return nil, fmt.Errorf("interval of the %s schedule must not be nil", s.Name)
+		}
+		var models []string
+		for _, model := range s.DataModels {
+			if name, ok := scheduled[model]; ok {
+				return nil, fmt.Errorf("data model %s is assigned to both %s and %s schedules", model, name, s.Name)
+			}
+			if !slices.Contains(cfg.DataModels, model) {
+				return nil, fmt.Errorf("data model %s of the %s schedule is not listed in data models", model, s.Name)
+			}
+			scheduled[model] = s.Name
+			if !isSampled(model) {
+				models = append(models, model)
+			}
+		}
+		if len(models) > 0 {
+			s.DataModels = models
+			res = append(res, s)
+		}
+	}
+	var models []string
+	for _, model := range cfg.DataModels {
+		if isSampled(model) {
+			sampled = append(sampled, model)
+			continue
+		}
+		if _, ok := scheduled[model]; !ok {
+			models = append(models, model)
+		}
+	}
+	if len(models) > 0 {
+		if cfg.Interval == nil {
+			return nil, errors.New("interval must not be nil")
+		}
+		res = append([]Schedule{{Name: DefaultSchedule, DataModels: models, Interval: cfg.Interval}}, res...)
+	}
+	if len(sampled) > 0 {
+		res = append(res, Schedule{Name: SamplingSchedule, DataModels: sampled, Interval: cfg.SamplingInterval})
+	}
+	return res, nil
+}
+
 func (f *Feed) contextCancelHandler() {
 	defer func() { close(f.waitCh) }()
 	defer f.log.Info("Stopped")
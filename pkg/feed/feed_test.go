@@ -33,6 +33,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
@@ -43,6 +44,10 @@ var (
 
 type mockSigner struct{}
 
+func (s mockSigner) Scheme() datapoint.SignatureScheme {
+	return datapoint.SignatureSchemeLegacy
+}
+
 func (s mockSigner) Supports(_ context.Context, data datapoint.Point) bool {
 	_, ok := data.Value.(pointValue)
 	return ok
@@ -192,6 +197,180 @@ func TestFeed_Broadcast(t *testing.T) {
 	}
 }
 
+func TestFeed_shouldBroadcast(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tick := func(price float64) datapoint.Point {
+		return datapoint.Point{Value: value.Tick{Pair: value.Pair{Base: "AAA", Quote: "BBB"}, Price: bn.Float(price)}}
+	}
+	tests := []struct {
+		name     string
+		model    string
+		last     *lastBroadcast
+		point    datapoint.Point
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "model without deviation",
+			model:    "CCCDDD",
+			last:     &lastBroadcast{value: bn.Float(100), time: now},
+			point:    tick(100),
+			now:      now,
+			expected: true,
+		},
+		{
+			name:     "first broadcast",
+			model:    "AAABBB",
+			point:    tick(100),
+			now:      now,
+			expected: true,
+		},
+		{
+			name:     "non-numeric value",
+			model:    "AAABBB",
+			last:     &lastBroadcast{value: bn.Float(100), time: now},
+			point:    datapoint.Point{Value: pointValue{value: "foo"}},
+			now:      now,
+			expected: true,
+		},
+		{
+			name:     "below threshold",
+			model:    "AAABBB",
+			last:     &lastBroadcast{value: bn.Float(100), time: now},
+			point:    tick(100.5),
+			now:      now.Add(time.Minute),
+			expected: false,
+		},
+		{
+			name:     "above threshold",
+			model:    "AAABBB",
+			last:     &lastBroadcast{value: bn.Float(100), time: now},
+			point:    tick(98.5),
+			now:      now.Add(time.Minute),
+			expected: true,
+		},
+		{
+			name:     "heartbeat",
+			model:    "AAABBB",
+			last:     &lastBroadcast{value: bn.Float(100), time: now},
+			point:    tick(100),
+			now:      now.Add(time.Hour),
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed, err := New(Config{
+				DataModels: []string{"AAABBB", "CCCDDD"},
+				Transport:  local.New([]byte("test"), 0, map[string]transport.Message{}),
+				Interval:   timeutil.NewTicker(0),
+				Deviations: map[string]Deviation{
+					"AAABBB": {Threshold: 0.01, Heartbeat: time.Hour},
+				},
+			})
+			require.NoError(t, err)
+			if tt.last != nil {
+				feed.last[tt.model] = *tt.last
+			}
+			assert.Equal(t, tt.expected, feed.shouldBroadcast(tt.model, tt.point, tt.now))
+		})
+	}
+}
+
+func TestFeed_Schedules(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedules []Schedule
+		expected  map[string][]string
+		wantErr   bool
+	}{
+		{
+			name:     "default schedule only",
+			expected: map[string][]string{DefaultSchedule: {"AAABBB", "CCCDDD", "EEEFFF"}},
+		},
+		{
+			name: "custom schedule",
+			schedules: []Schedule{
+				{Name: "slow", DataModels: []string{"CCCDDD"}, Interval: timeutil.NewTicker(time.Hour)},
+			},
+			expected: map[string][]string{
+				DefaultSchedule: {"AAABBB", "EEEFFF"},
+				"slow":          {"CCCDDD"},
+			},
+		},
+		{
+			name: "all models scheduled",
+			schedules: []Schedule{
+				{Name: "slow", DataModels: []string{"AAABBB", "CCCDDD", "EEEFFF"}, Interval: timeutil.NewTicker(time.Hour)},
+			},
+			expected: map[string][]string{"slow": {"AAABBB", "CCCDDD", "EEEFFF"}},
+		},
+		{
+			name: "model in two schedules",
+			schedules: []Schedule{
+				{Name: "a", DataModels: []string{"AAABBB"}, Interval: timeutil.NewTicker(time.Hour)},
+				{Name: "b", DataModels: []string{"AAABBB"}, Interval: timeutil.NewTicker(time.Hour)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown model",
+			schedules: []Schedule{
+				{Name: "a", DataModels: []string{"XXXYYY"}, Interval: timeutil.NewTicker(time.Hour)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed, err := New(Config{
+				DataModels: []string{"AAABBB", "CCCDDD", "EEEFFF"},
+				Transport:  local.New([]byte("test"), 0, map[string]transport.Message{}),
+				Interval:   timeutil.NewTicker(time.Minute),
+				Schedules:  tt.schedules,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			schedules := make(map[string][]string)
+			for _, s := range feed.schedules {
+				schedules[s.Name] = s.DataModels
+			}
+			assert.Equal(t, tt.expected, schedules)
+		})
+	}
+}
+
+func TestFeed_SamplingSchedule(t *testing.T) {
+	feed, err := New(Config{
+		DataModels: []string{"AAABBB", "CCCDDD", "EEEFFF"},
+		Transport:  local.New([]byte("test"), 0, map[string]transport.Message{}),
+		Interval:   timeutil.NewTicker(time.Minute),
+		Schedules: []Schedule{
+			{Name: "slow", DataModels: []string{"CCCDDD", "EEEFFF"}, Interval: timeutil.NewTicker(time.Hour)},
+		},
+		Deviations: map[string]Deviation{
+			"AAABBB": {Threshold: 0.01, Heartbeat: time.Hour},
+			"EEEFFF": {Threshold: 0.01, Heartbeat: time.Hour},
+		},
+		SamplingInterval: timeutil.NewTicker(time.Second),
+	})
+	require.NoError(t, err)
+	schedules := make(map[string][]string)
+	for _, s := range feed.schedules {
+		schedules[s.Name] = s.DataModels
+	}
+
+	// Data models with a deviation must be moved to the sampling schedule,
+	// and the default schedule must be removed because it is empty.
+	assert.Equal(t, map[string][]string{
+		"slow":           {"CCCDDD"},
+		SamplingSchedule: {"AAABBB", "EEEFFF"},
+	}, schedules)
+}
+
 func TestFeed_Start(t *testing.T) {
 	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer ctxCancel()
@@ -224,3 +403,194 @@ func TestFeed_Start(t *testing.T) {
 
 	ctxCancel()
 }
+
+type batchTransport struct {
+	transport.Service
+	batches []*messages.DataPointBatch
+	err     error
+}
+
+func (b *batchTransport) Broadcast(topic string, msg transport.Message) error {
+	if topic != messages.DataPointBatchV1MessageName {
+		return errors.New("unexpected topic")
+	}
+	b.batches = append(b.batches, msg.(*messages.DataPointBatch))
+	return b.err
+}
+
+type recordingAuditLog struct {
+	records int
+	errs    int
+}
+
+func (r *recordingAuditLog) Record(_ *messages.DataPoint, broadcastErr error) error {
+	r.records++
+	if broadcastErr != nil {
+		r.errs++
+	}
+	return nil
+}
+
+func TestFeed_broadcastBatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		err         error
+		v2          bool
+		wantBatches int
+	}{
+		{name: "single batch", size: 3, wantBatches: 1},
+		{name: "split batches", size: messages.MaxDataPointBatchSize + 1, wantBatches: 2},
+		{name: "broadcast error", size: 3, err: errors.New("error"), wantBatches: 1},
+		{name: "broadcast error, sent on v2", size: 3, err: errors.New("error"), v2: true, wantBatches: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := &batchTransport{err: tt.err}
+			al := &recordingAuditLog{}
+			feed, err := New(Config{
+				DataModels: []string{"AAABBB"},
+				Transport:  tr,
+				Interval:   timeutil.NewTicker(time.Second),
+				Deviations: map[string]Deviation{"AAABBB": {Threshold: 0.01, Heartbeat: time.Hour}},
+				AuditLog:   al,
+				Batch:      true,
+			})
+			if tt.v2 {
+				feed.publishV2 = true
+			}
+			require.NoError(t, err)
+			feed.ctx = context.Background()
+
+			point := datapoint.Point{Value: value.StaticValue{Value: bn.Float(1)}, Time: time.Now()}
+			pending := make([]pendingDataPoint, tt.size)
+			for i := range pending {
+				pending[i] = pendingDataPoint{
+					model: "AAABBB",
+					point: point,
+					now:   time.Now(),
+					msgs:  []*messages.DataPoint{{Model: "AAABBB", Value: point}},
+				}
+			}
+			feed.broadcastBatch(pending)
+
+			require.Len(t, tr.batches, tt.wantBatches)
+			total := 0
+			for _, b := range tr.batches {
+				assert.LessOrEqual(t, len(b.DataPoints), messages.MaxDataPointBatchSize)
+				total += len(b.DataPoints)
+			}
+			assert.Equal(t, tt.size, total)
+			assert.Equal(t, tt.size, al.records)
+			if tt.err != nil {
+				assert.Equal(t, tt.size, al.errs)
+			}
+			if tt.err != nil && !tt.v2 {
+				assert.NotContains(t, feed.last, "AAABBB")
+			}
+			if tt.err == nil {
+				assert.Equal(t, 0, al.errs)
+			}
+			if tt.err == nil || tt.v2 {
+				assert.Contains(t, feed.last, "AAABBB")
+			}
+		})
+	}
+}
+
+type topicTransport struct {
+	transport.Service
+	msgs map[string][]transport.Message
+}
+
+func (tt *topicTransport) Broadcast(topic string, msg transport.Message) error {
+	if tt.msgs == nil {
+		tt.msgs = make(map[string][]transport.Message)
+	}
+	tt.msgs[topic] = append(tt.msgs[topic], msg)
+	return nil
+}
+
+func TestFeed_broadcastV2(t *testing.T) {
+	tr := &topicTransport{}
+	feed, err := New(Config{
+		DataModels: []string{"AAABBB"},
+		Transport:  tr,
+		Interval:   timeutil.NewTicker(time.Second),
+		Topics:     []string{messages.DataPointV1MessageName, messages.DataPointV2MessageName},
+		Compact:    true,
+	})
+	require.NoError(t, err)
+	feed.ctx = context.Background()
+	assert.True(t, feed.publishV1)
+	assert.True(t, feed.publishV2)
+
+	point := datapoint.Point{Value: value.StaticValue{Value: bn.Float(1)}, Time: time.Now()}
+	msgs := []*messages.DataPoint{
+		{Model: "AAABBB", Value: point, SignatureScheme: datapoint.SignatureSchemeLegacy},
+		{Model: "AAABBB", Value: point, SignatureScheme: datapoint.SignatureSchemeEIP712},
+	}
+	require.NoError(t, feed.broadcastV2(msgs))
+	for _, err := range feed.broadcast(msgs) {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, tr.msgs[messages.DataPointV2MessageName], 1)
+	require.Len(t, tr.msgs[messages.DataPointV1MessageName], 2)
+	v2 := tr.msgs[messages.DataPointV2MessageName][0].(*messages.DataPointV2)
+	assert.Equal(t, "AAABBB", v2.Model)
+	assert.True(t, v2.Compact)
+	require.Len(t, v2.Signatures, 2)
+	assert.Equal(t, datapoint.SignatureSchemeEIP712, v2.Signatures[1].SignatureScheme)
+}
+
+type errorAuditLog struct {
+	errs []error
+}
+
+func (r *errorAuditLog) Record(_ *messages.DataPoint, broadcastErr error) error {
+	r.errs = append(r.errs, broadcastErr)
+	return nil
+}
+
+func TestFeed_record(t *testing.T) {
+	al := &errorAuditLog{}
+	feed, err := New(Config{
+		DataModels: []string{"AAABBB"},
+		Transport:  &topicTransport{},
+		Interval:   timeutil.NewTicker(time.Second),
+		Topics:     []string{messages.DataPointV1MessageName, messages.DataPointV2MessageName},
+		AuditLog:   al,
+	})
+	require.NoError(t, err)
+
+	msg := &messages.DataPoint{Model: "AAABBB"}
+	feed.record(msg, nil, nil)
+	feed.record(msg, errors.New("v1 error"), nil)
+	feed.record(msg, errors.New("v1 error"), errors.New("v2 error"))
+
+	require.Len(t, al.errs, 3)
+	assert.NoError(t, al.errs[0])
+	assert.EqualError(t, al.errs[1], messages.DataPointV1MessageName+": v1 error")
+	assert.ErrorContains(t, al.errs[2], messages.DataPointV1MessageName+": v1 error")
+	assert.ErrorContains(t, al.errs[2], messages.DataPointV2MessageName+": v2 error")
+}
+
+func TestFeed_Topics(t *testing.T) {
+	feed, err := New(Config{
+		DataModels: []string{"AAABBB"},
+		Transport:  &topicTransport{},
+		Interval:   timeutil.NewTicker(time.Second),
+	})
+	require.NoError(t, err)
+	assert.True(t, feed.publishV1)
+	assert.False(t, feed.publishV2)
+
+	_, err = New(Config{
+		DataModels: []string{"AAABBB"},
+		Transport:  &topicTransport{},
+		Interval:   timeutil.NewTicker(time.Second),
+		Topics:     []string{"foo"},
+	})
+	require.Error(t, err)
+}
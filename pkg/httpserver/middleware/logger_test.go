@@ -81,3 +81,20 @@ func TestLogger_DebugLevel(t *testing.T) {
 	assert.NotEmpty(t, recordedLogFields[0]["duration"])
 	assert.NotEmpty(t, recordedLogFields[0]["remoteAddr"])
 }
+
+func TestLogger_DebugLevel_Hijacker(t *testing.T) {
+	l := callback.New(log.Debug, func(level log.Level, fields log.Fields, msg string) {})
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h := (&Logger{Log: l}).Handle(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		hj, ok := writer.(http.Hijacker)
+		require.True(t, ok)
+
+		// httptest.ResponseRecorder does not implement http.Hijacker, so
+		// the error from the underlying writer must be returned.
+		_, _, err := hj.Hijack()
+		assert.Error(t, err)
+	}))
+	h.ServeHTTP(w, r)
+}
@@ -16,8 +16,11 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 )
 
@@ -54,6 +57,16 @@ func (r *recorder) WriteHeader(code int) {
 	r.rw.WriteHeader(code)
 }
 
+// Hijack implements the http.Hijacker interface. It is required by handlers
+// that take over the connection, like WebSocket or net/rpc handlers.
+func (r *recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.rw.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("underlying response writer does not implement http.Hijacker")
+	}
+	return h.Hijack()
+}
+
 func readRequest(r *http.Request) []byte {
 	b, _ := io.ReadAll(r.Body)
 	r.Body = io.NopCloser(bytes.NewReader(b))
@@ -0,0 +1,145 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay"
+)
+
+const LoggerTag = "RELAY_API"
+
+// defaultTimeout is the default timeout for the HTTP server.
+const defaultTimeout = 3 * time.Second
+
+// StatusProvider provides the status of contracts updated by the relay.
+type StatusProvider interface {
+	Status() []relay.ContractStatus
+}
+
+// StatusAPI provides an HTTP API with the state of contracts updated by
+// the relay.
+//
+// It provides one GET endpoint in root path that returns the status of
+// every configured contract in JSON format.
+type StatusAPI struct {
+	ctx context.Context
+
+	srv    *httpserver.HTTPServer
+	status StatusProvider
+	log    log.Logger
+}
+
+// Config is the configuration for the StatusAPI.
+type Config struct {
+	// StatusProvider provides the status of contracts.
+	StatusProvider StatusProvider
+
+	// Address specifies the TCP address for the server to listen on in the
+	// form "host:port".
+	Address string
+
+	// Logger is a current logger used by the StatusAPI.
+	Logger log.Logger
+}
+
+type jsonStatus struct {
+	Contracts []relay.ContractStatus `json:"contracts"`
+}
+
+// New returns a new instance of the StatusAPI struct.
+func New(cfg Config) (*StatusAPI, error) {
+	if cfg.StatusProvider == nil {
+		return nil, errors.New("status provider must not be nil")
+	}
+	if cfg.Address == "" {
+		return nil, errors.New("address must not be empty")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	api := &StatusAPI{
+		status: cfg.StatusProvider,
+		log:    cfg.Logger.WithField("tag", LoggerTag),
+	}
+	api.srv = httpserver.New(&http.Server{
+		Addr:              cfg.Address,
+		Handler:           http.HandlerFunc(api.handler),
+		IdleTimeout:       defaultTimeout,
+		ReadTimeout:       defaultTimeout,
+		WriteTimeout:      defaultTimeout,
+		ReadHeaderTimeout: defaultTimeout,
+	})
+	api.srv.Use(&middleware.HealthCheck{
+		Path:  "/health",
+		Check: func(r *http.Request) bool { return true },
+	})
+	api.srv.Use(&middleware.Logger{Log: api.log})
+	return api, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (s *StatusAPI) Start(ctx context.Context) error {
+	if s.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	s.log.Debug("Starting")
+	s.ctx = ctx
+	err := s.srv.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to start the HTTP server: %w", err)
+	}
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *StatusAPI) Wait() <-chan error {
+	return s.srv.Wait()
+}
+
+// handler is the HTTP handler for the StatusAPI.
+func (s *StatusAPI) handler(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	status := jsonStatus{Contracts: s.status.Status()}
+	if status.Contracts == nil {
+		status.Contracts = []relay.ContractStatus{}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(res).Encode(status)
+}
+
+func (s *StatusAPI) contextCancelHandler() {
+	defer s.log.Debug("Stopped")
+	<-s.ctx.Done()
+}
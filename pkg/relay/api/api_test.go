@@ -0,0 +1,106 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay"
+)
+
+type testStatusProvider []relay.ContractStatus
+
+func (p testStatusProvider) Status() []relay.ContractStatus {
+	return p
+}
+
+func TestStatusAPI(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	spread := 1.5
+	txHash := types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	api, err := New(Config{
+		StatusProvider: testStatusProvider{{
+			Type:             relay.ContractTypeScribe,
+			DataModel:        "ETH/USD",
+			Address:          types.MustAddressFromHex("0x2222222222222222222222222222222222222222"),
+			Val:              "1500",
+			Age:              time.Unix(10, 0).UTC(),
+			OffChainVal:      "1522.5",
+			OffChainAge:      time.Unix(20, 0).UTC(),
+			Spread:           &spread,
+			TimeToExpiration: -30,
+			Bar:              13,
+			Signers:          13,
+			QuorumReached:    true,
+			LastCheck:        time.Unix(30, 0).UTC(),
+			LastPoke: &relay.PokeStatus{
+				Time:    time.Unix(25, 0).UTC(),
+				Reason:  "expired,stale",
+				Outcome: relay.PokeOutcomeFailed,
+				Error:   "failed",
+			},
+			PendingTxHash: &txHash,
+		}},
+		Address: "127.0.0.1:0",
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, api.Start(ctx))
+	defer func() {
+		cancelFunc()
+		require.NoError(t, <-api.Wait())
+	}()
+
+	res, err := http.Get(fmt.Sprintf("http://%s", api.srv.Addr().String()))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contracts":[{
+		"type":"scribe",
+		"data_model":"ETH/USD",
+		"address":"0x2222222222222222222222222222222222222222",
+		"val":"1500",
+		"age":"1970-01-01T00:00:10Z",
+		"off_chain_val":"1522.5",
+		"off_chain_age":"1970-01-01T00:00:20Z",
+		"spread":1.5,
+		"time_to_expiration":-30,
+		"bar":13,
+		"signers":13,
+		"quorum_reached":true,
+		"last_check":"1970-01-01T00:00:30Z",
+		"last_poke":{"time":"1970-01-01T00:00:25Z","reason":"expired,stale","outcome":"failed","error":"failed"},
+		"pending_tx_hash":"0x1111111111111111111111111111111111111111111111111111111111111111"
+	}]}`, read(res))
+
+	// Return method not allowed if the method is not GET:
+	res, err = http.Post(fmt.Sprintf("http://%s", api.srv.Addr().String()), "application/json", nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func read(res *http.Response) string {
+	b, _ := io.ReadAll(res.Body)
+	return string(b)
+}
@@ -30,6 +30,9 @@ var (
 	abiMedian   = make(map[string]*goethABI.Method)
 	abiScribe   = make(map[string]*goethABI.Method)
 	abiOpScribe = make(map[string]*goethABI.Method)
+
+	eventScribe   = make(map[string]*goethABI.Event)
+	eventOpScribe = make(map[string]*goethABI.Event)
 )
 
 func init() {
@@ -37,6 +40,7 @@ func init() {
 	abi.Types["PokeData"], _ = abi.ParseType("(uint128 val, uint32 age)")
 	abi.Types["SchnorrData"], _ = abi.ParseType("(bytes32 signature, address commitment, bytes signersBlob)")
 	abi.Types["ECDSAData"], _ = abi.ParseType("(uint8 v, bytes32 r, bytes32 s)")
+	abi.Types["Point"], _ = abi.ParseType("(uint256 x, uint256 y)")
 
 	// Median
 	abiMedian["poke"], _ = abi.ParseMethod(
@@ -51,6 +55,7 @@ func init() {
 	abiMedian["age"], _ = abi.ParseMethod(`age()`)
 	abiMedian["wat"], _ = abi.ParseMethod(`wat()`)
 	abiMedian["bar"], _ = abi.ParseMethod(`bar()`)
+	abiMedian["slot"], _ = abi.ParseMethod(`slot(uint8)(address)`)
 
 	// Scribe
 	abiScribe["wat"], _ = abi.ParseMethod(`wat()(bytes32)`)
@@ -62,6 +67,25 @@ func init() {
 			SchnorrData calldata schnorrData
 		)`,
 	)
+	abiScribe["lift"], _ = abi.ParseMethod(
+		`function lift(
+			Point memory pubKey, 
+			ECDSAData memory ecdsaData
+		)(uint)`,
+	)
+	abiScribe["liftMany"], _ = abi.ParseMethod(
+		`function lift(
+			Point[] memory pubKeys, 
+			ECDSAData[] memory ecdsaDatas
+		)(uint[])`,
+	)
+	eventScribe["FeedLifted"], _ = abi.ParseEvent(
+		`event FeedLifted(
+			address indexed caller, 
+			address indexed feed, 
+			uint indexed index
+		)`,
+	)
 
 	// Optimistic Scribe
 	abiOpScribe["wat"], _ = abi.ParseMethod(`wat()(bytes32)`)
@@ -73,6 +97,16 @@ func init() {
 			ECDSAData calldata ecdsaData
 		)`,
 	)
+	abiOpScribe["opChallenge"], _ = abi.ParseMethod(`opChallenge(SchnorrData calldata schnorrData)(bool)`)
+	abiOpScribe["opChallengePeriod"], _ = abi.ParseMethod(`opChallengePeriod()(uint16)`)
+	eventOpScribe["OpPoked"], _ = abi.ParseEvent(
+		`event OpPoked(
+			address indexed caller, 
+			address indexed opFeed, 
+			SchnorrData schnorrData, 
+			PokeData pokeData
+		)`,
+	)
 }
 
 type PokeData struct {
@@ -97,6 +131,13 @@ func toSchnorrDataStruct(s SchnorrData) SchnorrDataStruct {
 	return SchnorrDataStruct(s)
 }
 
+func fromPokeDataStruct(p PokeDataStruct) PokeData {
+	return PokeData{
+		Val: bn.DecFixedPointFromRawBigInt(p.Val, ScribePricePrecision),
+		Age: time.Unix(int64(p.Age), 0),
+	}
+}
+
 func toECDSADataStruct(s types.Signature) ECDSADataStruct {
 	return ECDSADataStruct{
 		V: uint8(s.V.Uint64()),
@@ -118,6 +159,13 @@ type SchnorrDataStruct struct {
 	SignersBlob []byte        `abi:"signersBlob"`
 }
 
+// PointStruct represents the LibSecp256k1.Point struct used by the IScribe
+// interface.
+type PointStruct struct {
+	X *big.Int `abi:"x"`
+	Y *big.Int `abi:"y"`
+}
+
 // ECDSADataStruct represents the ECDSAData struct in the IScribe interface.
 type ECDSADataStruct struct {
 	V uint8    `abi:"v"`
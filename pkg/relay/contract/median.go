@@ -25,13 +25,12 @@ import (
 	"github.com/defiweb/go-eth/rpc"
 	"github.com/defiweb/go-eth/types"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
 )
 
 const MedianPricePrecision = 18
-const MedianPokeGesLimit = 200000
-const MedianPokeMaxFeePerGas = 2000 * 1e9
 
 type Median struct {
 	client  rpc.RPC
@@ -113,40 +112,65 @@ func (m *Median) Bar(ctx context.Context) (int, error) {
 	return int(new(big.Int).SetBytes(res).Int64()), nil
 }
 
-func (m *Median) Poke(ctx context.Context, val []*bn.DecFixedPointNumber, age []time.Time, v []uint8, r []*big.Int, s []*big.Int) error {
+// Feeds returns the addresses of feeds authorized to update the contract.
+//
+// The Median contract does not provide a list of feeds, but every feed
+// occupies one of 256 slots indexed by the first byte of its address, so
+// the list is obtained by reading all slots. Slots are read in a single
+// multicall if the chain has a multicall contract, otherwise they are read
+// one by one.
+func (m *Median) Feeds(ctx context.Context) ([]types.Address, error) {
+	calls := make([]types.Call, 256)
+	for i := range calls {
+		calls[i] = types.Call{
+			To:    &m.address,
+			Input: errutil.Must(abiMedian["slot"].EncodeArgs(uint8(i))),
+		}
+	}
+	res, err := ethereum.MultiCall(ctx, m.client, calls, types.LatestBlockNumber)
+	if err != nil {
+		res = make([][]byte, len(calls))
+		for i, call := range calls {
+			res[i], err = m.client.Call(ctx, call, types.LatestBlockNumber)
+			if err != nil {
+				return nil, fmt.Errorf("median: feeds query failed: %v", err)
+			}
+		}
+	}
+	var feeds []types.Address
+	for _, r := range res {
+		var feed types.Address
+		if err := abiMedian["slot"].DecodeValues(r, &feed); err != nil {
+			return nil, fmt.Errorf("median: feeds query failed: %v", err)
+		}
+		if feed != (types.Address{}) {
+			feeds = append(feeds, feed)
+		}
+	}
+	return feeds, nil
+}
+
+// Poke returns a transaction that updates the Median contract. The
+// transaction is simulated, but it is not sent. The gas limit, fees and
+// nonce are left for the sender to set.
+func (m *Median) Poke(ctx context.Context, val []*bn.DecFixedPointNumber, age []time.Time, v []uint8, r []*big.Int, s []*big.Int) (*types.Transaction, error) {
 	ints := make([]*big.Int, len(val))
 	for i, v := range val {
 		if v.Precision() != MedianPricePrecision {
-			return fmt.Errorf("median: poke failed: invalid precision: %d", v.Precision())
+			return nil, fmt.Errorf("median: poke failed: invalid precision: %d", v.Precision())
 		}
 		ints[i] = v.RawBigInt()
 	}
 	calldata, err := abiMedian["poke"].EncodeArgs(ints, age, v, r, s)
 	if err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
-	}
-	nonce, err := m.client.GetTransactionCount(
-		ctx,
-		m.address,
-		types.LatestBlockNumber,
-	)
-	if err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
+		return nil, fmt.Errorf("median: poke failed: %v", err)
 	}
 	tx := (&types.Transaction{}).
 		SetType(types.DynamicFeeTxType).
 		SetTo(m.address).
-		SetInput(calldata).
-		SetNonce(nonce).
-		SetGasLimit(MedianPokeGesLimit).
-		SetMaxPriorityFeePerGas(big.NewInt(1)).
-		SetMaxFeePerGas(big.NewInt(MedianPokeMaxFeePerGas))
+		SetInput(calldata)
 	if err := simulateTransaction(ctx, m.client, *tx); err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
-	}
-	_, err = m.client.SendTransaction(ctx, *tx)
-	if err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
+		return nil, fmt.Errorf("median: poke failed: %v", err)
 	}
-	return nil
+	return tx, nil
 }
@@ -0,0 +1,88 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	goethABI "github.com/defiweb/go-eth/abi"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
+)
+
+var (
+	testMedianAddress = types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	testFeed          = types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+)
+
+func testSlotResult(i int) []byte {
+	feed := types.Address{}
+	if i == int(testFeed[0]) {
+		feed = testFeed
+	}
+	return types.MustHashFromBytes(feed.Bytes(), types.PadLeft).Bytes()
+}
+
+func TestMedian_Feeds_MultiCall(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m := NewMedian(cli, testMedianAddress)
+
+	results := make([][]byte, 256)
+	for i := range results {
+		results[i] = testSlotResult(i)
+	}
+	aggregate := goethABI.MustParseMethod(`aggregate((address,bytes)[])(uint256 blockNumber, bytes[] returnData)`)
+	res, err := goethABI.EncodeValues(aggregate.Outputs(), uint64(1), results)
+	require.NoError(t, err)
+
+	cli.On("ChainID", ctx).Return(uint64(1), nil)
+	cli.On("Call", ctx, mock.Anything, types.LatestBlockNumber).Return(res, nil).Once()
+
+	feeds, err := m.Feeds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{testFeed}, feeds)
+
+	// All slots are read in a single call.
+	cli.AssertNumberOfCalls(t, "Call", 1)
+}
+
+func TestMedian_Feeds_Fallback(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m := NewMedian(cli, testMedianAddress)
+
+	cli.On("ChainID", ctx).Return(uint64(0), errors.New("unavailable"))
+	for i := 0; i < 256; i++ {
+		call := types.Call{
+			To:    &testMedianAddress,
+			Input: errutil.Must(abiMedian["slot"].EncodeArgs(uint8(i))),
+		}
+		cli.On("Call", ctx, call, types.LatestBlockNumber).Return(testSlotResult(i), nil)
+	}
+
+	feeds, err := m.Feeds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Address{testFeed}, feeds)
+	cli.AssertNumberOfCalls(t, "Call", 256)
+}
@@ -18,12 +18,26 @@ package contract
 import (
 	"context"
 	"fmt"
-	"math/big"
+	"time"
 
 	"github.com/defiweb/go-eth/rpc"
 	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
 )
 
+// OpPokedEvent represents the OpPoked event emitted by the OpScribe contract
+// when it is optimistically updated.
+type OpPokedEvent struct {
+	Caller      types.Address
+	OpFeed      types.Address
+	SchnorrData SchnorrData
+	PokeData    PokeData
+	BlockNumber uint64
+	TxHash      types.Hash
+	LogIndex    uint64
+}
+
 type OpScribe struct {
 	Scribe
 }
@@ -37,40 +51,103 @@ func NewOpScribe(client rpc.RPC, address types.Address) *OpScribe {
 	}
 }
 
-const ScribeOpPokeGasLimit = 200000
-const ScribeOpPokeMaxFeePerGas = 2000 * 1e9
-
-func (s *OpScribe) OpPoke(ctx context.Context, pokeData PokeData, schnorrData SchnorrData, ecdsaData types.Signature) error {
+// OpPoke returns a transaction that optimistically updates the OpScribe
+// contract. The transaction is simulated, but it is not sent. The gas
+// limit, fees and nonce are left for the sender to set.
+func (s *OpScribe) OpPoke(ctx context.Context, pokeData PokeData, schnorrData SchnorrData, ecdsaData types.Signature) (*types.Transaction, error) {
 	calldata, err := abiOpScribe["opPoke"].EncodeArgs(
 		toPokeDataStruct(pokeData),
 		toSchnorrDataStruct(schnorrData),
 		toECDSADataStruct(ecdsaData),
 	)
 	if err != nil {
-		return fmt.Errorf("opScribe: opPoke failed: %v", err)
+		return nil, fmt.Errorf("opScribe: opPoke failed: %v", err)
+	}
+	tx := (&types.Transaction{}).
+		SetType(types.DynamicFeeTxType).
+		SetTo(s.address).
+		SetInput(calldata)
+	if err := simulateTransaction(ctx, s.client, *tx); err != nil {
+		return nil, fmt.Errorf("opScribe: opPoke failed: %v", err)
 	}
-	nonce, err := s.client.GetTransactionCount(
+	return tx, nil
+}
+
+func (s *OpScribe) OpChallengePeriod(ctx context.Context) (time.Duration, error) {
+	res, err := s.client.Call(
 		ctx,
-		s.address,
+		types.Call{
+			To:    &s.address,
+			Input: errutil.Must(abiOpScribe["opChallengePeriod"].EncodeArgs()),
+		},
 		types.LatestBlockNumber,
 	)
 	if err != nil {
-		return fmt.Errorf("scribe: poke failed: %v", err)
+		return 0, fmt.Errorf("opScribe: opChallengePeriod query failed: %v", err)
+	}
+	var period uint16
+	if err := abiOpScribe["opChallengePeriod"].DecodeValues(res, &period); err != nil {
+		return 0, fmt.Errorf("opScribe: opChallengePeriod query failed: %v", err)
+	}
+	return time.Duration(period) * time.Second, nil
+}
+
+// OpPokedEvents returns the OpPoked events emitted by the contract between
+// the given blocks, inclusive.
+func (s *OpScribe) OpPokedEvents(ctx context.Context, fromBlock, toBlock uint64) ([]OpPokedEvent, error) {
+	event := eventOpScribe["OpPoked"]
+	from := types.BlockNumberFromUint64(fromBlock)
+	to := types.BlockNumberFromUint64(toBlock)
+	logs, err := s.client.GetLogs(ctx, types.FilterLogsQuery{
+		Address:   []types.Address{s.address},
+		FromBlock: &from,
+		ToBlock:   &to,
+		Topics:    [][]types.Hash{{event.Topic0()}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opScribe: OpPoked query failed: %v", err)
+	}
+	events := make([]OpPokedEvent, 0, len(logs))
+	for _, l := range logs {
+		if l.Removed || l.BlockNumber == nil || l.TransactionHash == nil || l.LogIndex == nil {
+			continue
+		}
+		var (
+			caller      types.Address
+			opFeed      types.Address
+			schnorrData SchnorrDataStruct
+			pokeData    PokeDataStruct
+		)
+		if err := event.DecodeValues(l.Topics, l.Data, &caller, &opFeed, &schnorrData, &pokeData); err != nil {
+			return nil, fmt.Errorf("opScribe: OpPoked query failed: %v", err)
+		}
+		events = append(events, OpPokedEvent{
+			Caller:      caller,
+			OpFeed:      opFeed,
+			SchnorrData: SchnorrData(schnorrData),
+			PokeData:    fromPokeDataStruct(pokeData),
+			BlockNumber: l.BlockNumber.Uint64(),
+			TxHash:      *l.TransactionHash,
+			LogIndex:    *l.LogIndex,
+		})
+	}
+	return events, nil
+}
+
+// OpChallenge returns a transaction that challenges the last optimistic
+// update of the OpScribe contract. The transaction is simulated, but it is
+// not sent. The gas limit, fees and nonce are left for the sender to set.
+func (s *OpScribe) OpChallenge(ctx context.Context, schnorrData SchnorrData) (*types.Transaction, error) {
+	calldata, err := abiOpScribe["opChallenge"].EncodeArgs(toSchnorrDataStruct(schnorrData))
+	if err != nil {
+		return nil, fmt.Errorf("opScribe: opChallenge failed: %v", err)
 	}
 	tx := (&types.Transaction{}).
 		SetType(types.DynamicFeeTxType).
 		SetTo(s.address).
-		SetInput(calldata).
-		SetNonce(nonce).
-		SetGasLimit(ScribeOpPokeGasLimit).
-		SetMaxPriorityFeePerGas(big.NewInt(1)).
-		SetMaxFeePerGas(big.NewInt(ScribeOpPokeMaxFeePerGas)) // 2000 Gwei TODO: use gas estimator
+		SetInput(calldata)
 	if err := simulateTransaction(ctx, s.client, *tx); err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
-	}
-	_, err = s.client.SendTransaction(ctx, *tx)
-	if err != nil {
-		return fmt.Errorf("opScribe: opPoke failed: %v", err)
+		return nil, fmt.Errorf("opScribe: opChallenge failed: %v", err)
 	}
-	return nil
+	return tx, nil
 }
@@ -18,20 +18,21 @@ package contract
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/defiweb/go-eth/crypto"
 	"github.com/defiweb/go-eth/rpc"
 	"github.com/defiweb/go-eth/types"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
 )
 
 const ScribePricePrecision = 18
-const ScribePokeGasLimit = 200000
-const ScribePokeMaxFeePerGas = 2000 * 1e9
 
 type Scribe struct {
 	client  rpc.RPC
@@ -89,13 +90,22 @@ func (s *Scribe) Wat(ctx context.Context) (string, error) {
 }
 
 func (s *Scribe) Bar(ctx context.Context) (int, error) {
+	return s.bar(ctx, types.LatestBlockNumber)
+}
+
+// BarAt returns the required number of signers at the given block.
+func (s *Scribe) BarAt(ctx context.Context, block uint64) (int, error) {
+	return s.bar(ctx, types.BlockNumberFromUint64(block))
+}
+
+func (s *Scribe) bar(ctx context.Context, block types.BlockNumber) (int, error) {
 	res, err := s.client.Call(
 		ctx,
 		types.Call{
 			To:    &s.address,
 			Input: errutil.Must(abiScribe["bar"].EncodeArgs()),
 		},
-		types.LatestBlockNumber,
+		block,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("scribe: bar query failed: %v", err)
@@ -108,13 +118,22 @@ func (s *Scribe) Bar(ctx context.Context) (int, error) {
 }
 
 func (s *Scribe) Feeds(ctx context.Context) ([]types.Address, []uint8, error) {
+	return s.feeds(ctx, types.LatestBlockNumber)
+}
+
+// FeedsAt returns the lifted feeds and their indices at the given block.
+func (s *Scribe) FeedsAt(ctx context.Context, block uint64) ([]types.Address, []uint8, error) {
+	return s.feeds(ctx, types.BlockNumberFromUint64(block))
+}
+
+func (s *Scribe) feeds(ctx context.Context, block types.BlockNumber) ([]types.Address, []uint8, error) {
 	res, err := s.client.Call(
 		ctx,
 		types.Call{
 			To:    &s.address,
 			Input: errutil.Must(abiScribe["feeds"].EncodeArgs()),
 		},
-		types.LatestBlockNumber,
+		block,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("scribe: feeds query failed: %v", err)
@@ -127,35 +146,108 @@ func (s *Scribe) Feeds(ctx context.Context) ([]types.Address, []uint8, error) {
 	return feeds, feedIndices, nil
 }
 
-func (s *Scribe) Poke(ctx context.Context, pokeData PokeData, schnorrData SchnorrData) error {
-	calldata, err := abiScribe["poke"].EncodeArgs(toPokeDataStruct(pokeData), toSchnorrDataStruct(schnorrData))
+// LiftedPublicKey returns the public key of the feed lifted up to the given
+// block.
+//
+// The public key is not stored in a readable form by the contract, so it is
+// read from the calldata of the transaction that emitted the FeedLifted
+// event. It only works if the lift function was called directly by the
+// transaction. The returned key is not verified, the caller must check that
+// the feed address is derived from it.
+func (s *Scribe) LiftedPublicKey(ctx context.Context, feed types.Address, toBlock uint64) (schnorr.PublicKey, error) {
+	event := eventScribe["FeedLifted"]
+	from := types.BlockNumberFromUint64(0)
+	to := types.BlockNumberFromUint64(toBlock)
+	logs, err := s.client.GetLogs(ctx, types.FilterLogsQuery{
+		Address:   []types.Address{s.address},
+		FromBlock: &from,
+		ToBlock:   &to,
+		Topics:    [][]types.Hash{{event.Topic0()}, nil, {types.MustHashFromBytes(feed.Bytes(), types.PadLeft)}},
+	})
 	if err != nil {
-		return fmt.Errorf("scribe: poke failed: %v", err)
+		return schnorr.PublicKey{}, fmt.Errorf("scribe: FeedLifted query failed: %v", err)
 	}
-	nonce, err := s.client.GetTransactionCount(
-		ctx,
-		s.address,
-		types.LatestBlockNumber,
+	// Use the most recent lift, in case the feed was lifted more than once.
+	for i := len(logs) - 1; i >= 0; i-- {
+		l := logs[i]
+		if l.Removed || l.TransactionHash == nil {
+			continue
+		}
+		tx, err := s.client.GetTransactionByHash(ctx, *l.TransactionHash)
+		if err != nil {
+			return schnorr.PublicKey{}, fmt.Errorf("scribe: lift transaction query failed: %v", err)
+		}
+		if tx == nil || tx.To == nil || *tx.To != s.address {
+			continue
+		}
+		for _, p := range decodeLiftedPublicKeys(tx.Input) {
+			k := schnorr.PublicKey{X: p.X, Y: p.Y}
+			if k.Valid() && k.Address() == feed {
+				return k, nil
+			}
+		}
+	}
+	return schnorr.PublicKey{}, fmt.Errorf("scribe: public key of the feed %s not found", feed)
+}
+
+// decodeLiftedPublicKeys returns the public keys passed to the lift function
+// in the calldata, or nil if the calldata is not a lift call.
+func decodeLiftedPublicKeys(input []byte) []PointStruct {
+	if len(input) < 4 {
+		return nil
+	}
+	var (
+		pubKey     PointStruct
+		pubKeys    []PointStruct
+		ecdsaData  ECDSADataStruct
+		ecdsaDatas []ECDSADataStruct
 	)
+	if abiScribe["lift"].FourBytes().Match(input[:4]) {
+		if err := abiScribe["lift"].DecodeArgs(input, &pubKey, &ecdsaData); err != nil {
+			return nil
+		}
+		return []PointStruct{pubKey}
+	}
+	if abiScribe["liftMany"].FourBytes().Match(input[:4]) {
+		if err := abiScribe["liftMany"].DecodeArgs(input, &pubKeys, &ecdsaDatas); err != nil {
+			return nil
+		}
+		return pubKeys
+	}
+	return nil
+}
+
+// Poke returns a transaction that updates the Scribe contract. The
+// transaction is simulated, but it is not sent. The gas limit, fees and
+// nonce are left for the sender to set.
+func (s *Scribe) Poke(ctx context.Context, pokeData PokeData, schnorrData SchnorrData) (*types.Transaction, error) {
+	calldata, err := abiScribe["poke"].EncodeArgs(toPokeDataStruct(pokeData), toSchnorrDataStruct(schnorrData))
 	if err != nil {
-		return fmt.Errorf("scribe: poke failed: %v", err)
+		return nil, fmt.Errorf("scribe: poke failed: %v", err)
 	}
 	tx := (&types.Transaction{}).
 		SetType(types.DynamicFeeTxType).
 		SetTo(s.address).
-		SetInput(calldata).
-		SetNonce(nonce).
-		SetGasLimit(ScribePokeGasLimit).
-		SetMaxPriorityFeePerGas(big.NewInt(1)).
-		SetMaxFeePerGas(big.NewInt(ScribePokeMaxFeePerGas)) // 2000 Gwei TODO: use gas estimator
+		SetInput(calldata)
 	if err := simulateTransaction(ctx, s.client, *tx); err != nil {
-		return fmt.Errorf("median: poke failed: %v", err)
-	}
-	_, err = s.client.SendTransaction(ctx, *tx)
-	if err != nil {
-		return fmt.Errorf("scribe: poke failed: %v", err)
+		return nil, fmt.Errorf("scribe: poke failed: %v", err)
 	}
-	return nil
+	return tx, nil
+}
+
+// ConstructPokeMessage returns the message signed by feeds to update the
+// Scribe contract with the given data model. It is equivalent to the
+// constructPokeMessage function of the contract.
+func ConstructPokeMessage(wat string, pokeData PokeData) types.Hash {
+	var (
+		val [16]byte
+		age [4]byte
+		w   [32]byte
+	)
+	pokeData.Val.RawBigInt().FillBytes(val[:])
+	binary.BigEndian.PutUint32(age[:], uint32(pokeData.Age.Unix()))
+	copy(w[:], wat)
+	return crypto.Keccak256(crypto.AddMessagePrefix(crypto.Keccak256(val[:], age[:], w[:]).Bytes()))
 }
 
 // bytesToString converts a string terminated by a null byte to a Go string.
@@ -0,0 +1,330 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"golang.org/x/exp/slices"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const CoordinatorLoggerTag = "RELAY_COORDINATOR"
+
+// DefaultTakeoverDelay is the default time a relay waits for every relay
+// with a higher priority before it updates a contract.
+const DefaultTakeoverDelay = 30 * time.Second
+
+// roundTTL is the time after which the coordinator forgets an update round.
+const roundTTL = time.Hour
+
+// Coordinator coordinates contract updates between multiple relays, so only
+// one of them sends a transaction when a contract needs to be updated.
+//
+// Every update round, identified by the contract address and the time of
+// the last contract update, relays are ordered deterministically by the
+// hash of the round and their addresses. The first relay in the order
+// updates the contract immediately, every next relay waits an additional
+// takeover delay, so it takes over only if all relays before it failed.
+//
+// Before sending a transaction, a relay announces its intent to other
+// relays using the transport. Relays that receive the intent back off for
+// the takeover delay. The intent is announced again while the transaction
+// is pending, so other relays keep backing off until it is mined or
+// dropped, even if it takes longer than the takeover delay.
+type Coordinator struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	waitCh chan error
+	log    log.Logger
+
+	transport     transport.Service
+	self          types.Address
+	relays        []types.Address
+	takeoverDelay time.Duration
+	rounds        map[roundKey]time.Time
+	intents       map[roundKey]intent
+}
+
+// CoordinatorConfig is the configuration for the Coordinator.
+type CoordinatorConfig struct {
+	// Transport is an implementation of transport used to exchange intents
+	// with other relays.
+	Transport transport.Service
+
+	// Self is the address used by the transport to sign messages of this
+	// relay.
+	Self types.Address
+
+	// Relays is the list of addresses of all coordinated relays, including
+	// this one.
+	Relays []types.Address
+
+	// TakeoverDelay is the time a relay waits for every relay with a higher
+	// priority before it updates a contract. If zero, DefaultTakeoverDelay
+	// is used.
+	TakeoverDelay time.Duration
+
+	// Logger is a current logger interface used by the Coordinator.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+type roundKey struct {
+	contract types.Address
+	age      int64
+}
+
+type intent struct {
+	relay    types.Address
+	received time.Time
+}
+
+// NewCoordinator creates a new instance of the Coordinator.
+func NewCoordinator(cfg CoordinatorConfig) (*Coordinator, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if !slices.Contains(cfg.Relays, cfg.Self) {
+		return nil, errors.New("relay list must contain the address of this relay")
+	}
+	if cfg.TakeoverDelay == 0 {
+		cfg.TakeoverDelay = DefaultTakeoverDelay
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Coordinator{
+		waitCh:        make(chan error),
+		log:           cfg.Logger.WithField("tag", CoordinatorLoggerTag),
+		transport:     cfg.Transport,
+		self:          cfg.Self,
+		relays:        cfg.Relays,
+		takeoverDelay: cfg.TakeoverDelay,
+		rounds:        make(map[roundKey]time.Time),
+		intents:       make(map[roundKey]intent),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (c *Coordinator) Start(ctx context.Context) error {
+	if c.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	c.log.Info("Starting")
+	c.ctx = ctx
+	go c.collectorRoutine()
+	go c.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (c *Coordinator) Wait() <-chan error {
+	return c.waitCh
+}
+
+// Acquire returns true if this relay should update the contract in the
+// given round. If so, the intent to update the contract is announced to
+// other relays.
+//
+// The age is the time of the last contract update.
+func (c *Coordinator) Acquire(contract types.Address, dataModel string, age time.Time) bool {
+	now := time.Now()
+	key := roundKey{contract: contract, age: age.Unix()}
+	fields := log.Fields{
+		"contract":  contract.String(),
+		"dataModel": dataModel,
+		"age":       age,
+	}
+
+	c.mu.Lock()
+	c.cleanup(now)
+	first, ok := c.rounds[key]
+	if !ok {
+		first = now
+		c.rounds[key] = now
+	}
+	if in, ok := c.intents[key]; ok && now.Sub(in.received) < c.takeoverDelay {
+		c.mu.Unlock()
+		c.log.
+			WithFields(fields).
+			WithField("relay", in.relay.String()).
+			Info("Another relay announced the contract update, backing off")
+		return false
+	}
+	rank := c.rank(key)
+	wait := time.Duration(rank) * c.takeoverDelay
+	if now.Sub(first) < wait {
+		c.mu.Unlock()
+		c.log.
+			WithFields(fields).
+			WithField("rank", rank).
+			WithField("takeoverIn", (wait - now.Sub(first)).String()).
+			Debug("Waiting for relays with a higher priority to update the contract")
+		return false
+	}
+	c.mu.Unlock()
+
+	c.announce(contract, dataModel, age)
+	if rank > 0 {
+		c.log.
+			WithFields(fields).
+			WithField("rank", rank).
+			Warn("Taking over the contract update from relays with a higher priority")
+	}
+	return true
+}
+
+// Track announces the intent to update the contract again every half of
+// the takeover delay until the transaction is mined or dropped. It must be
+// called after the transaction for the round acquired with Acquire is sent.
+func (c *Coordinator) Track(contract types.Address, dataModel string, age time.Time, tx *PendingTx) {
+	if c.ctx == nil {
+		return
+	}
+	go func() {
+		t := time.NewTicker(c.takeoverDelay / 2)
+		defer t.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				return
+			case <-tx.Done():
+				return
+			case <-t.C:
+				c.announce(contract, dataModel, age)
+			}
+		}
+	}()
+}
+
+// announce broadcasts the intent to update the contract in the given round.
+func (c *Coordinator) announce(contract types.Address, dataModel string, age time.Time) {
+	msg := &messages.RelayIntent{
+		ContractAddress: contract,
+		DataModel:       dataModel,
+		Age:             age,
+		Timestamp:       time.Now(),
+	}
+	if err := c.transport.Broadcast(messages.RelayIntentV1MessageName, msg); err != nil {
+		c.log.
+			WithError(err).
+			WithFields(log.Fields{
+				"contract":  contract.String(),
+				"dataModel": dataModel,
+				"age":       age,
+			}).
+			Warn("Unable to announce the contract update")
+	}
+}
+
+// rank returns the position of this relay in the order of relays for the
+// given round.
+func (c *Coordinator) rank(key roundKey) int {
+	var age [8]byte
+	binary.BigEndian.PutUint64(age[:], uint64(key.age))
+	type relayHash struct {
+		relay types.Address
+		hash  types.Hash
+	}
+	hashes := make([]relayHash, len(c.relays))
+	for i, r := range c.relays {
+		hashes[i] = relayHash{
+			relay: r,
+			hash:  crypto.Keccak256(key.contract.Bytes(), age[:], r.Bytes()),
+		}
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].hash.Bytes(), hashes[j].hash.Bytes()) < 0
+	})
+	for i, h := range hashes {
+		if h.relay == c.self {
+			return i
+		}
+	}
+	return len(c.relays)
+}
+
+// cleanup removes rounds and intents older than roundTTL.
+func (c *Coordinator) cleanup(now time.Time) {
+	for k, t := range c.rounds {
+		if now.Sub(t) > roundTTL {
+			delete(c.rounds, k)
+		}
+	}
+	for k, in := range c.intents {
+		if now.Sub(in.received) > roundTTL {
+			delete(c.intents, k)
+		}
+	}
+}
+
+func (c *Coordinator) handleIntentMessage(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		c.log.WithError(msg.Error).Error("Unable to receive message")
+		return
+	}
+	in, ok := msg.Message.(*messages.RelayIntent)
+	if !ok {
+		c.log.Error("Unexpected value returned from the transport layer")
+		return
+	}
+	relay := msgAuthorToAddr(msg.Author)
+	if relay == c.self || !slices.Contains(c.relays, relay) {
+		return
+	}
+	now := time.Now()
+	if now.Sub(in.Timestamp) > c.takeoverDelay {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.intents[roundKey{contract: in.ContractAddress, age: in.Age.Unix()}] = intent{relay: relay, received: now}
+}
+
+func (c *Coordinator) collectorRoutine() {
+	ch := c.transport.Messages(messages.RelayIntentV1MessageName)
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case msg := <-ch:
+			c.handleIntentMessage(msg)
+		}
+	}
+}
+
+// contextCancelHandler handles context cancellation.
+func (c *Coordinator) contextCancelHandler() {
+	defer func() { close(c.waitCh) }()
+	defer c.log.Info("Stopped")
+	<-c.ctx.Done()
+}
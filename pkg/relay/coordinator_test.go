@@ -0,0 +1,169 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+var testRelays = []types.Address{
+	types.MustAddressFromHex("0x1111111111111111111111111111111111111111"),
+	types.MustAddressFromHex("0x2222222222222222222222222222222222222222"),
+	types.MustAddressFromHex("0x3333333333333333333333333333333333333333"),
+}
+
+func newTestCoordinator(t *testing.T, ctx context.Context, self types.Address) *Coordinator {
+	tra := local.New(self.Bytes(), 10, map[string]transport.Message{
+		messages.RelayIntentV1MessageName: (*messages.RelayIntent)(nil),
+	})
+	require.NoError(t, tra.Start(ctx))
+	c, err := NewCoordinator(CoordinatorConfig{
+		Transport:     tra,
+		Self:          self,
+		Relays:        testRelays,
+		TakeoverDelay: time.Minute,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+// coordinatorsByRank returns coordinators for all test relays ordered by
+// their rank in the given round.
+func coordinatorsByRank(t *testing.T, ctx context.Context, key roundKey) []*Coordinator {
+	cs := make([]*Coordinator, len(testRelays))
+	for _, r := range testRelays {
+		c := newTestCoordinator(t, ctx, r)
+		rank := c.rank(key)
+		require.Less(t, rank, len(cs))
+		require.Nil(t, cs[rank], "ranks must be unique")
+		cs[rank] = c
+	}
+	return cs
+}
+
+func TestCoordinator_Acquire(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contract := types.MustAddressFromHex("0x4444444444444444444444444444444444444444")
+	age := time.Unix(1234567890, 0)
+	cs := coordinatorsByRank(t, ctx, roundKey{contract: contract, age: age.Unix()})
+
+	// The first relay updates the contract immediately.
+	assert.True(t, cs[0].Acquire(contract, "ETH/USD", age))
+
+	// Other relays wait for relays with a higher priority.
+	assert.False(t, cs[1].Acquire(contract, "ETH/USD", age))
+	assert.False(t, cs[2].Acquire(contract, "ETH/USD", age))
+
+	// The second relay takes over after the takeover delay.
+	key := roundKey{contract: contract, age: age.Unix()}
+	cs[1].rounds[key] = time.Now().Add(-time.Minute)
+	assert.True(t, cs[1].Acquire(contract, "ETH/USD", age))
+	cs[2].rounds[key] = time.Now().Add(-time.Minute)
+	assert.False(t, cs[2].Acquire(contract, "ETH/USD", age))
+	cs[2].rounds[key] = time.Now().Add(-2 * time.Minute)
+	assert.True(t, cs[2].Acquire(contract, "ETH/USD", age))
+}
+
+func TestCoordinator_Intent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contract := types.MustAddressFromHex("0x4444444444444444444444444444444444444444")
+	age := time.Unix(1234567890, 0)
+	cs := coordinatorsByRank(t, ctx, roundKey{contract: contract, age: age.Unix()})
+
+	intent := &messages.RelayIntent{
+		ContractAddress: contract,
+		DataModel:       "ETH/USD",
+		Age:             age,
+		Timestamp:       time.Now(),
+	}
+
+	// Intents from unknown relays are ignored.
+	cs[0].handleIntentMessage(transport.ReceivedMessage{
+		Message: intent,
+		Author:  types.MustAddressFromHex("0x5555555555555555555555555555555555555555").Bytes(),
+	})
+	assert.True(t, cs[0].Acquire(contract, "ETH/USD", age))
+
+	// A relay backs off when another relay announced the update, even if it
+	// has a higher priority.
+	cs[0].handleIntentMessage(transport.ReceivedMessage{
+		Message: intent,
+		Author:  cs[1].self.Bytes(),
+	})
+	assert.False(t, cs[0].Acquire(contract, "ETH/USD", age))
+
+	// Intents for other rounds do not affect the next round, where relays
+	// may be ordered differently.
+	next := age.Add(time.Minute)
+	for _, c := range cs {
+		rank := c.rank(roundKey{contract: contract, age: next.Unix()})
+		assert.Equal(t, rank == 0, c.Acquire(contract, "ETH/USD", next))
+	}
+}
+
+func TestCoordinator_Track(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	contract := types.MustAddressFromHex("0x4444444444444444444444444444444444444444")
+	age := time.Unix(1234567890, 0)
+	c := coordinatorsByRank(t, ctx, roundKey{contract: contract, age: age.Unix()})[0]
+	c.takeoverDelay = 20 * time.Millisecond
+	c.ctx = ctx
+	msgs := c.transport.Messages(messages.RelayIntentV1MessageName)
+
+	require.True(t, c.Acquire(contract, "ETH/USD", age))
+	tx := &PendingTx{doneCh: make(chan struct{})}
+	c.Track(contract, "ETH/USD", age, tx)
+
+	// The intent is announced again while the transaction is pending.
+	for i := 0; i < 3; i++ {
+		select {
+		case msg := <-msgs:
+			in := msg.Message.(*messages.RelayIntent)
+			assert.Equal(t, contract, in.ContractAddress)
+			assert.Equal(t, age.Unix(), in.Age.Unix())
+		case <-time.After(time.Second):
+			require.Fail(t, "intent was not announced")
+		}
+	}
+
+	// Announcements stop once the transaction is mined or dropped.
+	tx.finish(nil, nil)
+	time.Sleep(2 * c.takeoverDelay)
+	for len(msgs) > 0 {
+		<-msgs
+	}
+	select {
+	case <-msgs:
+		assert.Fail(t, "intent announced after the transaction was done")
+	case <-time.After(3 * c.takeoverDelay):
+	}
+}
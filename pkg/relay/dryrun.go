@@ -0,0 +1,60 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+)
+
+// dryRunPoke reports the transaction that would be sent to update a
+// contract instead of sending it. The tx and simErr arguments are the
+// result of building and simulating the transaction.
+func dryRunPoke(
+	ctx context.Context,
+	logger log.Logger,
+	txManager *TxManager,
+	status *contractStatus,
+	isExpired, isStale bool,
+	tx *types.Transaction,
+	simErr error,
+) error {
+	if simErr != nil {
+		status.setDryRunPoke(isExpired, isStale, simErr)
+		return simErr
+	}
+	gasLimit, err := txManager.EstimateGas(ctx, *tx)
+	status.setDryRunPoke(isExpired, isStale, err)
+	if err != nil {
+		return err
+	}
+	fields := log.Fields{
+		"reason":   pokeReason(isExpired, isStale),
+		"calldata": hexutil.BytesToHex(tx.Input),
+		"gasLimit": gasLimit,
+	}
+	if tx.To != nil {
+		fields["to"] = tx.To.String()
+	}
+	logger.
+		WithFields(fields).
+		Info("Dry run, update transaction not sent")
+	return nil
+}
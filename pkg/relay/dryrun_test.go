@@ -0,0 +1,64 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+func TestDryRunPoke(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m, err := NewTxManager(TxManagerConfig{Client: cli})
+	require.NoError(t, err)
+
+	cli.On("Accounts", ctx).Return([]types.Address{testSender}, nil)
+	cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+
+	s := newContractStatus(ContractTypeMedian, "ETH/USD", testContract)
+	s.enableDryRun()
+	tx := testTx()
+
+	s.setCheck(nil)
+	require.NoError(t, dryRunPoke(ctx, null.New(), m, s, true, false, &tx, nil))
+	s.setCheck(nil)
+	require.NoError(t, dryRunPoke(ctx, null.New(), m, s, true, true, &tx, nil))
+	s.setCheck(nil)
+	require.Error(t, dryRunPoke(ctx, null.New(), m, s, false, true, nil, errors.New("reverted")))
+	s.setCheck(nil)
+
+	st := s.get()
+	require.NotNil(t, st.DryRun)
+	assert.Equal(t, DryRunSummary{Checks: 4, Pokes: 2, Expired: 2, Stale: 1, Failed: 1}, *st.DryRun)
+	assert.Equal(t, PokeOutcomeFailed, st.LastPoke.Outcome)
+	assert.Equal(t, "stale", st.LastPoke.Reason)
+
+	// Transactions must never be sent in the dry-run mode.
+	cli.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+	cli.AssertCalled(t, "EstimateGas", ctx, mock.MatchedBy(func(call types.Call) bool {
+		return call.From != nil && *call.From == testSender
+	}), types.LatestBlockNumber)
+}
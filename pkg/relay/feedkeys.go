@@ -0,0 +1,166 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const FeedKeyStoreLoggerTag = "FEED_KEY_STORE"
+
+// FeedKeyStore collects Schnorr public keys of feeds.
+//
+// Public keys are learned from the greet and MuSig commitment messages sent
+// by feeds. Since the address of a feed is derived from its public key, keys
+// are stored under the derived address and do not have to be trusted.
+type FeedKeyStore struct {
+	ctx    context.Context
+	mu     sync.RWMutex
+	waitCh chan error
+	log    log.Logger
+
+	transport transport.Service
+	keys      map[types.Address]schnorr.PublicKey
+}
+
+// FeedKeyStoreConfig is the configuration for FeedKeyStore.
+type FeedKeyStoreConfig struct {
+	// Transport is an implementation of transport used to receive public
+	// keys from feeds.
+	Transport transport.Service
+
+	// Logger is a current logger interface used by the store.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+// NewFeedKeyStore creates a new instance of the FeedKeyStore.
+func NewFeedKeyStore(cfg FeedKeyStoreConfig) (*FeedKeyStore, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &FeedKeyStore{
+		waitCh:    make(chan error),
+		log:       cfg.Logger.WithField("tag", FeedKeyStoreLoggerTag),
+		transport: cfg.Transport,
+		keys:      make(map[types.Address]schnorr.PublicKey),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (s *FeedKeyStore) Start(ctx context.Context) error {
+	if s.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	s.log.Info("Starting")
+	s.ctx = ctx
+	go s.collectorRoutine()
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *FeedKeyStore) Wait() <-chan error {
+	return s.waitCh
+}
+
+// PublicKey returns the public key of the feed with the given address.
+func (s *FeedKeyStore) PublicKey(feed types.Address) (schnorr.PublicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	k, ok := s.keys[feed]
+	return k, ok
+}
+
+func (s *FeedKeyStore) collectKey(x, y *big.Int) {
+	key := schnorr.PublicKey{X: x, Y: y}
+	if !key.Valid() {
+		s.log.Warn("Received invalid public key")
+		return
+	}
+	feed := key.Address()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[feed]; !ok {
+		s.log.WithField("feed", feed.String()).Info("Learned public key of the feed")
+	}
+	s.keys[feed] = key
+}
+
+func (s *FeedKeyStore) handleGreetMessage(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		s.log.WithError(msg.Error).Error("Unable to receive message")
+		return
+	}
+	greet, ok := msg.Message.(*messages.Greet)
+	if !ok {
+		s.log.Error("Unexpected value returned from the transport layer")
+		return
+	}
+	s.collectKey(greet.PublicKeyX, greet.PublicKeyY)
+}
+
+func (s *FeedKeyStore) handleCommitmentMessage(msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		s.log.WithError(msg.Error).Error("Unable to receive message")
+		return
+	}
+	commitment, ok := msg.Message.(*messages.MuSigCommitment)
+	if !ok {
+		s.log.Error("Unexpected value returned from the transport layer")
+		return
+	}
+	s.collectKey(commitment.PublicKeyX, commitment.PublicKeyY)
+}
+
+func (s *FeedKeyStore) collectorRoutine() {
+	greetCh := s.transport.Messages(messages.GreetV1MessageName)
+	commitmentCh := s.transport.Messages(messages.MuSigCommitmentV1MessageName)
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg := <-greetCh:
+			s.handleGreetMessage(msg)
+		case msg := <-commitmentCh:
+			s.handleCommitmentMessage(msg)
+		}
+	}
+}
+
+// contextCancelHandler handles context cancellation.
+func (s *FeedKeyStore) contextCancelHandler() {
+	defer func() { close(s.waitCh) }()
+	defer s.log.Info("Stopped")
+	<-s.ctx.Done()
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/defiweb/go-eth/types"
+	"golang.org/x/exp/slices"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
@@ -35,15 +36,30 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
+// DefaultMedianFeedsTTL is the default time after which the feed list and
+// the quorum of a Median contract are read from the contract again.
+const DefaultMedianFeedsTTL = 10 * time.Minute
+
 type medianWorker struct {
 	log            log.Logger
 	dataPointStore *store.Store
 	feedAddresses  []types.Address
+	feedsTTL       time.Duration
 	contract       MedianContract
+	txManager      *TxManager
+	coordinator    *Coordinator
+	status         *contractStatus
+	address        types.Address
 	dataModel      string
 	spread         float64
 	expiration     time.Duration
 	ticker         *timeutil.Ticker
+	dryRun         bool
+
+	// Feed list and quorum read from the contract.
+	feeds          []types.Address
+	bar            int
+	feedsUpdatedAt time.Time
 }
 
 func (w *medianWorker) workerRoutine(ctx context.Context) {
@@ -53,7 +69,9 @@ func (w *medianWorker) workerRoutine(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-w.ticker.TickCh():
-			if err := w.tryUpdate(ctx); err != nil {
+			err := w.tryUpdate(ctx)
+			w.status.setCheck(err)
+			if err != nil {
 				w.log.
 					WithError(err).
 					Error("Failed to update Median contract")
@@ -75,21 +93,25 @@ func (w *medianWorker) tryUpdate(ctx context.Context) error {
 		return err
 	}
 
-	// Quorum.
-	bar, err := w.contract.Bar(ctx)
+	// Feed list and quorum.
+	feeds, bar, err := w.contractFeeds(ctx)
 	if err != nil {
 		return err
 	}
 
+	w.status.setOnChain(val, age, bar, w.expiration)
+
 	// Load data points from the store.
-	dataPoints, signatures, err := w.getDataPoints(ctx, age, bar)
+	dataPoints, signatures, err := w.getDataPoints(ctx, feeds, age, bar)
 	if err != nil {
+		w.status.setOffChain(nil, time.Time{}, 0, len(dataPoints))
 		return err
 	}
 
 	prices := dataPointsToPrices(dataPoints)
 	median := calculateMedian(prices)
 	spread := calculateSpread(median, val)
+	w.status.setOffChain(median, latestDataPointTime(dataPoints), spread, len(dataPoints))
 
 	// Check if price on the Median contract needs to be updated.
 	// The price needs to be updated if:
@@ -118,6 +140,13 @@ func (w *medianWorker) tryUpdate(ctx context.Context) error {
 
 	// If price is stale or expired, send update.
 	if isExpired || isStale {
+		if pokePending(w.log.WithField("dataModel", w.dataModel), w.txManager, w.address) {
+			return nil
+		}
+		if w.coordinator != nil && !w.coordinator.Acquire(w.address, w.dataModel, age) {
+			return nil
+		}
+
 		var (
 			ages = make([]time.Time, len(dataPoints))
 			vs   = make([]uint8, len(signatures))
@@ -125,24 +154,95 @@ func (w *medianWorker) tryUpdate(ctx context.Context) error {
 			ss   = make([]*big.Int, len(signatures))
 		)
 		for i := range dataPoints {
-			ages = append(ages, dataPoints[i].Time)
-			vs = append(vs, uint8(signatures[i].V.Uint64()))
-			rs = append(rs, signatures[i].R)
-			ss = append(ss, signatures[i].S)
+			ages[i] = dataPoints[i].Time
+			vs[i] = uint8(signatures[i].V.Uint64())
+			rs[i] = signatures[i].R
+			ss[i] = signatures[i].S
+		}
+
+		reason := pokeReason(isExpired, isStale)
+		tx, err := w.contract.Poke(ctx, prices, ages, vs, rs, ss)
+		if w.dryRun {
+			return dryRunPoke(ctx, w.log.WithField("dataModel", w.dataModel), w.txManager, w.status, isExpired, isStale, tx, err)
+		}
+		if err != nil {
+			w.status.setPoke(reason, nil, err)
+			return err
 		}
 
 		// Send *actual* transaction.
-		return w.contract.Poke(ctx, prices, ages, vs, rs, ss)
+		ptx, err := sendPoke(ctx, w.log.WithField("dataModel", w.dataModel), w.txManager, w.status, reason, *tx)
+		if err != nil {
+			return err
+		}
+		if w.coordinator != nil {
+			w.coordinator.Track(w.address, w.dataModel, age, ptx)
+		}
+		return nil
 	}
 
 	return nil
 }
 
-func (w *medianWorker) getDataPoints(ctx context.Context, after time.Time, quorum int) ([]datapoint.Point, []types.Signature, error) {
+// contractFeeds returns the feed list and the quorum of the contract. They
+// are cached for the feeds TTL. If they cannot be refreshed, the cached
+// values are used.
+func (w *medianWorker) contractFeeds(ctx context.Context) ([]types.Address, int, error) {
+	if w.feeds != nil && time.Since(w.feedsUpdatedAt) < w.feedsTTL {
+		return w.feeds, w.bar, nil
+	}
+	feeds, bar, err := w.readContractFeeds(ctx)
+	if err != nil {
+		if w.feeds == nil {
+			return nil, 0, err
+		}
+		w.log.
+			WithError(err).
+			WithField("dataModel", w.dataModel).
+			Warn("Unable to refresh Median feeds, using cached feeds")
+		return w.feeds, w.bar, nil
+	}
+	if len(w.feedAddresses) > 0 {
+		missing, extra := diffAddresses(w.feedAddresses, feeds)
+		if len(missing) > 0 || len(extra) > 0 {
+			w.log.
+				WithFields(log.Fields{
+					"dataModel":          w.dataModel,
+					"notLiftedFeeds":     missing,
+					"notConfiguredFeeds": extra,
+				}).
+				Warn("Feeds on the Median contract differ from the configured feeds")
+		}
+	}
+	w.feeds = feeds
+	w.bar = bar
+	w.feedsUpdatedAt = time.Now()
+	return feeds, bar, nil
+}
+
+func (w *medianWorker) readContractFeeds(ctx context.Context) ([]types.Address, int, error) {
+	feeds, err := w.contract.Feeds(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	bar, err := w.contract.Bar(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if feeds == nil {
+		feeds = []types.Address{}
+	}
+	return feeds, bar, nil
+}
+
+// getDataPoints returns data points required to update the contract. If
+// there are not enough data points to satisfy the quorum, the data points
+// found are returned along with an error.
+func (w *medianWorker) getDataPoints(ctx context.Context, feeds []types.Address, after time.Time, quorum int) ([]datapoint.Point, []types.Signature, error) {
 	// Generate slice of random indices to select data points from.
 	// It is important to select data points randomly to avoid promoting
 	// any particular feed.
-	randIndices, err := randomInts(len(w.feedAddresses))
+	randIndices, err := randomInts(len(feeds))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate random indices: %w", err)
 	}
@@ -152,14 +252,14 @@ func (w *medianWorker) getDataPoints(ctx context.Context, after time.Time, quoru
 	var dataPoints []datapoint.Point
 	var signatures []types.Signature
 	for _, i := range randIndices {
-		sdp, ok, err := w.dataPointStore.LatestFrom(ctx, w.feedAddresses[i], w.dataModel)
+		sdp, ok, err := w.dataPointStore.LatestFrom(ctx, feeds[i], w.dataModel)
 		if err != nil {
 			w.log.
 				WithError(err).
 				WithFields(log.Fields{
 					"contract":    w.contract,
 					"dataModel":   w.dataModel,
-					"feedAddress": w.feedAddresses[i],
+					"feedAddress": feeds[i],
 				}).
 				Warn("Failed to get data point")
 			continue
@@ -172,11 +272,23 @@ func (w *medianWorker) getDataPoints(ctx context.Context, after time.Time, quoru
 				WithFields(log.Fields{
 					"contract":    w.contract,
 					"dataModel":   w.dataModel,
-					"feedAddress": w.feedAddresses[i],
+					"feedAddress": feeds[i],
 				}).
 				Warn("Data point is not a tick")
 			continue
 		}
+		if sdp.SignatureScheme != datapoint.SignatureSchemeLegacy {
+			// Median contracts only verify legacy signatures.
+			w.log.
+				WithFields(log.Fields{
+					"contract":        w.contract,
+					"dataModel":       w.dataModel,
+					"feedAddress":     feeds[i],
+					"signatureScheme": sdp.SignatureScheme.String(),
+				}).
+				Warn("Data point signature scheme is not supported by Median contract")
+			continue
+		}
 		if sdp.DataPoint.Time.Before(after) {
 			continue
 		}
@@ -187,12 +299,61 @@ func (w *medianWorker) getDataPoints(ctx context.Context, after time.Time, quoru
 		}
 	}
 	if len(dataPoints) != quorum {
-		return nil, nil, errors.New("unable to obtain enough data points")
+		return dataPoints, signatures, errors.New("unable to obtain enough data points")
 	}
 
+	// The Median contract requires prices to be sorted in ascending order.
+	sort.Sort(dataPointsByPrice{dataPoints: dataPoints, signatures: signatures})
+
 	return dataPoints, signatures, nil
 }
 
+// dataPointsByPrice sorts data points and their signatures by price.
+type dataPointsByPrice struct {
+	dataPoints []datapoint.Point
+	signatures []types.Signature
+}
+
+func (d dataPointsByPrice) Len() int {
+	return len(d.dataPoints)
+}
+
+func (d dataPointsByPrice) Less(i, j int) bool {
+	return d.dataPoints[i].Value.(value.Tick).Price.Cmp(d.dataPoints[j].Value.(value.Tick).Price) < 0
+}
+
+func (d dataPointsByPrice) Swap(i, j int) {
+	d.dataPoints[i], d.dataPoints[j] = d.dataPoints[j], d.dataPoints[i]
+	d.signatures[i], d.signatures[j] = d.signatures[j], d.signatures[i]
+}
+
+// diffAddresses returns addresses from a that are not in b and addresses
+// from b that are not in a.
+func diffAddresses(a, b []types.Address) (onlyA, onlyB []types.Address) {
+	for _, x := range a {
+		if !slices.Contains(b, x) {
+			onlyA = append(onlyA, x)
+		}
+	}
+	for _, x := range b {
+		if !slices.Contains(a, x) {
+			onlyB = append(onlyB, x)
+		}
+	}
+	return onlyA, onlyB
+}
+
+// latestDataPointTime returns the time of the newest data point.
+func latestDataPointTime(dataPoints []datapoint.Point) time.Time {
+	var t time.Time
+	for _, dp := range dataPoints {
+		if dp.Time.After(t) {
+			t = dp.Time
+		}
+	}
+	return t
+}
+
 // dataPointsToPrices extracts prices from data points.
 func dataPointsToPrices(dataPoints []datapoint.Point) []*bn.DecFixedPointNumber {
 	prices := make([]*bn.DecFixedPointNumber, len(dataPoints))
@@ -208,6 +369,9 @@ func calculateMedian(prices []*bn.DecFixedPointNumber) *bn.DecFixedPointNumber {
 	if count == 0 {
 		return bn.DecFixedPoint(0, contract.MedianPricePrecision)
 	}
+	// Sort a copy, so the order of prices passed to the contract is not
+	// changed.
+	prices = append([]*bn.DecFixedPointNumber(nil), prices...)
 	sort.Slice(prices, func(i, j int) bool {
 		return prices[i].Cmp(prices[j]) < 0
 	})
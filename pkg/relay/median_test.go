@@ -0,0 +1,150 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+type testMedianContract struct {
+	feeds      []types.Address
+	bar        int
+	err        error
+	feedsCalls int
+}
+
+func (c *testMedianContract) Val(context.Context) (*bn.DecFixedPointNumber, error) {
+	return bn.DecFixedPoint(0, 18), nil
+}
+
+func (c *testMedianContract) Age(context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (c *testMedianContract) Wat(context.Context) (string, error) {
+	return "ETH/USD", nil
+}
+
+func (c *testMedianContract) Bar(context.Context) (int, error) {
+	return c.bar, c.err
+}
+
+func (c *testMedianContract) Feeds(context.Context) ([]types.Address, error) {
+	c.feedsCalls++
+	return c.feeds, c.err
+}
+
+func (c *testMedianContract) Poke(context.Context, []*bn.DecFixedPointNumber, []time.Time, []uint8, []*big.Int, []*big.Int) (*types.Transaction, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestMedianWorker_contractFeeds(t *testing.T) {
+	ctx := context.Background()
+	feeds := []types.Address{
+		types.MustAddressFromHex("0x1111111111111111111111111111111111111111"),
+		types.MustAddressFromHex("0x2222222222222222222222222222222222222222"),
+	}
+	c := &testMedianContract{feeds: feeds, bar: 2}
+	w := &medianWorker{
+		log:           null.New(),
+		contract:      c,
+		feedAddresses: feeds[:1],
+		feedsTTL:      time.Minute,
+		dataModel:     "ETH/USD",
+	}
+
+	got, bar, err := w.contractFeeds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, feeds, got)
+	assert.Equal(t, 2, bar)
+
+	// Feeds are cached for the TTL.
+	_, _, err = w.contractFeeds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.feedsCalls)
+
+	// Cached feeds are used if they cannot be refreshed.
+	w.feedsUpdatedAt = time.Now().Add(-time.Hour)
+	c.err = errors.New("rpc error")
+	got, bar, err = w.contractFeeds(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, feeds, got)
+	assert.Equal(t, 2, bar)
+	assert.Equal(t, 2, c.feedsCalls)
+
+	// Without cached feeds, the error is returned.
+	w.feeds = nil
+	_, _, err = w.contractFeeds(ctx)
+	assert.Error(t, err)
+}
+
+func TestDiffAddresses(t *testing.T) {
+	a := types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	b := types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+	c := types.MustAddressFromHex("0x3333333333333333333333333333333333333333")
+
+	onlyA, onlyB := diffAddresses([]types.Address{a, b}, []types.Address{b, c})
+	assert.Equal(t, []types.Address{a}, onlyA)
+	assert.Equal(t, []types.Address{c}, onlyB)
+
+	onlyA, onlyB = diffAddresses([]types.Address{a, b}, []types.Address{b, a})
+	assert.Empty(t, onlyA)
+	assert.Empty(t, onlyB)
+}
+
+func TestCalculateMedian(t *testing.T) {
+	prices := []*bn.DecFixedPointNumber{
+		bn.DecFixedPoint(3, 18),
+		bn.DecFixedPoint(1, 18),
+		bn.DecFixedPoint(4, 18),
+		bn.DecFixedPoint(2, 18),
+	}
+	assert.Equal(t, "2.5", calculateMedian(prices).String())
+
+	// The order of prices must not be changed.
+	assert.Equal(t, "3", prices[0].String())
+	assert.Equal(t, "1", prices[1].String())
+}
+
+func TestDataPointsByPrice(t *testing.T) {
+	var (
+		dataPoints []datapoint.Point
+		signatures []types.Signature
+	)
+	for _, p := range []int64{3, 1, 2} {
+		dataPoints = append(dataPoints, datapoint.Point{Value: value.Tick{Pair: value.Pair{Base: "A", Quote: "B"}, Price: bn.Float(p)}})
+		signatures = append(signatures, types.Signature{V: big.NewInt(p)})
+	}
+	sort.Sort(dataPointsByPrice{dataPoints: dataPoints, signatures: signatures})
+	for i, dp := range dataPoints {
+		assert.Equal(t, int64(i+1), dp.Value.(value.Tick).Price.BigInt().Int64())
+		assert.Equal(t, int64(i+1), signatures[i].V.Int64())
+	}
+}
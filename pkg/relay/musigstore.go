@@ -39,6 +39,7 @@ type MuSigStore struct {
 	transport          transport.Transport
 	scribeDataModels   []string
 	opScribeDataModels []string
+	feedKeys           FeedKeyProvider
 	signatures         map[storeKey]*messages.MuSigSignature
 	opSignatures       map[storeKey]*messages.MuSigOptimisticSignature
 }
@@ -57,6 +58,10 @@ type MuSigStoreConfig struct {
 	// optimistic signatures.
 	OpScribeDataModels []string
 
+	// FeedKeys provides public keys of feeds used to verify signatures
+	// before they are stored. If nil, signatures are not verified.
+	FeedKeys FeedKeyProvider
+
 	// Logger is a current logger interface used by the store.
 	Logger log.Logger
 }
@@ -68,7 +73,9 @@ func NewMuSigStore(cfg MuSigStoreConfig) *MuSigStore {
 		transport:          cfg.Transport,
 		scribeDataModels:   cfg.ScribeDataModels,
 		opScribeDataModels: cfg.OpScribeDataModels,
+		feedKeys:           cfg.FeedKeys,
 		signatures:         make(map[storeKey]*messages.MuSigSignature),
+		opSignatures:       make(map[storeKey]*messages.MuSigOptimisticSignature),
 	}
 }
 
@@ -199,6 +206,9 @@ func (m *MuSigStore) handleSignatureMessage(msg transport.ReceivedMessage) {
 	if !m.shouldCollectSignature(sig) {
 		return
 	}
+	if !m.verifySignature(msgAuthorToAddr(msg.Author), sig) {
+		return
+	}
 	m.collectSignature(msgAuthorToAddr(msg.Author), sig)
 }
 
@@ -215,9 +225,41 @@ func (m *MuSigStore) handleOptimisticSignatureMessage(msg transport.ReceivedMess
 	if !m.shouldCollectOpSignature(sig) {
 		return
 	}
+	if !m.verifySignature(msgAuthorToAddr(msg.Author), &sig.MuSigSignature) {
+		return
+	}
 	m.collectOpSignature(msgAuthorToAddr(msg.Author), sig)
 }
 
+// verifySignature returns false if the Schnorr signature is invalid or
+// cannot be verified because public keys of some signers are not known.
+func (m *MuSigStore) verifySignature(feed types.Address, sig *messages.MuSigSignature) bool {
+	if m.feedKeys == nil {
+		return true
+	}
+	err := verifyMuSigSignature(sig, m.feedKeys)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, errUnknownPublicKey):
+		m.log.
+			WithError(err).
+			WithField("feed", feed.String()).
+			WithField("dataModel", m.signatureDataModel(sig)).
+			WithField("sessionID", sig.SessionID.String()).
+			Warn("Unable to verify MuSig signature, dropping")
+		return false
+	default:
+		m.log.
+			WithError(err).
+			WithField("feed", feed.String()).
+			WithField("dataModel", m.signatureDataModel(sig)).
+			WithField("sessionID", sig.SessionID.String()).
+			Warn("Invalid MuSig signature, dropping")
+		return false
+	}
+}
+
 func (m *MuSigStore) signatureDataModel(sig *messages.MuSigSignature) string {
 	model, ok := sig.MsgMeta["wat"]
 	if !ok {
@@ -0,0 +1,73 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+)
+
+// pokePending reports whether a previous update transaction sent to the
+// contract is not mined yet. A new update must not be sent until then,
+// because it would compete with the pending one.
+func pokePending(logger log.Logger, txManager *TxManager, address types.Address) bool {
+	hash, ok := txManager.PendingTransaction(address)
+	if ok {
+		logger.
+			WithField("txHash", hash.String()).
+			Info("Previous update transaction is pending, skipping")
+	}
+	return ok
+}
+
+// sendPoke sends the transaction that updates a contract. It does not wait
+// until the transaction is mined, the result is logged and recorded in the
+// contract status in the background.
+func sendPoke(
+	ctx context.Context,
+	logger log.Logger,
+	txManager *TxManager,
+	status *contractStatus,
+	reason string,
+	tx types.Transaction,
+) (*PendingTx, error) {
+	if tx.To != nil {
+		logger = logger.WithField("contract", tx.To.String())
+	}
+	ptx, err := txManager.Send(ctx, tx)
+	if err != nil {
+		status.setPoke(reason, nil, err)
+		return nil, err
+	}
+	go func() {
+		receipt, err := ptx.Wait(ctx)
+		status.setPoke(reason, receipt, err)
+		if err != nil {
+			logger.
+				WithError(err).
+				WithField("txHash", ptx.Hash().String()).
+				Error("Update transaction failed")
+			return
+		}
+		logger.
+			WithFields(receiptLogFields(receipt)).
+			Info("Contract updated")
+	}()
+	return ptx, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
@@ -38,7 +39,8 @@ type MedianContract interface {
 	Age(ctx context.Context) (time.Time, error)
 	Wat(ctx context.Context) (string, error)
 	Bar(ctx context.Context) (int, error)
-	Poke(ctx context.Context, val []*bn.DecFixedPointNumber, age []time.Time, v []uint8, r []*big.Int, s []*big.Int) error
+	Feeds(ctx context.Context) ([]types.Address, error)
+	Poke(ctx context.Context, val []*bn.DecFixedPointNumber, age []time.Time, v []uint8, r []*big.Int, s []*big.Int) (*types.Transaction, error)
 }
 
 type ScribeContract interface {
@@ -46,12 +48,12 @@ type ScribeContract interface {
 	Bar(ctx context.Context) (int, error)
 	Feeds(ctx context.Context) ([]types.Address, []uint8, error)
 	Read(ctx context.Context) (*bn.DecFixedPointNumber, time.Time, error)
-	Poke(ctx context.Context, pokeData contract.PokeData, schnorrData contract.SchnorrData) error
+	Poke(ctx context.Context, pokeData contract.PokeData, schnorrData contract.SchnorrData) (*types.Transaction, error)
 }
 
 type OpScribeContract interface {
 	ScribeContract
-	OpPoke(ctx context.Context, pokeData contract.PokeData, schnorrData contract.SchnorrData, ecdsaData types.Signature) error
+	OpPoke(ctx context.Context, pokeData contract.PokeData, schnorrData contract.SchnorrData, ecdsaData types.Signature) (*types.Transaction, error)
 }
 
 type Relay struct {
@@ -59,25 +61,46 @@ type Relay struct {
 	waitCh chan error
 	log    log.Logger
 
-	medians   []*medianWorker
-	scribes   []*scribeWorker
-	opScribes []*opScribeWorker
+	medians     []*medianWorker
+	scribes     []*scribeWorker
+	opScribes   []*opScribeWorker
+	watchtowers []*watchtowerWorker
+	dryRun      bool
 }
 
 type Config struct {
 	Medians           []ConfigMedian
 	Scribes           []ConfigScribe
 	OptimisticScribes []ConfigOptimisticScribe
+	Watchtowers       []ConfigWatchtower
 	Logger            log.Logger
+
+	// DryRun enables the dry-run mode. In the dry-run mode, the relay
+	// checks contracts and simulates updates, but it does not send any
+	// transactions. Updates that would have been sent are logged and
+	// counted in the contract status.
+	DryRun bool
 }
 
 type ConfigMedian struct {
 	DataModel       string
 	ContractAddress types.Address
-	FeedAddresses   []types.Address
 	Client          rpc.RPC
+	TxManager       *TxManager
 	DataPointStore  *store.Store
 
+	// FeedAddresses is the list of expected feeds. Feeds are read from the
+	// contract, and a warning is logged if they differ from this list.
+	FeedAddresses []types.Address
+
+	// FeedsTTL is the time after which the feed list and the quorum are
+	// read from the contract again. If zero, DefaultMedianFeedsTTL is used.
+	FeedsTTL time.Duration
+
+	// Coordinator is an optional coordinator used to avoid duplicate
+	// updates when multiple relays update the same contract.
+	Coordinator *Coordinator
+
 	// Spread is the minimum calcSpread between the oracle price and new
 	// price required to send update.
 	Spread float64
@@ -96,8 +119,17 @@ type ConfigScribe struct {
 	ContractAddress types.Address
 	FeedAddresses   []types.Address
 	Client          rpc.RPC
+	TxManager       *TxManager
 	MuSigStore      *MuSigStore
 
+	// Coordinator is an optional coordinator used to avoid duplicate
+	// updates when multiple relays update the same contract.
+	Coordinator *Coordinator
+
+	// FeedKeyStore is an optional store of feed public keys used to verify
+	// signatures before sending updates.
+	FeedKeyStore *FeedKeyStore
+
 	// Spread is the minimum calcSpread between the oracle price and new
 	// price required to send update.
 	Spread float64
@@ -116,8 +148,17 @@ type ConfigOptimisticScribe struct {
 	ContractAddress types.Address
 	FeedAddresses   []types.Address
 	Client          rpc.RPC
+	TxManager       *TxManager
 	MuSigStore      *MuSigStore
 
+	// Coordinator is an optional coordinator used to avoid duplicate
+	// updates when multiple relays update the same contract.
+	Coordinator *Coordinator
+
+	// FeedKeyStore is an optional store of feed public keys used to verify
+	// signatures before sending updates.
+	FeedKeyStore *FeedKeyStore
+
 	// Spread is the minimum calcSpread between the oracle price and new
 	// price required to send update.
 	Spread float64
@@ -131,18 +172,45 @@ type ConfigOptimisticScribe struct {
 	Ticker *timeutil.Ticker
 }
 
+type ConfigWatchtower struct {
+	ContractAddress types.Address
+	Client          rpc.RPC
+	TxManager       *TxManager
+	FeedKeyStore    *FeedKeyStore
+
+	// LookbackBlocks is the number of recent blocks searched for optimistic
+	// updates. If zero, DefaultWatchtowerLookbackBlocks is used.
+	LookbackBlocks uint64
+
+	// Ticker notifies the watchtower to check for new optimistic updates.
+	Ticker *timeutil.Ticker
+}
+
 func New(cfg Config) (*Relay, error) {
 	logger := cfg.Logger.WithField("tag", LoggerTag)
 	r := &Relay{
 		waitCh: make(chan error),
 		log:    logger,
+		dryRun: cfg.DryRun,
 	}
 	for _, m := range cfg.Medians {
+		if m.TxManager == nil {
+			return nil, errors.New("tx manager must not be nil")
+		}
+		feedsTTL := m.FeedsTTL
+		if feedsTTL == 0 {
+			feedsTTL = DefaultMedianFeedsTTL
+		}
 		r.medians = append(r.medians, &medianWorker{
 			log:            logger,
 			dataPointStore: m.DataPointStore,
 			feedAddresses:  m.FeedAddresses,
+			feedsTTL:       feedsTTL,
 			contract:       contract.NewMedian(m.Client, m.ContractAddress),
+			txManager:      m.TxManager,
+			coordinator:    m.Coordinator,
+			status:         newContractStatus(ContractTypeMedian, m.DataModel, m.ContractAddress),
+			address:        m.ContractAddress,
 			dataModel:      m.DataModel,
 			spread:         m.Spread,
 			expiration:     m.Expiration,
@@ -150,30 +218,103 @@ func New(cfg Config) (*Relay, error) {
 		})
 	}
 	for _, s := range cfg.Scribes {
+		if s.TxManager == nil {
+			return nil, errors.New("tx manager must not be nil")
+		}
 		r.scribes = append(r.scribes, &scribeWorker{
-			log:        logger,
-			muSigStore: s.MuSigStore,
-			contract:   contract.NewScribe(s.Client, s.ContractAddress),
-			dataModel:  s.DataModel,
-			spread:     s.Spread,
-			expiration: s.Expiration,
-			ticker:     s.Ticker,
+			log:         logger,
+			muSigStore:  s.MuSigStore,
+			contract:    contract.NewScribe(s.Client, s.ContractAddress),
+			txManager:   s.TxManager,
+			coordinator: s.Coordinator,
+			feedKeys:    feedKeyProvider(s.FeedKeyStore),
+			status:      newContractStatus(ContractTypeScribe, s.DataModel, s.ContractAddress),
+			address:     s.ContractAddress,
+			dataModel:   s.DataModel,
+			spread:      s.Spread,
+			expiration:  s.Expiration,
+			ticker:      s.Ticker,
 		})
 	}
 	for _, s := range cfg.OptimisticScribes {
+		if s.TxManager == nil {
+			return nil, errors.New("tx manager must not be nil")
+		}
 		r.opScribes = append(r.opScribes, &opScribeWorker{
-			log:        nil,
-			muSigStore: s.MuSigStore,
-			contract:   contract.NewOpScribe(s.Client, s.ContractAddress),
-			dataModel:  s.DataModel,
-			spread:     s.Spread,
-			expiration: s.Expiration,
-			ticker:     s.Ticker,
+			log:         logger,
+			muSigStore:  s.MuSigStore,
+			contract:    contract.NewOpScribe(s.Client, s.ContractAddress),
+			txManager:   s.TxManager,
+			coordinator: s.Coordinator,
+			feedKeys:    feedKeyProvider(s.FeedKeyStore),
+			status:      newContractStatus(ContractTypeOptimisticScribe, s.DataModel, s.ContractAddress),
+			address:     s.ContractAddress,
+			dataModel:   s.DataModel,
+			spread:      s.Spread,
+			expiration:  s.Expiration,
+			ticker:      s.Ticker,
+		})
+	}
+	for _, w := range cfg.Watchtowers {
+		if w.TxManager == nil {
+			return nil, errors.New("tx manager must not be nil")
+		}
+		if w.FeedKeyStore == nil {
+			return nil, errors.New("feed key store must not be nil")
+		}
+		lookback := w.LookbackBlocks
+		if lookback == 0 {
+			lookback = DefaultWatchtowerLookbackBlocks
+		}
+		r.watchtowers = append(r.watchtowers, &watchtowerWorker{
+			log:        logger,
+			client:     w.Client,
+			contract:   contract.NewOpScribe(w.Client, w.ContractAddress),
+			txManager:  w.TxManager,
+			feedKeys:   feedKeyProvider(w.FeedKeyStore),
+			address:    w.ContractAddress,
+			lookback:   lookback,
+			ticker:     w.Ticker,
+			processed:  make(map[opPokeKey]uint64),
+			challenges: make(map[opPokeKey]*PendingTx),
+			liftedKeys: make(map[types.Address]schnorr.PublicKey),
+			unverified: make(map[opPokeKey]bool),
 		})
 	}
+	if cfg.DryRun {
+		// Coordination is disabled, so other relays do not wait for updates
+		// that are never sent.
+		for _, w := range r.medians {
+			w.dryRun = true
+			w.coordinator = nil
+			w.status.enableDryRun()
+		}
+		for _, w := range r.scribes {
+			w.dryRun = true
+			w.coordinator = nil
+			w.status.enableDryRun()
+		}
+		for _, w := range r.opScribes {
+			w.dryRun = true
+			w.coordinator = nil
+			w.status.enableDryRun()
+		}
+		for _, w := range r.watchtowers {
+			w.dryRun = true
+		}
+	}
 	return r, nil
 }
 
+// feedKeyProvider returns nil if the store is nil, so that the returned
+// interface can be compared to nil.
+func feedKeyProvider(s *FeedKeyStore) FeedKeyProvider {
+	if s == nil {
+		return nil
+	}
+	return s
+}
+
 // Start implements the supervisor.Service interface.
 func (m *Relay) Start(ctx context.Context) error {
 	if m.ctx != nil {
@@ -193,10 +334,35 @@ func (m *Relay) Start(ctx context.Context) error {
 	for _, w := range m.opScribes {
 		go w.workerRoutine(ctx)
 	}
+	for _, w := range m.watchtowers {
+		go w.workerRoutine(ctx)
+	}
 	go m.contextCancelHandler()
 	return nil
 }
 
+// Status returns the status of all contracts updated by the relay.
+func (m *Relay) Status() []ContractStatus {
+	var statuses []ContractStatus
+	add := func(s *contractStatus, txManager *TxManager) {
+		st := s.get()
+		if hash, ok := txManager.PendingTransaction(st.Address); ok {
+			st.PendingTxHash = &hash
+		}
+		statuses = append(statuses, st)
+	}
+	for _, w := range m.medians {
+		add(w.status, w.txManager)
+	}
+	for _, w := range m.scribes {
+		add(w.status, w.txManager)
+	}
+	for _, w := range m.opScribes {
+		add(w.status, w.txManager)
+	}
+	return statuses
+}
+
 // Wait implements the supervisor.Service interface.
 func (m *Relay) Wait() <-chan error {
 	return m.waitCh
@@ -206,4 +372,29 @@ func (m *Relay) contextCancelHandler() {
 	defer func() { close(m.waitCh) }()
 	defer m.log.Info("Stopped")
 	<-m.ctx.Done()
+	if m.dryRun {
+		m.logDryRunSummary()
+	}
+}
+
+// logDryRunSummary logs the number of updates that would have been sent
+// to every contract during the dry run.
+func (m *Relay) logDryRunSummary() {
+	for _, st := range m.Status() {
+		if st.DryRun == nil {
+			continue
+		}
+		m.log.
+			WithFields(log.Fields{
+				"type":      st.Type,
+				"dataModel": st.DataModel,
+				"address":   st.Address.String(),
+				"checks":    st.DryRun.Checks,
+				"pokes":     st.DryRun.Pokes,
+				"expired":   st.DryRun.Expired,
+				"stale":     st.DryRun.Stale,
+				"failed":    st.DryRun.Failed,
+			}).
+			Info("Dry run summary")
+	}
 }
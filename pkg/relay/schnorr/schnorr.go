@@ -0,0 +1,144 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package schnorr implements the Schnorr signature scheme used by Chronicle
+// Scribe contracts.
+package schnorr
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+)
+
+// PublicKey is a secp256k1 public key in affine coordinates.
+type PublicKey struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// Address returns the Ethereum address of the public key.
+func (p PublicKey) Address() types.Address {
+	var b [64]byte
+	p.X.FillBytes(b[:32])
+	p.Y.FillBytes(b[32:])
+	h := crypto.Keccak256(b[:])
+	return types.MustAddressFromBytes(h[12:])
+}
+
+// Valid returns true if the public key is a point on the curve.
+func (p PublicKey) Valid() bool {
+	_, err := p.jacobian()
+	return err == nil
+}
+
+func (p PublicKey) jacobian() (secp256k1.JacobianPoint, error) {
+	var x, y secp256k1.FieldVal
+	if p.X == nil || p.Y == nil || p.X.Sign() < 0 || p.Y.Sign() < 0 || p.X.BitLen() > 256 || p.Y.BitLen() > 256 {
+		return secp256k1.JacobianPoint{}, errors.New("invalid public key coordinates")
+	}
+	if x.SetByteSlice(p.X.Bytes()) || y.SetByteSlice(p.Y.Bytes()) {
+		return secp256k1.JacobianPoint{}, errors.New("public key coordinates overflow the field")
+	}
+	if !secp256k1.NewPublicKey(&x, &y).IsOnCurve() {
+		return secp256k1.JacobianPoint{}, errors.New("public key is not on the curve")
+	}
+	var one secp256k1.FieldVal
+	one.SetInt(1)
+	return secp256k1.MakeJacobianPoint(&x, &y, &one), nil
+}
+
+func fromJacobian(p secp256k1.JacobianPoint) PublicKey {
+	p.ToAffine()
+	var x, y [32]byte
+	p.X.PutBytes(&x)
+	p.Y.PutBytes(&y)
+	return PublicKey{X: new(big.Int).SetBytes(x[:]), Y: new(big.Int).SetBytes(y[:])}
+}
+
+// AggregatePublicKeys returns the sum of the public keys.
+func AggregatePublicKeys(keys []PublicKey) (PublicKey, error) {
+	if len(keys) == 0 {
+		return PublicKey{}, errors.New("no public keys to aggregate")
+	}
+	agg, err := keys[0].jacobian()
+	if err != nil {
+		return PublicKey{}, err
+	}
+	for _, k := range keys[1:] {
+		p, err := k.jacobian()
+		if err != nil {
+			return PublicKey{}, err
+		}
+		secp256k1.AddNonConst(&agg, &p, &agg)
+	}
+	if (agg.X.IsZero() && agg.Y.IsZero()) || agg.Z.IsZero() {
+		return PublicKey{}, errors.New("aggregated public key is the point at infinity")
+	}
+	return fromJacobian(agg), nil
+}
+
+// Challenge returns the challenge of the signature:
+//
+//	e = H(Pₓ ‖ Pₚ ‖ m ‖ Rₑ) mod Q
+//
+// where Pₓ is the x coordinate of the public key, Pₚ is the parity of its y
+// coordinate, m is the message and Rₑ is the commitment address.
+func Challenge(pubKey PublicKey, message types.Hash, commitment types.Address) *big.Int {
+	var x [32]byte
+	pubKey.X.FillBytes(x[:])
+	h := crypto.Keccak256(x[:], []byte{byte(pubKey.Y.Bit(0))}, message.Bytes(), commitment.Bytes())
+	e := new(big.Int).SetBytes(h.Bytes())
+	return e.Mod(e, secp256k1.S256().N)
+}
+
+// Verify verifies the Schnorr signature of the message.
+//
+// The signature is valid if the address of the point [s]G - [e]P is equal
+// to the commitment, where s is the signature, e is the challenge and P is
+// the public key. This is equivalent to the verification performed by the
+// Scribe contract using the ecrecover precompile.
+func Verify(pubKey PublicKey, message types.Hash, signature *big.Int, commitment types.Address) bool {
+	if signature == nil || signature.Sign() <= 0 || commitment == (types.Address{}) {
+		return false
+	}
+	p, err := pubKey.jacobian()
+	if err != nil {
+		return false
+	}
+	var s, e secp256k1.ModNScalar
+	if signature.BitLen() > 256 || s.SetByteSlice(signature.Bytes()) {
+		return false
+	}
+	e.SetByteSlice(Challenge(pubKey, message, commitment).Bytes())
+	if e.IsZero() {
+		return false
+	}
+
+	// R = [s]G - [e]P
+	var sG, eP, r secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&s, &sG)
+	secp256k1.ScalarMultNonConst(&e, &p, &eP)
+	eP.ToAffine()
+	eP.Y.Negate(1).Normalize()
+	secp256k1.AddNonConst(&sG, &eP, &r)
+	if r.Z.IsZero() {
+		return false
+	}
+	return fromJacobian(r).Address() == commitment
+}
@@ -0,0 +1,101 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package schnorr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPublicKey(priv *big.Int) PublicKey {
+	var k secp256k1.ModNScalar
+	k.SetByteSlice(priv.Bytes())
+	var p secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&k, &p)
+	return fromJacobian(p)
+}
+
+// testSign signs the message using the private key and the nonce:
+//
+//	s = k + e * x mod Q
+func testSign(priv, nonce *big.Int, message types.Hash) (*big.Int, types.Address) {
+	commitment := testPublicKey(nonce).Address()
+	e := Challenge(testPublicKey(priv), message, commitment)
+	s := new(big.Int).Mul(e, priv)
+	s.Add(s, nonce)
+	s.Mod(s, secp256k1.S256().N)
+	return s, commitment
+}
+
+func TestPublicKey_Address(t *testing.T) {
+	priv := big.NewInt(1)
+	key := testPublicKey(priv)
+	// Address of the private key 1.
+	assert.Equal(t, types.MustAddressFromHex("0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"), key.Address())
+	assert.True(t, key.Valid())
+	assert.False(t, PublicKey{X: big.NewInt(1), Y: big.NewInt(1)}.Valid())
+}
+
+func TestAggregatePublicKeys(t *testing.T) {
+	agg, err := AggregatePublicKeys([]PublicKey{
+		testPublicKey(big.NewInt(2)),
+		testPublicKey(big.NewInt(3)),
+		testPublicKey(big.NewInt(5)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, testPublicKey(big.NewInt(10)), agg)
+
+	_, err = AggregatePublicKeys(nil)
+	assert.Error(t, err)
+
+	// P + (-P) is the point at infinity.
+	p := testPublicKey(big.NewInt(7))
+	negP := PublicKey{X: p.X, Y: new(big.Int).Sub(secp256k1.S256().P, p.Y)}
+	_, err = AggregatePublicKeys([]PublicKey{p, negP})
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	privs := []*big.Int{big.NewInt(1234), big.NewInt(5678)}
+	aggPriv := new(big.Int).Add(privs[0], privs[1])
+	aggPub, err := AggregatePublicKeys([]PublicKey{testPublicKey(privs[0]), testPublicKey(privs[1])})
+	require.NoError(t, err)
+
+	message := crypto.Keccak256([]byte("message"))
+	sig, commitment := testSign(aggPriv, big.NewInt(987654321), message)
+
+	assert.True(t, Verify(aggPub, message, sig, commitment))
+
+	// Invalid signature.
+	assert.False(t, Verify(aggPub, message, new(big.Int).Add(sig, big.NewInt(1)), commitment))
+	assert.False(t, Verify(aggPub, message, big.NewInt(0), commitment))
+
+	// Different message.
+	assert.False(t, Verify(aggPub, crypto.Keccak256([]byte("other")), sig, commitment))
+
+	// Different commitment.
+	assert.False(t, Verify(aggPub, message, sig, types.Address{}))
+	assert.False(t, Verify(aggPub, message, sig, testPublicKey(big.NewInt(1)).Address()))
+
+	// Missing signer.
+	assert.False(t, Verify(testPublicKey(privs[0]), message, sig, commitment))
+}
@@ -17,25 +17,32 @@ package relay
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
-	"math/big"
 	"time"
 
+	"github.com/defiweb/go-eth/types"
+
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
-	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
 )
 
 type scribeWorker struct {
-	log        log.Logger
-	muSigStore *MuSigStore
-	contract   ScribeContract
-	dataModel  string
-	spread     float64
-	expiration time.Duration
-	ticker     *timeutil.Ticker
+	log         log.Logger
+	muSigStore  *MuSigStore
+	contract    ScribeContract
+	txManager   *TxManager
+	coordinator *Coordinator
+	feedKeys    FeedKeyProvider
+	status      *contractStatus
+	address     types.Address
+	dataModel   string
+	spread      float64
+	expiration  time.Duration
+	ticker      *timeutil.Ticker
+	dryRun      bool
 }
 
 func (w *scribeWorker) workerRoutine(ctx context.Context) {
@@ -45,7 +52,9 @@ func (w *scribeWorker) workerRoutine(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-w.ticker.TickCh():
-			if err := w.tryUpdate(ctx); err != nil {
+			err := w.tryUpdate(ctx)
+			w.status.setCheck(err)
+			if err != nil {
 				w.log.WithError(err).Error("Failed to update Scribe contract")
 			}
 		}
@@ -74,6 +83,9 @@ func (w *scribeWorker) tryUpdate(ctx context.Context) error {
 		return err
 	}
 
+	w.status.setOnChain(val, age, bar, w.expiration)
+	w.status.setOffChain(nil, time.Time{}, 0, 0)
+
 	// Feed list required to generate signersBlob.
 	feeds, indices, err := w.contract.Feeds(ctx)
 	if err != nil {
@@ -82,18 +94,14 @@ func (w *scribeWorker) tryUpdate(ctx context.Context) error {
 
 	// Iterate over all signatures to check if any of them can be used to update
 	// the price on the Scribe contract.
+	hasOffChain := false
 	for _, s := range w.muSigStore.SignaturesByDataModel(w.dataModel) {
 		// Get price and its timestamp from the signature.
-		sigValBin, ok := s.MsgMeta["val"]
-		if !ok {
-			continue
-		}
-		sigAgeBin, ok := s.MsgMeta["age"]
-		if !ok {
+		pokeData, err := muSigPokeData(s)
+		if err != nil {
 			continue
 		}
-		sigVal := bn.DecFixedPointFromRawBigInt(new(big.Int).SetBytes(sigValBin), contract.ScribePricePrecision)
-		sigAge := time.Unix(new(big.Int).SetBytes(sigAgeBin).Int64(), 0)
+		sigVal, sigAge := pokeData.Val, pokeData.Age
 
 		// If the signature is older than the current price, skip it.
 		if sigAge.Before(age) {
@@ -110,27 +118,28 @@ func (w *scribeWorker) tryUpdate(ctx context.Context) error {
 		isExpired := time.Since(age) >= w.expiration
 		isStale := math.IsInf(spread, 0) || spread >= w.spread
 
-		// Generate signersBlob.
-		signersBlob := make([]byte, len(s.Signers))
-		for i, signer := range s.Signers {
-			f := false
-			for j, feed := range feeds {
-				if feed == signer {
-					f = true
-					signersBlob[i] = indices[j]
-				}
-				if !f {
-					break
-				}
-			}
+		// Signatures are sorted by newest first, so the first usable one is
+		// the best price available.
+		if !hasOffChain {
+			w.status.setOffChain(sigVal, sigAge, spread, len(s.Signers))
+			hasOffChain = true
 		}
 
-		// If signersBlob is not the same length as the number of feeds, it
-		// means that some signers are not present in the feed list on the
-		// contract.
-		if len(signersBlob) != len(s.Signers) {
+		// Generate signersBlob. If some signers are not present in the feed
+		// list on the contract, the signature cannot be used.
+		blob, err := signersBlob(s.Signers, feeds, indices)
+		if err != nil {
+			w.log.
+				WithError(err).
+				WithField("dataModel", w.dataModel).
+				Debug("Unable to use the signature")
 			continue
 		}
+		schnorrData := contract.SchnorrData{
+			Signature:   s.SchnorrSignature,
+			Commitment:  s.Commitment,
+			SignersBlob: blob,
+		}
 
 		// Print logs.
 		w.log.
@@ -150,19 +159,55 @@ func (w *scribeWorker) tryUpdate(ctx context.Context) error {
 
 		// If price is stale or expired, send update.
 		if isExpired || isStale {
-			// Send *actual* transaction.
-			return w.contract.Poke(
+			// Verify the signature before sending a transaction, so invalid
+			// signatures do not cost a revert. Signatures that cannot be
+			// verified because public keys of some signers are not known are
+			// not used either.
+			if w.feedKeys != nil {
+				err := verifyScribeSignature(wat, pokeData, schnorrData, bar, feeds, indices, w.feedKeys)
+				if err != nil {
+					msg := "Invalid Schnorr signature, skipping"
+					if errors.Is(err, errUnknownPublicKey) {
+						msg = "Unable to verify Schnorr signature, skipping"
+					}
+					w.log.
+						WithError(err).
+						WithField("dataModel", w.dataModel).
+						WithField("sessionID", s.SessionID.String()).
+						Warn(msg)
+					continue
+				}
+			}
+
+			if pokePending(w.log.WithField("dataModel", w.dataModel), w.txManager, w.address) {
+				return nil
+			}
+			if w.coordinator != nil && !w.coordinator.Acquire(w.address, w.dataModel, age) {
+				return nil
+			}
+
+			tx, err := w.contract.Poke(
 				ctx,
-				contract.PokeData{
-					Val: sigVal,
-					Age: sigAge,
-				},
-				contract.SchnorrData{
-					Signature:   s.SchnorrSignature,
-					Commitment:  s.Commitment,
-					SignersBlob: signersBlob,
-				},
+				pokeData,
+				schnorrData,
 			)
+			if w.dryRun {
+				return dryRunPoke(ctx, w.log.WithField("dataModel", w.dataModel), w.txManager, w.status, isExpired, isStale, tx, err)
+			}
+			if err != nil {
+				w.status.setPoke(pokeReason(isExpired, isStale), nil, err)
+				return err
+			}
+
+			// Send *actual* transaction.
+			ptx, err := sendPoke(ctx, w.log.WithField("dataModel", w.dataModel), w.txManager, w.status, pokeReason(isExpired, isStale), *tx)
+			if err != nil {
+				return err
+			}
+			if w.coordinator != nil {
+				w.coordinator.Track(w.address, w.dataModel, age, ptx)
+			}
+			return nil
 		}
 	}
 
@@ -0,0 +1,268 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+const (
+	ContractTypeMedian           = "median"
+	ContractTypeScribe           = "scribe"
+	ContractTypeOptimisticScribe = "optimistic_scribe"
+)
+
+const (
+	PokeOutcomeSuccess  = "success"
+	PokeOutcomeReverted = "reverted"
+	PokeOutcomeFailed   = "failed"
+	PokeOutcomeDryRun   = "dry_run"
+)
+
+// ContractStatus is the state of a contract updated by the relay, as
+// observed during the last check.
+type ContractStatus struct {
+	// Type is the type of the contract.
+	Type string `json:"type"`
+
+	// DataModel is the data model of the contract.
+	DataModel string `json:"data_model"`
+
+	// Address is the address of the contract.
+	Address types.Address `json:"address"`
+
+	// Val and Age are the current on-chain price and the time of the last
+	// update.
+	Val string    `json:"val,omitempty"`
+	Age time.Time `json:"age"`
+
+	// OffChainVal and OffChainAge are the best price available to the
+	// relay and its time.
+	OffChainVal string    `json:"off_chain_val,omitempty"`
+	OffChainAge time.Time `json:"off_chain_age"`
+
+	// Spread is the spread between the on-chain and off-chain prices as a
+	// percentage point. It is nil if the spread is not known or infinite.
+	Spread *float64 `json:"spread,omitempty"`
+
+	// TimeToExpiration is the time in seconds left until the on-chain price
+	// expires. It is negative if the price is already expired.
+	TimeToExpiration int64 `json:"time_to_expiration"`
+
+	// Bar is the number of signatures required by the contract and Signers
+	// is the number of signatures available to the relay.
+	Bar           int  `json:"bar"`
+	Signers       int  `json:"signers"`
+	QuorumReached bool `json:"quorum_reached"`
+
+	// LastCheck is the time of the last check and LastError is the error
+	// returned by it, if any.
+	LastCheck time.Time `json:"last_check"`
+	LastError string    `json:"last_error,omitempty"`
+
+	// LastPoke is the last attempt to update the contract.
+	LastPoke *PokeStatus `json:"last_poke,omitempty"`
+
+	// PendingTxHash is the hash of the update transaction that is not
+	// mined yet.
+	PendingTxHash *types.Hash `json:"pending_tx_hash,omitempty"`
+
+	// DryRun summarizes updates that would have been sent. It is nil if
+	// the relay is not running in the dry-run mode.
+	DryRun *DryRunSummary `json:"dry_run,omitempty"`
+}
+
+// DryRunSummary counts updates that would have been sent to a contract in
+// the dry-run mode.
+type DryRunSummary struct {
+	// Checks is the number of checks of the contract.
+	Checks int `json:"checks"`
+
+	// Pokes is the number of updates that would have been sent. Expired
+	// and Stale count the reasons of these updates, an update may have
+	// both reasons.
+	Pokes   int `json:"pokes"`
+	Expired int `json:"expired"`
+	Stale   int `json:"stale"`
+
+	// Failed is the number of updates that were required but failed the
+	// simulation or gas estimation.
+	Failed int `json:"failed"`
+}
+
+// PokeStatus is the result of an attempt to update a contract.
+type PokeStatus struct {
+	Time    time.Time   `json:"time"`
+	Reason  string      `json:"reason"`
+	Outcome string      `json:"outcome"`
+	TxHash  *types.Hash `json:"tx_hash,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// contractStatus holds the status of a contract shared between a worker
+// and the status API.
+type contractStatus struct {
+	mu     sync.Mutex
+	status ContractStatus
+}
+
+func newContractStatus(typ, dataModel string, address types.Address) *contractStatus {
+	return &contractStatus{status: ContractStatus{
+		Type:      typ,
+		DataModel: dataModel,
+		Address:   address,
+	}}
+}
+
+func (s *contractStatus) get() ContractStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.status
+	if st.LastPoke != nil {
+		p := *st.LastPoke
+		st.LastPoke = &p
+	}
+	if st.DryRun != nil {
+		d := *st.DryRun
+		st.DryRun = &d
+	}
+	return st
+}
+
+// setOnChain updates the on-chain state of the contract.
+func (s *contractStatus) setOnChain(val *bn.DecFixedPointNumber, age time.Time, bar int, expiration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Val = val.String()
+	s.status.Age = age
+	s.status.Bar = bar
+	s.status.TimeToExpiration = int64((expiration - time.Since(age)).Seconds())
+}
+
+// setOffChain updates the best price available to the relay. If val is
+// nil, no price is available.
+func (s *contractStatus) setOffChain(val *bn.DecFixedPointNumber, age time.Time, spread float64, signers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.OffChainVal = ""
+	s.status.OffChainAge = time.Time{}
+	s.status.Spread = nil
+	s.status.Signers = signers
+	s.status.QuorumReached = signers > 0 && signers >= s.status.Bar
+	if val == nil {
+		return
+	}
+	s.status.OffChainVal = val.String()
+	s.status.OffChainAge = age
+	if !math.IsInf(spread, 0) && !math.IsNaN(spread) {
+		s.status.Spread = &spread
+	}
+}
+
+// setCheck records the result of a check.
+func (s *contractStatus) setCheck(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.LastCheck = time.Now()
+	s.status.LastError = ""
+	if s.status.DryRun != nil {
+		s.status.DryRun.Checks++
+	}
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+}
+
+// setPoke records the result of an attempt to update the contract.
+func (s *contractStatus) setPoke(reason string, receipt *types.TransactionReceipt, err error) {
+	p := &PokeStatus{
+		Time:    time.Now(),
+		Reason:  reason,
+		Outcome: PokeOutcomeSuccess,
+	}
+	if receipt != nil {
+		hash := receipt.TransactionHash
+		p.TxHash = &hash
+	}
+	if err != nil {
+		p.Error = err.Error()
+		p.Outcome = PokeOutcomeFailed
+		if receipt != nil {
+			p.Outcome = PokeOutcomeReverted
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.LastPoke = p
+}
+
+// enableDryRun enables counting of updates that would have been sent in
+// the dry-run mode.
+func (s *contractStatus) enableDryRun() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.DryRun = &DryRunSummary{}
+}
+
+// setDryRunPoke records an update that would have been sent in the dry-run
+// mode. The err is the error returned by the simulation or gas estimation.
+func (s *contractStatus) setDryRunPoke(isExpired, isStale bool, err error) {
+	p := &PokeStatus{
+		Time:    time.Now(),
+		Reason:  pokeReason(isExpired, isStale),
+		Outcome: PokeOutcomeDryRun,
+	}
+	if err != nil {
+		p.Error = err.Error()
+		p.Outcome = PokeOutcomeFailed
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.LastPoke = p
+	if s.status.DryRun == nil {
+		return
+	}
+	if err != nil {
+		s.status.DryRun.Failed++
+		return
+	}
+	s.status.DryRun.Pokes++
+	if isExpired {
+		s.status.DryRun.Expired++
+	}
+	if isStale {
+		s.status.DryRun.Stale++
+	}
+}
+
+// pokeReason returns the reason of a contract update.
+func pokeReason(isExpired, isStale bool) string {
+	var r []string
+	if isExpired {
+		r = append(r, "expired")
+	}
+	if isStale {
+		r = append(r, "stale")
+	}
+	return strings.Join(r, ",")
+}
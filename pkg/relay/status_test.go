@@ -0,0 +1,60 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestContractStatus(t *testing.T) {
+	s := newContractStatus(ContractTypeMedian, "ETH/USD", testContract)
+	age := time.Now().Add(-time.Minute)
+
+	s.setOnChain(bn.DecFixedPoint(1500, 18), age, 3, time.Hour)
+	s.setOffChain(bn.DecFixedPoint(1530, 18), age.Add(time.Second), 2, 3)
+	st := s.get()
+	assert.Equal(t, "1500", st.Val)
+	assert.Equal(t, "1530", st.OffChainVal)
+	require.NotNil(t, st.Spread)
+	assert.Equal(t, float64(2), *st.Spread)
+	assert.InDelta(t, 59*60, st.TimeToExpiration, 1)
+	assert.True(t, st.QuorumReached)
+
+	// Infinite spread cannot be encoded in JSON.
+	s.setOffChain(bn.DecFixedPoint(1530, 18), age, math.Inf(1), 2)
+	st = s.get()
+	assert.Nil(t, st.Spread)
+	assert.False(t, st.QuorumReached)
+
+	s.setPoke(pokeReason(true, false), minedReceipt(testHash1, 0), errors.New("reverted"))
+	st = s.get()
+	require.NotNil(t, st.LastPoke)
+	assert.Equal(t, "expired", st.LastPoke.Reason)
+	assert.Equal(t, PokeOutcomeReverted, st.LastPoke.Outcome)
+	assert.Equal(t, testHash1, *st.LastPoke.TxHash)
+
+	s.setPoke(pokeReason(true, true), nil, errors.New("failed"))
+	assert.Equal(t, PokeOutcomeFailed, s.get().LastPoke.Outcome)
+	assert.Equal(t, "expired,stale", s.get().LastPoke.Reason)
+}
@@ -0,0 +1,724 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/rpc"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const (
+	DefaultGasLimitMultiplier    = 1.25
+	DefaultFeeHistoryBlocks      = 10
+	DefaultPriorityFeePercentile = 50
+	DefaultBaseFeeMultiplier     = 2
+	DefaultReplacementInterval   = time.Minute
+	DefaultReplacementFeeBump    = 0.125
+	DefaultMaxReplacements       = 5
+	DefaultReceiptPollInterval   = 5 * time.Second
+)
+
+// DefaultMaxFeePerGas is the default cap for the maximum fee per gas,
+// 2000 gwei.
+var DefaultMaxFeePerGas = new(big.Int).Mul(big.NewInt(2000), big.NewInt(1e9))
+
+// minReplacementFeeBump is the minimum fee bump accepted by most nodes for
+// replacement transactions.
+const minReplacementFeeBump = 0.1
+
+// TxManagerConfig is the configuration for the TxManager.
+type TxManagerConfig struct {
+	// Client is the RPC client used to send transactions. The client must
+	// implement the ethereum.FeeHistoryProvider interface.
+	Client rpc.RPC
+
+	// GasLimitMultiplier is the multiplier applied to the estimated gas.
+	// If zero, DefaultGasLimitMultiplier is used.
+	GasLimitMultiplier float64
+
+	// MaxGasLimit is the maximum gas limit of a transaction. If zero, the
+	// gas limit is not capped.
+	MaxGasLimit uint64
+
+	// FeeHistoryBlocks is the number of recent blocks used to estimate the
+	// priority fee. If zero, DefaultFeeHistoryBlocks is used.
+	FeeHistoryBlocks uint64
+
+	// PriorityFeePercentile is the percentile of priority fees paid in
+	// recent blocks used as the priority fee. If zero,
+	// DefaultPriorityFeePercentile is used.
+	PriorityFeePercentile float64
+
+	// BaseFeeMultiplier is the multiplier applied to the next block base fee
+	// to calculate the maximum fee per gas. It allows a transaction to stay
+	// valid when the base fee increases. If zero, DefaultBaseFeeMultiplier
+	// is used.
+	BaseFeeMultiplier float64
+
+	// MinPriorityFee is the minimum priority fee per gas in wei. If nil,
+	// the minimum is 1 wei.
+	MinPriorityFee *big.Int
+
+	// MaxFeePerGas is the cap for the maximum fee per gas in wei, including
+	// replacements. If nil, DefaultMaxFeePerGas is used.
+	MaxFeePerGas *big.Int
+
+	// ReplacementInterval is the time after which a transaction that was
+	// not mined is replaced with a transaction with higher fees. If zero,
+	// DefaultReplacementInterval is used.
+	ReplacementInterval time.Duration
+
+	// ReplacementFeeBump is the minimum relative fee increase of a
+	// replacement transaction, e.g. 0.125 means 12.5%. If zero,
+	// DefaultReplacementFeeBump is used.
+	ReplacementFeeBump float64
+
+	// MaxReplacements is the maximum number of replacements of a
+	// transaction. If zero, DefaultMaxReplacements is used.
+	MaxReplacements int
+
+	// ReceiptPollInterval is the interval between transaction receipt
+	// checks. If zero, DefaultReceiptPollInterval is used.
+	ReceiptPollInterval time.Duration
+
+	// Logger is a current logger interface used by the TxManager.
+	// If nil, null logger will be used.
+	Logger log.Logger
+}
+
+// TxManager sends transactions to the blockchain.
+//
+// It estimates the gas limit, prices fees using the eth_feeHistory call,
+// assigns nonces tracked locally for every sender and replaces transactions
+// that were not mined in time with transactions with higher fees.
+//
+// The same TxManager should be used for all transactions sent by the same
+// sender, otherwise nonces may collide.
+type TxManager struct {
+	mu      sync.Mutex
+	nonces  map[types.Address]uint64
+	pending map[types.Address]*PendingTx
+	sender  *types.Address
+
+	client                rpc.RPC
+	feeHistory            ethereum.FeeHistoryProvider
+	gasLimitMultiplier    float64
+	maxGasLimit           uint64
+	feeHistoryBlocks      uint64
+	priorityFeePercentile float64
+	baseFeeMultiplier     float64
+	minPriorityFee        *big.Int
+	maxFeePerGas          *big.Int
+	replacementInterval   time.Duration
+	replacementFeeBump    float64
+	maxReplacements       int
+	receiptPollInterval   time.Duration
+	log                   log.Logger
+}
+
+// fees is a pair of EIP-1559 fees.
+type fees struct {
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+}
+
+// NewTxManager creates a new instance of the TxManager.
+func NewTxManager(cfg TxManagerConfig) (*TxManager, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	feeHistory, ok := cfg.Client.(ethereum.FeeHistoryProvider)
+	if !ok {
+		return nil, errors.New("client does not support the eth_feeHistory call")
+	}
+	if cfg.GasLimitMultiplier == 0 {
+		cfg.GasLimitMultiplier = DefaultGasLimitMultiplier
+	}
+	if cfg.FeeHistoryBlocks == 0 {
+		cfg.FeeHistoryBlocks = DefaultFeeHistoryBlocks
+	}
+	if cfg.PriorityFeePercentile == 0 {
+		cfg.PriorityFeePercentile = DefaultPriorityFeePercentile
+	}
+	if cfg.BaseFeeMultiplier == 0 {
+		cfg.BaseFeeMultiplier = DefaultBaseFeeMultiplier
+	}
+	if cfg.MinPriorityFee == nil {
+		cfg.MinPriorityFee = big.NewInt(1)
+	}
+	if cfg.MaxFeePerGas == nil {
+		cfg.MaxFeePerGas = DefaultMaxFeePerGas
+	}
+	if cfg.ReplacementInterval == 0 {
+		cfg.ReplacementInterval = DefaultReplacementInterval
+	}
+	if cfg.ReplacementFeeBump == 0 {
+		cfg.ReplacementFeeBump = DefaultReplacementFeeBump
+	}
+	if cfg.MaxReplacements == 0 {
+		cfg.MaxReplacements = DefaultMaxReplacements
+	}
+	if cfg.ReceiptPollInterval == 0 {
+		cfg.ReceiptPollInterval = DefaultReceiptPollInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	if cfg.GasLimitMultiplier < 1 {
+		return nil, errors.New("gas limit multiplier must be greater than or equal to 1")
+	}
+	if cfg.PriorityFeePercentile < 0 || cfg.PriorityFeePercentile > 100 {
+		return nil, errors.New("priority fee percentile must be between 0 and 100")
+	}
+	if cfg.BaseFeeMultiplier < 1 {
+		return nil, errors.New("base fee multiplier must be greater than or equal to 1")
+	}
+	if cfg.MinPriorityFee.Cmp(cfg.MaxFeePerGas) > 0 {
+		return nil, errors.New("minimum priority fee must not be greater than the maximum fee per gas")
+	}
+	if cfg.ReplacementFeeBump < minReplacementFeeBump {
+		return nil, fmt.Errorf("replacement fee bump must be at least %v", minReplacementFeeBump)
+	}
+	if cfg.MaxReplacements < 0 {
+		return nil, errors.New("maximum number of replacements must not be negative")
+	}
+	return &TxManager{
+		nonces:                make(map[types.Address]uint64),
+		pending:               make(map[types.Address]*PendingTx),
+		client:                cfg.Client,
+		feeHistory:            feeHistory,
+		gasLimitMultiplier:    cfg.GasLimitMultiplier,
+		maxGasLimit:           cfg.MaxGasLimit,
+		feeHistoryBlocks:      cfg.FeeHistoryBlocks,
+		priorityFeePercentile: cfg.PriorityFeePercentile,
+		baseFeeMultiplier:     cfg.BaseFeeMultiplier,
+		minPriorityFee:        cfg.MinPriorityFee,
+		maxFeePerGas:          cfg.MaxFeePerGas,
+		replacementInterval:   cfg.ReplacementInterval,
+		replacementFeeBump:    cfg.ReplacementFeeBump,
+		maxReplacements:       cfg.MaxReplacements,
+		receiptPollInterval:   cfg.ReceiptPollInterval,
+		log:                   cfg.Logger.WithField("tag", LoggerTag),
+	}, nil
+}
+
+// PendingTx is a transaction sent by the TxManager. It is tracked in the
+// background until it is mined or dropped.
+type PendingTx struct {
+	mu      sync.Mutex
+	hash    types.Hash
+	doneCh  chan struct{}
+	receipt *types.TransactionReceipt
+	err     error
+}
+
+// Hash returns the hash of the last sent version of the transaction.
+func (p *PendingTx) Hash() types.Hash {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.hash
+}
+
+// Done returns a channel that is closed when the transaction is mined,
+// dropped or its tracking is stopped.
+func (p *PendingTx) Done() <-chan struct{} {
+	return p.doneCh
+}
+
+// Wait waits until the transaction is mined or dropped and returns the
+// receipt of the mined transaction. If the transaction was reverted, both
+// the receipt and an error are returned.
+func (p *PendingTx) Wait(ctx context.Context) (*types.TransactionReceipt, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.doneCh:
+		return p.receipt, p.err
+	}
+}
+
+func (p *PendingTx) setHash(hash types.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hash = hash
+}
+
+func (p *PendingTx) finish(receipt *types.TransactionReceipt, err error) {
+	p.receipt = receipt
+	p.err = err
+	close(p.doneCh)
+}
+
+// Send sends the transaction and returns without waiting until it is mined.
+//
+// The gas limit, fees and nonce of the transaction are set by the
+// TxManager. The transaction is tracked in the background until it is mined,
+// dropped or the context is canceled. If the transaction is not mined within
+// the replacement interval, it is replaced with a transaction with higher
+// fees. The nonce is not reused until the transaction is mined or dropped.
+func (m *TxManager) Send(ctx context.Context, tx types.Transaction) (*PendingTx, error) {
+	sender, err := m.senderAddress(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("tx manager: unable to determine sender: %w", err)
+	}
+	tx.SetFrom(sender)
+	tx.SetType(types.DynamicFeeTxType)
+
+	gasLimit, err := m.estimateGas(ctx, tx.Call)
+	if err != nil {
+		return nil, fmt.Errorf("tx manager: gas estimation failed: %w", err)
+	}
+	tx.SetGasLimit(gasLimit)
+
+	f, err := m.suggestFees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("tx manager: fee estimation failed: %w", err)
+	}
+	tx.SetMaxFeePerGas(f.maxFeePerGas)
+	tx.SetMaxPriorityFeePerGas(f.maxPriorityFeePerGas)
+
+	nonce, err := m.nextNonce(ctx, sender)
+	if err != nil {
+		return nil, fmt.Errorf("tx manager: unable to get nonce: %w", err)
+	}
+	tx.SetNonce(nonce)
+
+	hash, err := m.client.SendTransaction(ctx, tx)
+	if err != nil {
+		m.releaseNonce(sender, nonce)
+		return nil, fmt.Errorf("tx manager: unable to send transaction: %w", err)
+	}
+	m.log.
+		WithFields(txLogFields(tx, *hash)).
+		Info("Transaction sent")
+	p := &PendingTx{hash: *hash, doneCh: make(chan struct{})}
+	m.setPending(tx.To, p)
+	go m.trackRoutine(ctx, sender, tx, p)
+	return p, nil
+}
+
+// trackRoutine waits until the transaction is mined or dropped and replaces
+// it with higher fees if it is not mined in time.
+func (m *TxManager) trackRoutine(ctx context.Context, sender types.Address, tx types.Transaction, p *PendingTx) {
+	receipt, err := m.track(ctx, sender, tx, p)
+	m.clearPending(tx.To, p)
+	p.finish(receipt, err)
+}
+
+func (m *TxManager) track(ctx context.Context, sender types.Address, tx types.Transaction, p *PendingTx) (*types.TransactionReceipt, error) {
+	var (
+		hashes       = []types.Hash{p.Hash()}
+		sentAt       = time.Now()
+		replacements = 0
+		poll         = time.NewTicker(m.receiptPollInterval)
+	)
+	defer poll.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-poll.C:
+		}
+		receipt, err := m.receipt(ctx, hashes)
+		if err != nil {
+			m.log.
+				WithError(err).
+				WithFields(txLogFields(tx, hashes[len(hashes)-1])).
+				Warn("Unable to get transaction receipt")
+			continue
+		}
+		if receipt != nil {
+			if receipt.Status != nil && *receipt.Status == 0 {
+				return receipt, fmt.Errorf("tx manager: transaction %s reverted", receipt.TransactionHash)
+			}
+			return receipt, nil
+		}
+		if time.Since(sentAt) < m.replacementInterval {
+			continue
+		}
+		sentAt = time.Now()
+		if replacements >= m.maxReplacements {
+			// No more replacements are sent, but the transaction may still
+			// be mined, so the nonce is kept until the node drops it.
+			dropped, err := m.dropped(ctx, hashes)
+			if err != nil {
+				m.log.
+					WithError(err).
+					WithFields(txLogFields(tx, hashes[len(hashes)-1])).
+					Warn("Unable to check transaction status")
+				continue
+			}
+			if !dropped {
+				continue
+			}
+			// The local nonce must be synchronized with the node on the
+			// next call.
+			m.resetNonce(sender)
+			return nil, fmt.Errorf(
+				"tx manager: transaction %s was dropped after %d replacements",
+				hashes[len(hashes)-1],
+				replacements,
+			)
+		}
+		replacements++
+		replacement, err := m.bumpFees(ctx, tx)
+		if err != nil {
+			m.log.
+				WithError(err).
+				WithFields(txLogFields(tx, hashes[len(hashes)-1])).
+				Warn("Unable to replace transaction")
+			continue
+		}
+		hash, err := m.client.SendTransaction(ctx, replacement)
+		if err != nil {
+			m.log.
+				WithError(err).
+				WithFields(txLogFields(replacement, hashes[len(hashes)-1])).
+				Warn("Unable to replace transaction")
+			continue
+		}
+		tx = replacement
+		hashes = append(hashes, *hash)
+		p.setHash(*hash)
+		m.log.
+			WithFields(txLogFields(tx, *hash)).
+			WithField("replacement", replacements).
+			Info("Transaction replaced")
+	}
+}
+
+// EstimateGas returns the gas limit that Send would use for the
+// transaction, without sending it.
+func (m *TxManager) EstimateGas(ctx context.Context, tx types.Transaction) (uint64, error) {
+	sender, err := m.senderAddress(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("tx manager: unable to determine sender: %w", err)
+	}
+	tx.SetFrom(sender)
+	gasLimit, err := m.estimateGas(ctx, tx.Call)
+	if err != nil {
+		return 0, fmt.Errorf("tx manager: gas estimation failed: %w", err)
+	}
+	return gasLimit, nil
+}
+
+// PendingTransaction returns the hash of the last sent transaction to the
+// given address that is not mined or dropped yet.
+func (m *TxManager) PendingTransaction(to types.Address) (types.Hash, bool) {
+	m.mu.Lock()
+	p, ok := m.pending[to]
+	m.mu.Unlock()
+	if !ok {
+		return types.Hash{}, false
+	}
+	return p.Hash(), true
+}
+
+func (m *TxManager) setPending(to *types.Address, p *PendingTx) {
+	if to == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[*to] = p
+}
+
+func (m *TxManager) clearPending(to *types.Address, p *PendingTx) {
+	if to == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending[*to] == p {
+		delete(m.pending, *to)
+	}
+}
+
+// senderAddress returns the address of the transaction sender. If the
+// transaction does not specify it, the first account of the client is used.
+func (m *TxManager) senderAddress(ctx context.Context, tx types.Transaction) (types.Address, error) {
+	if tx.From != nil {
+		return *tx.From, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sender != nil {
+		return *m.sender, nil
+	}
+	accounts, err := m.client.Accounts(ctx)
+	if err != nil {
+		return types.Address{}, err
+	}
+	if len(accounts) == 0 {
+		return types.Address{}, errors.New("client has no accounts")
+	}
+	m.sender = &accounts[0]
+	return accounts[0], nil
+}
+
+// estimateGas estimates the gas limit of the call.
+func (m *TxManager) estimateGas(ctx context.Context, call types.Call) (uint64, error) {
+	call.GasLimit = nil
+	call.GasPrice = nil
+	call.MaxFeePerGas = nil
+	call.MaxPriorityFeePerGas = nil
+	gas, err := m.client.EstimateGas(ctx, call, types.LatestBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	if m.maxGasLimit > 0 && gas > m.maxGasLimit {
+		return 0, fmt.Errorf("estimated gas %d exceeds the gas limit %d", gas, m.maxGasLimit)
+	}
+	limit := uint64(float64(gas) * m.gasLimitMultiplier)
+	if m.maxGasLimit > 0 && limit > m.maxGasLimit {
+		limit = m.maxGasLimit
+	}
+	return limit, nil
+}
+
+// suggestFees calculates fees using the fee history of recent blocks.
+//
+// The priority fee is the median of the configured percentile of priority
+// fees paid in recent blocks. The maximum fee is the next block base fee
+// multiplied by the base fee multiplier, plus the priority fee.
+func (m *TxManager) suggestFees(ctx context.Context) (fees, error) {
+	h, err := m.feeHistory.FeeHistory(
+		ctx,
+		m.feeHistoryBlocks,
+		types.LatestBlockNumber,
+		[]float64{m.priorityFeePercentile},
+	)
+	if err != nil {
+		return fees{}, err
+	}
+	if len(h.BaseFeePerGas) == 0 {
+		return fees{}, errors.New("fee history does not contain base fees")
+	}
+
+	// The last base fee in the fee history is the base fee of the next
+	// block.
+	baseFee := h.BaseFeePerGas[len(h.BaseFeePerGas)-1]
+
+	var rewards []*big.Int
+	for _, r := range h.Reward {
+		if len(r) > 0 && r[0] != nil {
+			rewards = append(rewards, r[0])
+		}
+	}
+	tip := medianBigInt(rewards)
+	if tip == nil || tip.Cmp(m.minPriorityFee) < 0 {
+		tip = new(big.Int).Set(m.minPriorityFee)
+	}
+
+	maxFee := mulBigInt(baseFee, m.baseFeeMultiplier)
+	maxFee.Add(maxFee, tip)
+	if maxFee.Cmp(m.maxFeePerGas) > 0 {
+		maxFee = new(big.Int).Set(m.maxFeePerGas)
+	}
+	if maxFee.Cmp(baseFee) < 0 {
+		return fees{}, fmt.Errorf("base fee %s exceeds the maximum fee per gas %s", baseFee, m.maxFeePerGas)
+	}
+	if tip.Cmp(maxFee) > 0 {
+		tip = new(big.Int).Set(maxFee)
+	}
+	return fees{maxFeePerGas: maxFee, maxPriorityFeePerGas: tip}, nil
+}
+
+// bumpFees returns a copy of the transaction with fees increased by at
+// least the replacement fee bump, or to the current suggested fees if they
+// are higher. If the maximum fee per gas does not allow to increase fees
+// enough for the replacement to be accepted by nodes, an error is returned.
+func (m *TxManager) bumpFees(ctx context.Context, tx types.Transaction) (types.Transaction, error) {
+	maxFee := mulBigInt(tx.MaxFeePerGas, 1+m.replacementFeeBump)
+	maxFee.Add(maxFee, big.NewInt(1))
+	tip := mulBigInt(tx.MaxPriorityFeePerGas, 1+m.replacementFeeBump)
+	tip.Add(tip, big.NewInt(1))
+	if f, err := m.suggestFees(ctx); err == nil {
+		if f.maxFeePerGas.Cmp(maxFee) > 0 {
+			maxFee = f.maxFeePerGas
+		}
+		if f.maxPriorityFeePerGas.Cmp(tip) > 0 {
+			tip = f.maxPriorityFeePerGas
+		}
+	}
+	if maxFee.Cmp(m.maxFeePerGas) > 0 {
+		maxFee = new(big.Int).Set(m.maxFeePerGas)
+	}
+	if tip.Cmp(maxFee) > 0 {
+		tip = new(big.Int).Set(maxFee)
+	}
+	// Nodes reject replacements that do not increase both fees by at least
+	// minReplacementFeeBump, so there is no point in sending them.
+	if maxFee.Cmp(minReplacementFee(tx.MaxFeePerGas)) < 0 || tip.Cmp(minReplacementFee(tx.MaxPriorityFeePerGas)) < 0 {
+		return types.Transaction{}, fmt.Errorf("maximum fee per gas %s reached", m.maxFeePerGas)
+	}
+	tx.SetMaxFeePerGas(maxFee)
+	tx.SetMaxPriorityFeePerGas(tip)
+	tx.Signature = nil
+	return tx, nil
+}
+
+// receipt returns the receipt of the first mined transaction from the
+// given list. If none of the transactions is mined, nil is returned.
+func (m *TxManager) receipt(ctx context.Context, hashes []types.Hash) (*types.TransactionReceipt, error) {
+	var lastErr error
+	for _, hash := range hashes {
+		r, err := m.client.GetTransactionReceipt(ctx, hash)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if r != nil && r.TransactionHash != (types.Hash{}) {
+			return r, nil
+		}
+	}
+	return nil, lastErr
+}
+
+// dropped reports whether none of the versions of the transaction is known
+// to the node anymore, so it cannot be mined.
+func (m *TxManager) dropped(ctx context.Context, hashes []types.Hash) (bool, error) {
+	for _, hash := range hashes {
+		tx, err := m.client.GetTransactionByHash(ctx, hash)
+		if err != nil {
+			return false, err
+		}
+		if tx != nil && tx.Hash != nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// nextNonce returns the next nonce for the sender.
+//
+// The nonce is the higher of the locally tracked nonce and the pending
+// transaction count reported by the node, so transactions sent in quick
+// succession do not reuse nonces even if the node lags behind.
+func (m *TxManager) nextNonce(ctx context.Context, sender types.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nonce, err := m.client.GetTransactionCount(ctx, sender, types.PendingBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+	if local, ok := m.nonces[sender]; ok && local > nonce {
+		nonce = local
+	}
+	m.nonces[sender] = nonce + 1
+	return nonce, nil
+}
+
+// releaseNonce releases the nonce of a transaction that was not sent, so it
+// can be used by the next transaction.
+func (m *TxManager) releaseNonce(sender types.Address, nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.nonces[sender] == nonce+1 {
+		m.nonces[sender] = nonce
+	}
+}
+
+// resetNonce removes the locally tracked nonce of the sender, so the next
+// nonce is taken from the node.
+func (m *TxManager) resetNonce(sender types.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.nonces, sender)
+}
+
+// receiptLogFields returns log fields describing the transaction receipt.
+func receiptLogFields(r *types.TransactionReceipt) log.Fields {
+	f := log.Fields{
+		"txHash":  r.TransactionHash.String(),
+		"gasUsed": r.GasUsed,
+	}
+	if r.BlockNumber != nil {
+		f["blockNumber"] = r.BlockNumber.String()
+	}
+	if r.EffectiveGasPrice != nil {
+		f["effectiveGasPrice"] = r.EffectiveGasPrice.String()
+	}
+	if r.Status != nil {
+		f["status"] = *r.Status
+	}
+	return f
+}
+
+func txLogFields(tx types.Transaction, hash types.Hash) log.Fields {
+	f := log.Fields{"txHash": hash.String()}
+	if tx.From != nil {
+		f["from"] = tx.From.String()
+	}
+	if tx.To != nil {
+		f["to"] = tx.To.String()
+	}
+	if tx.Nonce != nil {
+		f["nonce"] = *tx.Nonce
+	}
+	if tx.GasLimit != nil {
+		f["gasLimit"] = *tx.GasLimit
+	}
+	if tx.MaxFeePerGas != nil {
+		f["maxFeePerGas"] = tx.MaxFeePerGas.String()
+	}
+	if tx.MaxPriorityFeePerGas != nil {
+		f["maxPriorityFeePerGas"] = tx.MaxPriorityFeePerGas.String()
+	}
+	return f
+}
+
+// minReplacementFee returns the minimum fee of a transaction replacing
+// a transaction with the fee x.
+func minReplacementFee(x *big.Int) *big.Int {
+	r := mulBigInt(x, 1+minReplacementFeeBump)
+	return r.Add(r, big.NewInt(1))
+}
+
+// mulBigInt multiplies x by a float factor, rounding down.
+func mulBigInt(x *big.Int, f float64) *big.Int {
+	r, _ := new(big.Float).Mul(new(big.Float).SetInt(x), big.NewFloat(f)).Int(nil)
+	return r
+}
+
+// medianBigInt returns the median of xs, or nil if xs is empty.
+func medianBigInt(xs []*big.Int) *big.Int {
+	if len(xs) == 0 {
+		return nil
+	}
+	s := make([]*big.Int, len(xs))
+	copy(s, xs)
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Cmp(s[j]) < 0
+	})
+	if len(s)%2 == 0 {
+		m := new(big.Int).Add(s[len(s)/2-1], s[len(s)/2])
+		return m.Div(m, big.NewInt(2))
+	}
+	return new(big.Int).Set(s[len(s)/2])
+}
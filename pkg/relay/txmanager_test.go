@@ -0,0 +1,313 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+var (
+	testSender   = types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+	testContract = types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+	testHash1    = types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone)
+	testHash2    = types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone)
+)
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e9))
+}
+
+func testFeeHistory() *types.FeeHistory {
+	return &types.FeeHistory{
+		BaseFeePerGas: []*big.Int{gwei(90), gwei(95), gwei(100)},
+		Reward:        [][]*big.Int{{gwei(3)}, {gwei(1)}},
+	}
+}
+
+func testTx() types.Transaction {
+	return *(&types.Transaction{}).SetTo(testContract).SetInput([]byte{1, 2, 3})
+}
+
+func minedReceipt(hash types.Hash, status uint64) *types.TransactionReceipt {
+	return &types.TransactionReceipt{
+		TransactionHash: hash,
+		BlockNumber:     big.NewInt(42),
+		GasUsed:         21000,
+		Status:          &status,
+	}
+}
+
+func TestTxManager_suggestFees(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxFee     *big.Int
+		history    *types.FeeHistory
+		wantMaxFee *big.Int
+		wantTip    *big.Int
+		wantErr    bool
+	}{
+		{
+			name:       "fee history",
+			history:    testFeeHistory(),
+			wantMaxFee: gwei(202),
+			wantTip:    gwei(2),
+		},
+		{
+			name:       "capped",
+			maxFee:     gwei(150),
+			history:    testFeeHistory(),
+			wantMaxFee: gwei(150),
+			wantTip:    gwei(2),
+		},
+		{
+			name:       "no rewards",
+			history:    &types.FeeHistory{BaseFeePerGas: []*big.Int{gwei(100)}},
+			wantMaxFee: new(big.Int).Add(gwei(200), big.NewInt(1)),
+			wantTip:    big.NewInt(1),
+		},
+		{
+			name:    "base fee above cap",
+			maxFee:  gwei(50),
+			history: testFeeHistory(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cli := &mocks.RPC{}
+			m, err := NewTxManager(TxManagerConfig{Client: cli, MaxFeePerGas: tt.maxFee})
+			require.NoError(t, err)
+
+			cli.On("FeeHistory", ctx, uint64(DefaultFeeHistoryBlocks), types.LatestBlockNumber, []float64{DefaultPriorityFeePercentile}).Return(tt.history, nil)
+
+			f, err := m.suggestFees(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMaxFee.String(), f.maxFeePerGas.String())
+			assert.Equal(t, tt.wantTip.String(), f.maxPriorityFeePerGas.String())
+		})
+	}
+}
+
+func TestTxManager_Send(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m, err := NewTxManager(TxManagerConfig{
+		Client:              cli,
+		ReceiptPollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	cli.On("Accounts", ctx).Return([]types.Address{testSender}, nil)
+	cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+	cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+	cli.On("GetTransactionCount", ctx, testSender, types.PendingBlockNumber).Return(uint64(5), nil)
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash1, nil)
+	cli.On("GetTransactionReceipt", ctx, testHash1).Return(minedReceipt(testHash1, 1), nil)
+
+	// The first transaction uses the nonce reported by the node.
+	ptx, err := m.Send(ctx, testTx())
+	require.NoError(t, err)
+	receipt, err := ptx.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testHash1, receipt.TransactionHash)
+
+	// Mined transactions are no longer pending.
+	_, ok := m.PendingTransaction(testContract)
+	assert.False(t, ok)
+
+	// The second transaction uses the locally tracked nonce, because the
+	// node still reports the same pending transaction count.
+	ptx, err = m.Send(ctx, testTx())
+	require.NoError(t, err)
+	_, err = ptx.Wait(ctx)
+	require.NoError(t, err)
+
+	var sent []types.Transaction
+	for _, c := range cli.Calls {
+		if c.Method == "SendTransaction" {
+			sent = append(sent, c.Arguments.Get(1).(types.Transaction))
+		}
+	}
+	require.Len(t, sent, 2)
+	assert.Equal(t, uint64(5), *sent[0].Nonce)
+	assert.Equal(t, uint64(6), *sent[1].Nonce)
+	assert.Equal(t, testSender, *sent[0].From)
+	assert.Equal(t, uint64(125000), *sent[0].GasLimit)
+	assert.Equal(t, gwei(202).String(), sent[0].MaxFeePerGas.String())
+	assert.Equal(t, gwei(2).String(), sent[0].MaxPriorityFeePerGas.String())
+}
+
+func TestTxManager_Send_Reverted(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m, err := NewTxManager(TxManagerConfig{
+		Client:              cli,
+		ReceiptPollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+	cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+	cli.On("GetTransactionCount", ctx, testSender, types.PendingBlockNumber).Return(uint64(5), nil)
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash1, nil)
+	cli.On("GetTransactionReceipt", ctx, testHash1).Return(minedReceipt(testHash1, 0), nil)
+
+	tx := testTx()
+	tx.SetFrom(testSender)
+	ptx, err := m.Send(ctx, tx)
+	require.NoError(t, err)
+	receipt, err := ptx.Wait(ctx)
+	assert.Error(t, err)
+	require.NotNil(t, receipt)
+	assert.Equal(t, uint64(0), *receipt.Status)
+}
+
+func TestTxManager_Send_Replacement(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m, err := NewTxManager(TxManagerConfig{
+		Client:              cli,
+		ReplacementInterval: 10 * time.Millisecond,
+		ReceiptPollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	cli.On("Accounts", ctx).Return([]types.Address{testSender}, nil)
+	cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+	cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+	cli.On("GetTransactionCount", ctx, testSender, types.PendingBlockNumber).Return(uint64(5), nil)
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash1, nil).Once()
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash2, nil).Once()
+	cli.On("GetTransactionReceipt", ctx, testHash1).Return(&types.TransactionReceipt{}, nil)
+	cli.On("GetTransactionReceipt", ctx, testHash2).Return(minedReceipt(testHash2, 1), nil)
+
+	ptx, err := m.Send(ctx, testTx())
+	require.NoError(t, err)
+	receipt, err := ptx.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testHash2, receipt.TransactionHash)
+
+	var sent []types.Transaction
+	for _, c := range cli.Calls {
+		if c.Method == "SendTransaction" {
+			sent = append(sent, c.Arguments.Get(1).(types.Transaction))
+		}
+	}
+	require.Len(t, sent, 2)
+
+	// The replacement must use the same nonce and fees higher by at least
+	// the replacement fee bump.
+	assert.Equal(t, *sent[0].Nonce, *sent[1].Nonce)
+	minMaxFee := mulBigInt(sent[0].MaxFeePerGas, 1+DefaultReplacementFeeBump)
+	minTip := mulBigInt(sent[0].MaxPriorityFeePerGas, 1+DefaultReplacementFeeBump)
+	assert.True(t, sent[1].MaxFeePerGas.Cmp(minMaxFee) > 0)
+	assert.True(t, sent[1].MaxPriorityFeePerGas.Cmp(minTip) > 0)
+}
+
+func TestTxManager_Send_Dropped(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.RPC{}
+	m, err := NewTxManager(TxManagerConfig{
+		Client:              cli,
+		ReplacementInterval: 10 * time.Millisecond,
+		ReceiptPollInterval: time.Millisecond,
+		MaxReplacements:     1,
+	})
+	require.NoError(t, err)
+
+	cli.On("Accounts", ctx).Return([]types.Address{testSender}, nil)
+	cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+	cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+	cli.On("GetTransactionCount", ctx, testSender, types.PendingBlockNumber).Return(uint64(5), nil)
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash1, nil).Once()
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash2, nil).Once()
+	cli.On("GetTransactionReceipt", ctx, mock.Anything).Return(&types.TransactionReceipt{}, nil)
+
+	// The node knows the transaction during the first check after the last
+	// replacement and drops it before the second one.
+	cli.On("GetTransactionByHash", ctx, testHash1).Return(&types.OnChainTransaction{Hash: &testHash1}, nil).Once()
+	cli.On("GetTransactionByHash", ctx, mock.Anything).Return(&types.OnChainTransaction{}, nil)
+
+	ptx, err := m.Send(ctx, testTx())
+	require.NoError(t, err)
+	_, err = ptx.Wait(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, testHash2, ptx.Hash())
+
+	// The nonce was kept while the node knew the transaction and released
+	// once it was dropped.
+	cli.AssertNumberOfCalls(t, "GetTransactionByHash", 3)
+	_, ok := m.PendingTransaction(testContract)
+	assert.False(t, ok)
+	m.mu.Lock()
+	assert.NotContains(t, m.nonces, testSender)
+	m.mu.Unlock()
+}
+
+func TestTxManager_bumpFees(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxFee  *big.Int
+		tx      types.Transaction
+		wantErr bool
+	}{
+		{
+			name:   "bumped",
+			maxFee: gwei(1000),
+			tx:     *(&types.Transaction{}).SetMaxFeePerGas(gwei(300)).SetMaxPriorityFeePerGas(gwei(2)),
+		},
+		{
+			name:    "capped below minimum bump",
+			maxFee:  gwei(320),
+			tx:      *(&types.Transaction{}).SetMaxFeePerGas(gwei(300)).SetMaxPriorityFeePerGas(gwei(2)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cli := &mocks.RPC{}
+			m, err := NewTxManager(TxManagerConfig{Client: cli, MaxFeePerGas: tt.maxFee})
+			require.NoError(t, err)
+
+			cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+
+			tx, err := m.bumpFees(ctx, tt.tx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tx.MaxFeePerGas.Cmp(mulBigInt(tt.tx.MaxFeePerGas, 1+minReplacementFeeBump)) > 0)
+			assert.True(t, tx.MaxPriorityFeePerGas.Cmp(mulBigInt(tt.tx.MaxPriorityFeePerGas, 1+minReplacementFeeBump)) > 0)
+		})
+	}
+}
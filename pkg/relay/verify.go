@@ -0,0 +1,186 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// errUnknownPublicKey is returned when a signature cannot be verified
+// because the public key of one of the signers is not known.
+var errUnknownPublicKey = errors.New("unknown public key")
+
+// FeedKeyProvider provides Schnorr public keys of feeds.
+type FeedKeyProvider interface {
+	PublicKey(feed types.Address) (schnorr.PublicKey, bool)
+}
+
+// muSigPokeData returns the poke data signed in the MuSig session.
+func muSigPokeData(sig *messages.MuSigSignature) (contract.PokeData, error) {
+	val, ok := sig.MsgMeta["val"]
+	if !ok {
+		return contract.PokeData{}, errors.New("missing val in message metadata")
+	}
+	age, ok := sig.MsgMeta["age"]
+	if !ok {
+		return contract.PokeData{}, errors.New("missing age in message metadata")
+	}
+	return contract.PokeData{
+		Val: bn.DecFixedPointFromRawBigInt(new(big.Int).SetBytes(val), contract.ScribePricePrecision),
+		Age: time.Unix(new(big.Int).SetBytes(age).Int64(), 0),
+	}, nil
+}
+
+// signersBlob returns the signersBlob expected by the Scribe contract, which
+// is the list of feed indices of the signers.
+//
+// The feeds and indices are the feed list returned by the contract.
+func signersBlob(signers []types.Address, feeds []types.Address, indices []uint8) ([]byte, error) {
+	blob := make([]byte, len(signers))
+	for i, signer := range signers {
+		found := false
+		for j, feed := range feeds {
+			if feed == signer && j < len(indices) {
+				blob[i] = indices[j]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("signer %s is not a feed", signer)
+		}
+	}
+	return blob, nil
+}
+
+// verifyMuSigSignature verifies the Schnorr signature of the MuSig session
+// against the public keys of its signers.
+func verifyMuSigSignature(sig *messages.MuSigSignature, keys FeedKeyProvider) error {
+	wat, ok := sig.MsgMeta["wat"]
+	if !ok {
+		return errors.New("missing wat in message metadata")
+	}
+	pokeData, err := muSigPokeData(sig)
+	if err != nil {
+		return err
+	}
+	return verifySchnorrSignature(
+		string(wat),
+		pokeData,
+		sig.SchnorrSignature,
+		sig.Commitment,
+		sig.Signers,
+		keys,
+	)
+}
+
+// verifyScribeSignature verifies the Schnorr signature of the poke data the
+// same way as the Scribe contract does.
+//
+// The feeds and indices are the feed list returned by the contract, and bar
+// is the required number of signers.
+func verifyScribeSignature(
+	wat string,
+	pokeData contract.PokeData,
+	schnorrData contract.SchnorrData,
+	bar int,
+	feeds []types.Address,
+	indices []uint8,
+	keys FeedKeyProvider,
+) error {
+	if len(schnorrData.SignersBlob) != bar {
+		return fmt.Errorf("invalid number of signers: %d, expected %d", len(schnorrData.SignersBlob), bar)
+	}
+	signers, err := blobSigners(schnorrData.SignersBlob, feeds, indices)
+	if err != nil {
+		return err
+	}
+	return verifySchnorrSignature(
+		wat,
+		pokeData,
+		schnorrData.Signature,
+		schnorrData.Commitment,
+		signers,
+		keys,
+	)
+}
+
+// blobSigners returns the addresses of the feeds listed in the signersBlob.
+// It is the reverse of signersBlob.
+func blobSigners(blob []byte, feeds []types.Address, indices []uint8) ([]types.Address, error) {
+	feedByIndex := make(map[uint8]types.Address, len(feeds))
+	for i, feed := range feeds {
+		if i < len(indices) {
+			feedByIndex[indices[i]] = feed
+		}
+	}
+	signers := make([]types.Address, 0, len(blob))
+	for _, idx := range blob {
+		feed, ok := feedByIndex[idx]
+		if !ok {
+			return nil, fmt.Errorf("signer %d is not a feed", idx)
+		}
+		signers = append(signers, feed)
+	}
+	return signers, nil
+}
+
+// verifySchnorrSignature verifies the Schnorr signature of the poke data
+// against the aggregated public key of the signers.
+func verifySchnorrSignature(
+	wat string,
+	pokeData contract.PokeData,
+	signature *big.Int,
+	commitment types.Address,
+	signers []types.Address,
+	keys FeedKeyProvider,
+) error {
+	if len(signers) == 0 {
+		return errors.New("no signers")
+	}
+	seen := make(map[types.Address]bool, len(signers))
+	pubKeys := make([]schnorr.PublicKey, 0, len(signers))
+	for _, signer := range signers {
+		if seen[signer] {
+			return fmt.Errorf("duplicated signer %s", signer)
+		}
+		seen[signer] = true
+		pubKey, ok := keys.PublicKey(signer)
+		if !ok {
+			return fmt.Errorf("%w of the feed %s", errUnknownPublicKey, signer)
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	aggPubKey, err := schnorr.AggregatePublicKeys(pubKeys)
+	if err != nil {
+		return err
+	}
+	msg := contract.ConstructPokeMessage(wat, pokeData)
+	if !schnorr.Verify(aggPubKey, msg, signature, commitment) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+// testMuSigSignature returns a MuSig signature of the ETH/USD poke data
+// signed by the given private keys.
+func testMuSigSignature(privs []int64) *messages.MuSigSignature {
+	pokeData := contract.PokeData{
+		Val: bn.DecFixedPoint(1500, contract.ScribePricePrecision),
+		Age: time.Unix(1234567890, 0),
+	}
+	sig, commitment := testSchnorrSign(privs, 42, contract.ConstructPokeMessage("ETH/USD", pokeData))
+	var signers []types.Address
+	for _, p := range privs {
+		signers = append(signers, testSchnorrKey(p).Address())
+	}
+	return &messages.MuSigSignature{
+		ComputedAt: time.Now(),
+		MsgMeta: map[string][]byte{
+			"wat": []byte("ETH/USD"),
+			"val": pokeData.Val.RawBigInt().Bytes(),
+			"age": big.NewInt(pokeData.Age.Unix()).Bytes(),
+		},
+		Commitment:       commitment,
+		Signers:          signers,
+		SchnorrSignature: sig,
+	}
+}
+
+func testKeys(privs []int64) testFeedKeys {
+	keys := testFeedKeys{}
+	for _, p := range privs {
+		keys[testSchnorrKey(p).Address()] = testSchnorrKey(p)
+	}
+	return keys
+}
+
+func TestSignersBlob(t *testing.T) {
+	feeds := []types.Address{
+		types.MustAddressFromHex("0x1111111111111111111111111111111111111111"),
+		types.MustAddressFromHex("0x2222222222222222222222222222222222222222"),
+		types.MustAddressFromHex("0x3333333333333333333333333333333333333333"),
+	}
+	indices := []uint8{3, 7, 9}
+
+	blob, err := signersBlob([]types.Address{feeds[2], feeds[0]}, feeds, indices)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{9, 3}, blob)
+
+	_, err = signersBlob([]types.Address{feeds[1], types.MustAddressFromHex("0x4444444444444444444444444444444444444444")}, feeds, indices)
+	assert.Error(t, err)
+}
+
+func TestVerifyMuSigSignature(t *testing.T) {
+	privs := []int64{1111, 2222}
+	keys := testKeys(privs)
+
+	tests := []struct {
+		name    string
+		modify  func(sig *messages.MuSigSignature)
+		wantErr bool
+		unknown bool
+	}{
+		{
+			name:   "valid",
+			modify: func(sig *messages.MuSigSignature) {},
+		},
+		{
+			name: "invalid signature",
+			modify: func(sig *messages.MuSigSignature) {
+				sig.SchnorrSignature = new(big.Int).Add(sig.SchnorrSignature, big.NewInt(1))
+			},
+			wantErr: true,
+		},
+		{
+			name: "different value",
+			modify: func(sig *messages.MuSigSignature) {
+				sig.MsgMeta["val"] = big.NewInt(1).Bytes()
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing signer",
+			modify: func(sig *messages.MuSigSignature) {
+				sig.Signers = sig.Signers[:1]
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicated signer",
+			modify: func(sig *messages.MuSigSignature) {
+				sig.Signers = []types.Address{sig.Signers[0], sig.Signers[0]}
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing metadata",
+			modify: func(sig *messages.MuSigSignature) {
+				delete(sig.MsgMeta, "age")
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown signer",
+			modify: func(sig *messages.MuSigSignature) {
+				sig.Signers = append(sig.Signers, testSchnorrKey(3333).Address())
+			},
+			wantErr: true,
+			unknown: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := testMuSigSignature(privs)
+			tt.modify(sig)
+			err := verifyMuSigSignature(sig, keys)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.unknown, errors.Is(err, errUnknownPublicKey))
+		})
+	}
+}
+
+func TestMuSigStore_dropsInvalidSignatures(t *testing.T) {
+	privs := []int64{1111, 2222}
+	store := NewMuSigStore(MuSigStoreConfig{
+		ScribeDataModels: []string{"ETH/USD"},
+		FeedKeys:         testKeys(privs[:1]),
+		Logger:           null.New(),
+	})
+	author := testSchnorrKey(privs[0]).Address().Bytes()
+
+	// Signatures of signers with unknown keys are dropped.
+	store.handleSignatureMessage(transport.ReceivedMessage{Message: testMuSigSignature(privs), Author: author})
+	assert.Empty(t, store.SignaturesByDataModel("ETH/USD"))
+
+	store.feedKeys = testKeys(privs)
+	store.handleSignatureMessage(transport.ReceivedMessage{Message: testMuSigSignature(privs), Author: author})
+	assert.Len(t, store.SignaturesByDataModel("ETH/USD"), 1)
+
+	invalid := testMuSigSignature(privs)
+	invalid.ComputedAt = time.Now().Add(time.Minute)
+	invalid.SchnorrSignature = big.NewInt(1)
+	store.handleSignatureMessage(transport.ReceivedMessage{Message: invalid, Author: author})
+	sigs := store.SignaturesByDataModel("ETH/USD")
+	require.Len(t, sigs, 1)
+	assert.NotEqual(t, invalid.SchnorrSignature, sigs[0].SchnorrSignature)
+}
@@ -0,0 +1,331 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/defiweb/go-eth/hexutil"
+	"github.com/defiweb/go-eth/rpc"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/timeutil"
+)
+
+// DefaultWatchtowerLookbackBlocks is the default number of recent blocks
+// searched for optimistic updates.
+const DefaultWatchtowerLookbackBlocks = 1000
+
+type WatchtowerContract interface {
+	Wat(ctx context.Context) (string, error)
+	BarAt(ctx context.Context, block uint64) (int, error)
+	FeedsAt(ctx context.Context, block uint64) ([]types.Address, []uint8, error)
+	LiftedPublicKey(ctx context.Context, feed types.Address, toBlock uint64) (schnorr.PublicKey, error)
+	OpChallengePeriod(ctx context.Context) (time.Duration, error)
+	OpPokedEvents(ctx context.Context, fromBlock, toBlock uint64) ([]contract.OpPokedEvent, error)
+	OpChallenge(ctx context.Context, schnorrData contract.SchnorrData) (*types.Transaction, error)
+}
+
+// watchtowerWorker watches optimistic updates of the OpScribe contract and
+// challenges the ones with an invalid Schnorr signature before the end of
+// the challenge period.
+type watchtowerWorker struct {
+	log       log.Logger
+	client    rpc.RPC
+	contract  WatchtowerContract
+	txManager *TxManager
+	feedKeys  FeedKeyProvider
+	address   types.Address
+	lookback  uint64
+	ticker    *timeutil.Ticker
+	dryRun    bool
+
+	// processed contains optimistic updates that were already verified
+	// or challenged.
+	processed map[opPokeKey]uint64
+
+	// challenges contains challenge transactions that are not mined yet.
+	challenges map[opPokeKey]*PendingTx
+
+	// liftedKeys contains public keys of feeds read from the contract,
+	// used when the key was not received from the feed itself.
+	liftedKeys map[types.Address]schnorr.PublicKey
+
+	// unverified contains optimistic updates that could not be verified
+	// because public keys of some signers are not known.
+	unverified map[opPokeKey]bool
+}
+
+type opPokeKey struct {
+	txHash   types.Hash
+	logIndex uint64
+}
+
+func (w *watchtowerWorker) workerRoutine(ctx context.Context) {
+	w.ticker.Start(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.ticker.TickCh():
+			if err := w.tryChallenge(ctx); err != nil {
+				w.log.WithError(err).Error("Failed to verify OpScribe updates")
+			}
+		}
+	}
+}
+
+func (w *watchtowerWorker) tryChallenge(ctx context.Context) error {
+	latest, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	to := latest.Uint64()
+	from := uint64(0)
+	if to > w.lookback {
+		from = to - w.lookback
+	}
+
+	w.checkChallenges()
+
+	// Forget updates that are no longer in the searched range.
+	for k, n := range w.processed {
+		if n < from {
+			delete(w.processed, k)
+		}
+	}
+
+	events, err := w.contract.OpPokedEvents(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	var pending []contract.OpPokedEvent
+	for _, e := range events {
+		if _, ok := w.processed[opPokeKey{txHash: e.TxHash, logIndex: e.LogIndex}]; !ok {
+			pending = append(pending, e)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// Contract data required to verify signatures.
+	wat, err := w.contract.Wat(ctx)
+	if err != nil {
+		return err
+	}
+	period, err := w.contract.OpChallengePeriod(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, e := range pending {
+		if err := w.processEvent(ctx, e, wat, period); err != nil {
+			// Updates signed by feeds with unknown public keys are verified
+			// again once the keys are known.
+			if !errors.Is(err, errUnknownPublicKey) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		w.processed[opPokeKey{txHash: e.TxHash, logIndex: e.LogIndex}] = e.BlockNumber
+	}
+	return errors.Join(errs...)
+}
+
+// processEvent verifies the optimistic update and challenges it if its
+// signature is invalid. An error is returned only if the update should be
+// processed again.
+func (w *watchtowerWorker) processEvent(
+	ctx context.Context,
+	event contract.OpPokedEvent,
+	wat string,
+	period time.Duration,
+) error {
+	fields := log.Fields{
+		"dataModel":    wat,
+		"contract":     w.address.String(),
+		"opFeed":       event.OpFeed.String(),
+		"val":          event.PokeData.Val,
+		"age":          event.PokeData.Age,
+		"opPokeTxHash": event.TxHash.String(),
+		"blockNumber":  event.BlockNumber,
+	}
+
+	block, err := w.client.BlockByNumber(ctx, types.BlockNumberFromUint64(event.BlockNumber), false)
+	if err != nil {
+		return err
+	}
+	key := opPokeKey{txHash: event.TxHash, logIndex: event.LogIndex}
+	deadline := block.Timestamp.Add(period)
+	if !time.Now().Before(deadline) {
+		if w.unverified[key] {
+			delete(w.unverified, key)
+			w.log.
+				WithFields(fields).
+				WithField("challengeDeadline", deadline).
+				Error("OpPoke signature was not verified before the end of the challenge period")
+			return nil
+		}
+		w.log.WithFields(fields).Debug("OpPoke challenge period is over")
+		return nil
+	}
+
+	// The signature is verified against the feeds and the quorum at the
+	// block of the update, because they may have changed since then.
+	bar, err := w.contract.BarAt(ctx, event.BlockNumber)
+	if err != nil {
+		return err
+	}
+	feeds, indices, err := w.contract.FeedsAt(ctx, event.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if signers, err := blobSigners(event.SchnorrData.SignersBlob, feeds, indices); err == nil {
+		w.loadLiftedKeys(ctx, signers, event.BlockNumber)
+	}
+
+	err = verifyScribeSignature(wat, event.PokeData, event.SchnorrData, bar, feeds, indices, w)
+	if !errors.Is(err, errUnknownPublicKey) {
+		delete(w.unverified, key)
+	}
+	if err == nil {
+		w.log.WithFields(fields).Debug("OpPoke signature is valid")
+		return nil
+	}
+	if errors.Is(err, errUnknownPublicKey) {
+		// If the update cannot be verified, it cannot be challenged. This
+		// becomes critical as the end of the challenge period approaches.
+		w.unverified[key] = true
+		l := w.log.
+			WithError(err).
+			WithFields(fields).
+			WithField("challengeDeadline", deadline)
+		if time.Until(deadline) < period/2 {
+			l.Error("Unable to verify OpPoke signature before the end of the challenge period")
+		} else {
+			l.Warn("Unable to verify OpPoke signature")
+		}
+		return err
+	}
+
+	w.log.
+		WithError(err).
+		WithFields(fields).
+		WithField("challengeDeadline", deadline).
+		Warn("Invalid OpPoke signature, challenging")
+
+	tx, err := w.contract.OpChallenge(ctx, event.SchnorrData)
+	if err != nil {
+		return fmt.Errorf("unable to challenge OpPoke %s: %w", event.TxHash, err)
+	}
+	if w.dryRun {
+		w.log.
+			WithFields(fields).
+			WithField("calldata", hexutil.BytesToHex(tx.Input)).
+			Warn("Dry run, OpPoke challenge not sent")
+		return nil
+	}
+	ptx, err := w.txManager.Send(ctx, *tx)
+	if err != nil {
+		return fmt.Errorf("unable to challenge OpPoke %s: %w", event.TxHash, err)
+	}
+	w.challenges[key] = ptx
+	w.log.
+		WithFields(fields).
+		WithField("txHash", ptx.Hash().String()).
+		Warn("OpPoke challenge sent")
+	return nil
+}
+
+// PublicKey implements the FeedKeyProvider interface. Keys received from
+// feeds take precedence over keys read from the contract.
+func (w *watchtowerWorker) PublicKey(feed types.Address) (schnorr.PublicKey, bool) {
+	if k, ok := w.feedKeys.PublicKey(feed); ok {
+		return k, true
+	}
+	k, ok := w.liftedKeys[feed]
+	return k, ok
+}
+
+// loadLiftedKeys reads public keys of the given feeds from the contract if
+// they are not known yet. Feeds that do not gossip are otherwise never
+// verified.
+func (w *watchtowerWorker) loadLiftedKeys(ctx context.Context, feeds []types.Address, block uint64) {
+	for _, feed := range feeds {
+		if _, ok := w.PublicKey(feed); ok {
+			continue
+		}
+		k, err := w.contract.LiftedPublicKey(ctx, feed, block)
+		if err != nil {
+			w.log.
+				WithError(err).
+				WithFields(log.Fields{
+					"contract": w.address.String(),
+					"feed":     feed.String(),
+				}).
+				Warn("Unable to read public key of the feed from the contract")
+			continue
+		}
+		w.liftedKeys[feed] = k
+		w.log.
+			WithFields(log.Fields{
+				"contract": w.address.String(),
+				"feed":     feed.String(),
+			}).
+			Info("Read public key of the feed from the contract")
+	}
+}
+
+// checkChallenges checks the results of sent challenge transactions. If
+// a challenge failed, the update is verified and challenged again.
+func (w *watchtowerWorker) checkChallenges() {
+	for k, ptx := range w.challenges {
+		select {
+		case <-ptx.Done():
+		default:
+			continue
+		}
+		delete(w.challenges, k)
+		receipt, err := ptx.Wait(context.Background())
+		if err != nil {
+			delete(w.processed, k)
+			w.log.
+				WithError(err).
+				WithFields(log.Fields{
+					"contract":     w.address.String(),
+					"opPokeTxHash": k.txHash.String(),
+					"txHash":       ptx.Hash().String(),
+				}).
+				Error("OpPoke challenge failed")
+			continue
+		}
+		w.log.
+			WithFields(log.Fields{
+				"contract":     w.address.String(),
+				"opPokeTxHash": k.txHash.String(),
+			}).
+			WithFields(receiptLogFields(receipt)).
+			Warn("OpPoke challenged")
+	}
+}
@@ -0,0 +1,258 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/contract"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay/schnorr"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+type testFeedKeys map[types.Address]schnorr.PublicKey
+
+func (k testFeedKeys) PublicKey(feed types.Address) (schnorr.PublicKey, bool) {
+	pk, ok := k[feed]
+	return pk, ok
+}
+
+type testWatchtowerContract struct {
+	events     []contract.OpPokedEvent
+	feeds      []types.Address
+	indices    []uint8
+	lifted     testFeedKeys
+	challenged []contract.SchnorrData
+	feedsAt    []uint64
+}
+
+func (c *testWatchtowerContract) Wat(context.Context) (string, error) {
+	return "ETH/USD", nil
+}
+
+func (c *testWatchtowerContract) BarAt(context.Context, uint64) (int, error) {
+	return 2, nil
+}
+
+func (c *testWatchtowerContract) FeedsAt(_ context.Context, block uint64) ([]types.Address, []uint8, error) {
+	c.feedsAt = append(c.feedsAt, block)
+	return c.feeds, c.indices, nil
+}
+
+func (c *testWatchtowerContract) LiftedPublicKey(_ context.Context, feed types.Address, _ uint64) (schnorr.PublicKey, error) {
+	if k, ok := c.lifted[feed]; ok {
+		return k, nil
+	}
+	return schnorr.PublicKey{}, errors.New("not found")
+}
+
+func (c *testWatchtowerContract) OpChallengePeriod(context.Context) (time.Duration, error) {
+	return 20 * time.Minute, nil
+}
+
+func (c *testWatchtowerContract) OpPokedEvents(_ context.Context, _, _ uint64) ([]contract.OpPokedEvent, error) {
+	return c.events, nil
+}
+
+func (c *testWatchtowerContract) OpChallenge(_ context.Context, schnorrData contract.SchnorrData) (*types.Transaction, error) {
+	c.challenged = append(c.challenged, schnorrData)
+	tx := testTx()
+	return &tx, nil
+}
+
+func testSchnorrKey(priv int64) schnorr.PublicKey {
+	var k secp256k1.ModNScalar
+	k.SetInt(uint32(priv))
+	var p secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&k, &p)
+	p.ToAffine()
+	var x, y [32]byte
+	p.X.PutBytes(&x)
+	p.Y.PutBytes(&y)
+	return schnorr.PublicKey{X: new(big.Int).SetBytes(x[:]), Y: new(big.Int).SetBytes(y[:])}
+}
+
+// testSchnorrSign signs the message with the sum of the private keys.
+func testSchnorrSign(privs []int64, nonce int64, message types.Hash) (*big.Int, types.Address) {
+	var pubKeys []schnorr.PublicKey
+	aggPriv := new(big.Int)
+	for _, p := range privs {
+		pubKeys = append(pubKeys, testSchnorrKey(p))
+		aggPriv.Add(aggPriv, big.NewInt(p))
+	}
+	aggPub, _ := schnorr.AggregatePublicKeys(pubKeys)
+	commitment := testSchnorrKey(nonce).Address()
+	e := schnorr.Challenge(aggPub, message, commitment)
+	s := new(big.Int).Mul(e, aggPriv)
+	s.Add(s, big.NewInt(nonce))
+	return s.Mod(s, secp256k1.S256().N), commitment
+}
+
+func TestWatchtowerWorker_tryChallenge(t *testing.T) {
+	privs := []int64{1111, 2222, 3333}
+	keys := testFeedKeys{}
+	var feeds []types.Address
+	for _, p := range privs {
+		feeds = append(feeds, testSchnorrKey(p).Address())
+		keys[testSchnorrKey(p).Address()] = testSchnorrKey(p)
+	}
+	pokeData := contract.PokeData{
+		Val: bn.DecFixedPoint(1500, contract.ScribePricePrecision),
+		Age: time.Unix(1234567890, 0),
+	}
+	msg := contract.ConstructPokeMessage("ETH/USD", pokeData)
+	sig, commitment := testSchnorrSign(privs[:2], 42, msg)
+
+	tests := []struct {
+		name          string
+		schnorrData   contract.SchnorrData
+		blockTime     time.Time
+		keys          testFeedKeys
+		lifted        testFeedKeys
+		wantChallenge bool
+		wantRetry     bool
+	}{
+		{
+			name:        "valid signature",
+			schnorrData: contract.SchnorrData{Signature: sig, Commitment: commitment, SignersBlob: []byte{0, 1}},
+			blockTime:   time.Now(),
+			keys:        keys,
+		},
+		{
+			name:          "invalid signature",
+			schnorrData:   contract.SchnorrData{Signature: new(big.Int).Add(sig, big.NewInt(1)), Commitment: commitment, SignersBlob: []byte{0, 1}},
+			blockTime:     time.Now(),
+			keys:          keys,
+			wantChallenge: true,
+		},
+		{
+			name:          "wrong signers",
+			schnorrData:   contract.SchnorrData{Signature: sig, Commitment: commitment, SignersBlob: []byte{0, 2}},
+			blockTime:     time.Now(),
+			keys:          keys,
+			wantChallenge: true,
+		},
+		{
+			name:          "not enough signers",
+			schnorrData:   contract.SchnorrData{Signature: sig, Commitment: commitment, SignersBlob: []byte{0}},
+			blockTime:     time.Now(),
+			keys:          keys,
+			wantChallenge: true,
+		},
+		{
+			name:        "unknown public key",
+			schnorrData: contract.SchnorrData{Signature: new(big.Int).Add(sig, big.NewInt(1)), Commitment: commitment, SignersBlob: []byte{0, 1}},
+			blockTime:   time.Now(),
+			keys:        testFeedKeys{},
+			wantRetry:   true,
+		},
+		{
+			name:          "public key read from the contract",
+			schnorrData:   contract.SchnorrData{Signature: new(big.Int).Add(sig, big.NewInt(1)), Commitment: commitment, SignersBlob: []byte{0, 1}},
+			blockTime:     time.Now(),
+			keys:          testFeedKeys{},
+			lifted:        keys,
+			wantChallenge: true,
+		},
+		{
+			name:        "challenge period is over",
+			schnorrData: contract.SchnorrData{Signature: new(big.Int).Add(sig, big.NewInt(1)), Commitment: commitment, SignersBlob: []byte{0, 1}},
+			blockTime:   time.Now().Add(-time.Hour),
+			keys:        keys,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cli := &mocks.RPC{}
+			txManager, err := NewTxManager(TxManagerConfig{
+				Client:              cli,
+				ReceiptPollInterval: time.Millisecond,
+			})
+			require.NoError(t, err)
+			c := &testWatchtowerContract{
+				events: []contract.OpPokedEvent{{
+					OpFeed:      feeds[0],
+					PokeData:    pokeData,
+					SchnorrData: tt.schnorrData,
+					BlockNumber: 100,
+					TxHash:      testHash2,
+				}},
+				feeds:   feeds,
+				indices: []uint8{0, 1, 2},
+				lifted:  tt.lifted,
+			}
+			w := &watchtowerWorker{
+				log:        null.New(),
+				client:     cli,
+				contract:   c,
+				txManager:  txManager,
+				feedKeys:   tt.keys,
+				address:    testContract,
+				lookback:   DefaultWatchtowerLookbackBlocks,
+				processed:  make(map[opPokeKey]uint64),
+				challenges: make(map[opPokeKey]*PendingTx),
+				liftedKeys: make(map[types.Address]schnorr.PublicKey),
+				unverified: make(map[opPokeKey]bool),
+			}
+
+			cli.On("BlockNumber", ctx).Return(big.NewInt(110), nil)
+			cli.On("BlockByNumber", ctx, types.BlockNumberFromUint64(100), false).Return(&types.Block{Timestamp: tt.blockTime}, nil)
+			cli.On("Accounts", ctx).Return([]types.Address{testSender}, nil)
+			cli.On("EstimateGas", ctx, mock.Anything, types.LatestBlockNumber).Return(uint64(100000), nil)
+			cli.On("FeeHistory", ctx, mock.Anything, types.LatestBlockNumber, mock.Anything).Return(testFeeHistory(), nil)
+			cli.On("GetTransactionCount", ctx, testSender, types.PendingBlockNumber).Return(uint64(5), nil)
+			cli.On("SendTransaction", ctx, mock.Anything).Return(&testHash1, nil)
+			cli.On("GetTransactionReceipt", ctx, testHash1).Return(minedReceipt(testHash1, 1), nil)
+
+			require.NoError(t, w.tryChallenge(ctx))
+			for _, b := range c.feedsAt {
+				// Feeds are read at the block of the update.
+				assert.Equal(t, uint64(100), b)
+			}
+			if tt.wantChallenge {
+				require.Len(t, c.challenged, 1)
+				assert.Equal(t, tt.schnorrData, c.challenged[0])
+				cli.AssertCalled(t, "SendTransaction", ctx, mock.Anything)
+			} else {
+				assert.Empty(t, c.challenged)
+				cli.AssertNotCalled(t, "SendTransaction", ctx, mock.Anything)
+			}
+
+			// Processed updates are not verified again.
+			challenged := len(c.challenged)
+			require.NoError(t, w.tryChallenge(ctx))
+			assert.Len(t, c.challenged, challenged)
+			if tt.wantRetry {
+				cli.AssertNumberOfCalls(t, "BlockByNumber", 2)
+			} else {
+				cli.AssertNumberOfCalls(t, "BlockByNumber", 1)
+			}
+		})
+	}
+}
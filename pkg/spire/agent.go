@@ -27,6 +27,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 )
@@ -36,10 +37,17 @@ const AgentLoggerTag = "SPIRE_AGENT"
 // defaultHTTPTimeout is the default timeout for the HTTP server.
 const defaultHTTPTimeout = 3 * time.Second
 
+// Agent serves the data points collected by the price store and allows
+// publishing new ones.
+//
+// The agent provides the HTTP/JSON and WebSocket API described in the
+// httpAPI type. The legacy net/rpc API is still available under the
+// rpc.DefaultRPCPath path for older clients.
 type Agent struct {
 	ctx context.Context
 
 	srv *httpserver.HTTPServer
+	api *httpAPI
 	log log.Logger
 }
 
@@ -62,15 +70,37 @@ func NewAgent(cfg AgentConfig) (*Agent, error) {
 	if err != nil {
 		return nil, err
 	}
+	api := &httpAPI{
+		transport:  cfg.Transport,
+		priceStore: cfg.PriceStore,
+		log:        logger,
+	}
+	mux := http.NewServeMux()
+	mux.Handle(rpc.DefaultRPCPath, rpcSrv)
+	api.register(mux)
+	srv := httpserver.New(&http.Server{
+		Addr:              cfg.Address,
+		Handler:           mux,
+		IdleTimeout:       defaultHTTPTimeout,
+		ReadTimeout:       defaultHTTPTimeout,
+		WriteTimeout:      defaultHTTPTimeout,
+		ReadHeaderTimeout: defaultHTTPTimeout,
+	})
+	srv.Use(&middleware.HealthCheck{
+		Path:  "/health",
+		Check: func(r *http.Request) bool { return true },
+	})
+	srv.Use(&middleware.Recover{
+		Recover: func(err any) {
+			logger.
+				WithField("panic", err).
+				Error("Panic in HTTP handler")
+		},
+	})
+	srv.Use(&middleware.Logger{Log: logger})
 	return &Agent{
-		srv: httpserver.New(&http.Server{
-			Addr:              cfg.Address,
-			Handler:           rpcSrv,
-			IdleTimeout:       defaultHTTPTimeout,
-			ReadTimeout:       defaultHTTPTimeout,
-			WriteTimeout:      defaultHTTPTimeout,
-			ReadHeaderTimeout: defaultHTTPTimeout,
-		}),
+		srv: srv,
+		api: api,
 		log: logger,
 	}, nil
 }
@@ -84,6 +114,7 @@ func (s *Agent) Start(ctx context.Context) error {
 	}
 	s.log.Debug("Starting")
 	s.ctx = ctx
+	s.api.ctx = ctx
 	err := s.srv.Start(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to start the HTTP server: %w", err)
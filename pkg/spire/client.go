@@ -16,33 +16,61 @@
 package spire
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
-	"net/rpc"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/defiweb/go-eth/wallet"
+	"github.com/gorilla/websocket"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
+// defaultClientTimeout is the default timeout for HTTP requests made by
+// the client.
+const defaultClientTimeout = 30 * time.Second
+
+// Client is a client for the Spire agent's HTTP API.
 type Client struct {
 	ctx    context.Context
 	waitCh chan error
 
-	rpc    *rpc.Client
-	addr   string
+	client *http.Client
+	url    *url.URL
 	signer wallet.Key
 }
 
 type ClientConfig struct {
-	Signer  wallet.Key
+	Signer wallet.Key
+
+	// Address is the address of the agent. It can be either a "host:port"
+	// pair or a URL with the http or https scheme.
 	Address string
 }
 
 func NewClient(cfg ClientConfig) (*Client, error) {
+	addr := cfg.Address
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent address: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid agent address: unsupported scheme %q", u.Scheme)
+	}
 	return &Client{
 		waitCh: make(chan error),
-		addr:   cfg.Address,
+		client: &http.Client{Timeout: defaultClientTimeout},
+		url:    u,
 		signer: cfg.Signer,
 	}, nil
 }
@@ -52,11 +80,6 @@ func (c *Client) Start(ctx context.Context) error {
 		return errors.New("context must not be nil")
 	}
 	c.ctx = ctx
-	client, err := rpc.DialHTTP("tcp", c.addr)
-	if err != nil {
-		return err
-	}
-	c.rpc = client
 	go c.contextCancelHandler()
 	return nil
 }
@@ -66,36 +89,164 @@ func (c *Client) Wait() <-chan error {
 	return c.waitCh
 }
 
+// Publish publishes a data point to the network through the agent.
 func (c *Client) Publish(dataPoint *messages.DataPoint) error {
-	err := c.rpc.Call("API.Publish", PublishArg{DataPoint: dataPoint}, &Nothing{})
+	bin, err := dataPoint.MarshallBinary()
 	if err != nil {
 		return err
 	}
-	return nil
+	body, err := json.Marshal(JSONDataPoint{
+		Model:           dataPoint.Model,
+		Signature:       dataPoint.Signature,
+		SignatureScheme: dataPoint.SignatureScheme,
+		Binary:          bin,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodPost, c.endpoint(DataPointsPath, nil), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, nil)
 }
 
+// PullPrices returns the latest data points for a given model. If feed is
+// not empty, only the data point from that feed is returned.
 func (c *Client) PullPrices(assetPair string, feed string) ([]*messages.DataPoint, error) {
-	resp := &PullDataPointsResp{}
-	err := c.rpc.Call("API.PullPoints", PullPricesArg{FilterAssetPair: assetPair, FilterFeed: feed}, resp)
+	if assetPair == "" {
+		return nil, errors.New("please provide model")
+	}
+	query := url.Values{"model": {assetPair}}
+	if feed != "" {
+		query.Set("feed", feed)
+	}
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, c.endpoint(DataPointsPath, query), nil)
 	if err != nil {
 		return nil, err
 	}
-	return resp.DataPoints, nil
+	var resp jsonDataPoints
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	dataPoints := make([]*messages.DataPoint, 0, len(resp.DataPoints))
+	for _, jdp := range resp.DataPoints {
+		dp, err := jdp.DataPoint()
+		if err != nil {
+			return nil, err
+		}
+		dataPoints = append(dataPoints, dp)
+	}
+	return dataPoints, nil
 }
 
+// PullPrice returns the latest data point for a given model and feed. If
+// there is no such data point, nil is returned.
 func (c *Client) PullPrice(assetPair string, feed string) (*messages.DataPoint, error) {
-	resp := &PullDataPointResp{}
-	err := c.rpc.Call("API.PullPoint", PullPriceArg{AssetPair: assetPair, Feed: feed}, resp)
+	if feed == "" {
+		return nil, errors.New("please provide feed")
+	}
+	dataPoints, err := c.PullPrices(assetPair, feed)
 	if err != nil {
 		return nil, err
 	}
-	return resp.DataPoint, nil
+	if len(dataPoints) == 0 {
+		return nil, nil
+	}
+	return dataPoints[0], nil
+}
+
+// Stream streams new data points collected by the agent. Data points can be
+// filtered by model and feed, empty values disable filtering.
+//
+// The returned channel is closed when the context is canceled or when the
+// connection to the agent is closed.
+func (c *Client) Stream(ctx context.Context, model string, feed string) (<-chan *JSONDataPoint, error) {
+	query := url.Values{}
+	if model != "" {
+		query.Set("model", model)
+	}
+	if feed != "" {
+		query.Set("feed", feed)
+	}
+	wsURL := *c.url
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+	conn, res, err := websocket.DefaultDialer.DialContext(ctx, endpoint(&wsURL, DataPointsStreamPath, query), nil)
+	if err != nil {
+		if res != nil {
+			return nil, responseError(res)
+		}
+		return nil, err
+	}
+	ch := make(chan *JSONDataPoint)
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	go func() {
+		defer close(ch)
+		defer conn.Close()
+		for {
+			jdp := &JSONDataPoint{}
+			if err := conn.ReadJSON(jdp); err != nil {
+				return
+			}
+			select {
+			case ch <- jdp:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (c *Client) endpoint(path string, query url.Values) string {
+	return endpoint(c.url, path, query)
+}
+
+// do sends the request and decodes the JSON response into v. If v is nil,
+// the response body is discarded.
+func (c *Client) do(req *http.Request, v any) error {
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return responseError(res)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(v)
 }
 
 func (c *Client) contextCancelHandler() {
 	defer func() { close(c.waitCh) }()
 	<-c.ctx.Done()
-	if err := c.rpc.Close(); err != nil {
-		c.waitCh <- err
+	c.client.CloseIdleConnections()
+}
+
+func endpoint(u *url.URL, path string, query url.Values) string {
+	e := *u
+	e.Path = strings.TrimRight(e.Path, "/") + path
+	e.RawQuery = query.Encode()
+	return e.String()
+}
+
+// responseError returns an error describing an unsuccessful response.
+func responseError(res *http.Response) error {
+	var jerr jsonError
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxPublishBodySize))
+	if err := json.Unmarshal(body, &jerr); err == nil && jerr.Error != "" {
+		return fmt.Errorf("agent responded with %s: %s", res.Status, jerr.Error)
 	}
+	return fmt.Errorf("agent responded with %s", res.Status)
 }
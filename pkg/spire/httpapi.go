@@ -0,0 +1,338 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spire
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/gorilla/websocket"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const (
+	// DataPointsPath is the path of the endpoint that returns the latest
+	// data points (GET) and publishes new data points (POST).
+	DataPointsPath = "/api/v1/data_points"
+
+	// DataPointsStreamPath is the path of the WebSocket endpoint that
+	// streams new data points.
+	DataPointsStreamPath = "/api/v1/data_points/stream"
+)
+
+const (
+	// maxPublishBodySize is the maximum size of the publish request body.
+	maxPublishBodySize = 1 * 1024 * 1024 // 1MB
+
+	// wsWriteTimeout is the time allowed to write a single message to
+	// the WebSocket peer.
+	wsWriteTimeout = 10 * time.Second
+
+	// wsPongTimeout is the time allowed to read the next pong message from
+	// the WebSocket peer.
+	wsPongTimeout = 60 * time.Second
+
+	// wsPingInterval is the interval at which ping messages are sent to
+	// the WebSocket peer. Must be less than wsPongTimeout.
+	wsPingInterval = 30 * time.Second
+
+	// wsReadLimit is the maximum size of a message read from the
+	// WebSocket peer. Clients are not expected to send any messages.
+	wsReadLimit = 512
+)
+
+// JSONDataPoint is the JSON representation of a data point used by the
+// agent's HTTP API.
+type JSONDataPoint struct {
+	// Model of the data point.
+	Model string `json:"model"`
+
+	// Value is a human-readable representation of the data point. It is
+	// ignored when publishing data points.
+	Value json.RawMessage `json:"value,omitempty"`
+
+	// Feed is the address of the feed that signed the data point. It is
+	// ignored when publishing data points.
+	Feed *types.Address `json:"feed,omitempty"`
+
+	// Signature is the feed signature of the data point.
+	Signature types.Signature `json:"signature"`
+
+	// SignatureScheme is the scheme used to create the signature.
+	SignatureScheme datapoint.SignatureScheme `json:"signatureScheme"`
+
+	// Binary is the binary representation of the data point message. It is
+	// the only field required when publishing data points.
+	Binary types.Bytes `json:"binary"`
+}
+
+// DataPoint decodes the data point message from the binary representation.
+func (j *JSONDataPoint) DataPoint() (*messages.DataPoint, error) {
+	dp := &messages.DataPoint{}
+	if err := dp.UnmarshallBinary(j.Binary); err != nil {
+		return nil, fmt.Errorf("invalid data point: %w", err)
+	}
+	return dp, nil
+}
+
+type jsonDataPoints struct {
+	DataPoints []*JSONDataPoint `json:"dataPoints"`
+}
+
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// httpAPI provides an HTTP API for the Spire agent.
+//
+// It provides following endpoints:
+//   - GET DataPointsPath?model=MODEL[&feed=ADDRESS] returns the latest data
+//     points for a given model, optionally filtered by the feed address.
+//   - POST DataPointsPath publishes a data point to the network.
+//   - GET DataPointsPath/stream[?model=MODEL][&feed=ADDRESS] upgrades the
+//     connection to a WebSocket and streams new data points as they are
+//     collected by the agent.
+type httpAPI struct {
+	ctx context.Context
+
+	transport  transport.Service
+	priceStore *store.Store
+	upgrader   websocket.Upgrader
+	log        log.Logger
+}
+
+func (a *httpAPI) register(mux *http.ServeMux) {
+	mux.HandleFunc(DataPointsPath, a.dataPointsHandler)
+	mux.HandleFunc(DataPointsStreamPath, a.streamHandler)
+}
+
+func (a *httpAPI) dataPointsHandler(res http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		a.pullHandler(res, req)
+	case http.MethodPost:
+		a.publishHandler(res, req)
+	default:
+		res.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (a *httpAPI) pullHandler(res http.ResponseWriter, req *http.Request) {
+	model, feed, err := parseFilters(req)
+	if err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: err.Error()})
+		return
+	}
+	if model == "" {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: "model must be provided"})
+		return
+	}
+	var points []store.StoredDataPoint
+	if feed != nil {
+		point, ok, err := a.priceStore.LatestFrom(req.Context(), *feed, model)
+		if err != nil {
+			writeJSON(res, http.StatusInternalServerError, jsonError{Error: err.Error()})
+			return
+		}
+		if ok {
+			points = append(points, point)
+		}
+	} else {
+		latest, err := a.priceStore.Latest(req.Context(), model)
+		if err != nil {
+			writeJSON(res, http.StatusInternalServerError, jsonError{Error: err.Error()})
+			return
+		}
+		for _, point := range latest {
+			points = append(points, point)
+		}
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].From.String() < points[j].From.String()
+		})
+	}
+	resp := jsonDataPoints{DataPoints: make([]*JSONDataPoint, 0, len(points))}
+	for _, point := range points {
+		jdp, err := toJSONDataPoint(point)
+		if err != nil {
+			writeJSON(res, http.StatusInternalServerError, jsonError{Error: err.Error()})
+			return
+		}
+		resp.DataPoints = append(resp.DataPoints, jdp)
+	}
+	writeJSON(res, http.StatusOK, resp)
+}
+
+func (a *httpAPI) publishHandler(res http.ResponseWriter, req *http.Request) {
+	var jdp JSONDataPoint
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxPublishBodySize)).Decode(&jdp); err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	dp, err := jdp.DataPoint()
+	if err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: err.Error()})
+		return
+	}
+	a.log.
+		WithField("model", dp.Model).
+		Info("Publish data point")
+	if err := a.transport.Broadcast(messages.DataPointV1MessageName, dp); err != nil {
+		writeJSON(res, http.StatusInternalServerError, jsonError{Error: err.Error()})
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+func (a *httpAPI) streamHandler(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	model, feed, err := parseFilters(req)
+	if err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: err.Error()})
+		return
+	}
+
+	// Subscribe before upgrading the connection, so that the client does
+	// not miss data points collected right after the handshake.
+	ctx, ctxCancel := context.WithCancel(a.ctx)
+	defer ctxCancel()
+	points := a.priceStore.Subscribe(ctx)
+
+	conn, err := a.upgrader.Upgrade(res, req, nil)
+	if err != nil {
+		// The upgrader already responded with an error.
+		a.log.WithError(err).Warn("Unable to upgrade connection to WebSocket")
+		return
+	}
+	defer conn.Close()
+
+	// Clients are not expected to send any messages, but the connection
+	// must be read to process control messages and detect disconnects.
+	go func() {
+		defer ctxCancel()
+		conn.SetReadLimit(wsReadLimit)
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+				time.Now().Add(wsWriteTimeout),
+			)
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case point, ok := <-points:
+			if !ok {
+				return
+			}
+			if model != "" && point.Model != model {
+				continue
+			}
+			if feed != nil && point.From != *feed {
+				continue
+			}
+			jdp, err := toJSONDataPoint(point)
+			if err != nil {
+				a.log.
+					WithError(err).
+					WithFields(point.LogFields()).
+					Error("Unable to encode data point")
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteJSON(jdp); err != nil {
+				a.log.
+					WithError(err).
+					Debug("Unable to write data point to WebSocket")
+				return
+			}
+		}
+	}
+}
+
+// parseFilters parses the model and feed query parameters.
+func parseFilters(req *http.Request) (string, *types.Address, error) {
+	query := req.URL.Query()
+	model := query.Get("model")
+	if query.Get("feed") == "" {
+		return model, nil, nil
+	}
+	feed, err := types.AddressFromHex(query.Get("feed"))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid feed address: %w", err)
+	}
+	return model, &feed, nil
+}
+
+func toJSONDataPoint(point store.StoredDataPoint) (*JSONDataPoint, error) {
+	dp := &messages.DataPoint{
+		Model:           point.Model,
+		Value:           point.DataPoint,
+		Signature:       point.Signature,
+		SignatureScheme: point.SignatureScheme,
+	}
+	bin, err := dp.MarshallBinary()
+	if err != nil {
+		return nil, err
+	}
+	val, err := json.Marshal(point.DataPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONDataPoint{
+		Model:           point.Model,
+		Value:           val,
+		Feed:            &point.From,
+		Signature:       point.Signature,
+		SignatureScheme: point.SignatureScheme,
+		Binary:          bin,
+	}, nil
+}
+
+func writeJSON(res http.ResponseWriter, code int, v any) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(code)
+	_ = json.NewEncoder(res).Encode(v)
+}
@@ -0,0 +1,128 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spire
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/rpc"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Stream(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	ch, err := spire.Stream(ctx, "AAA/BBB", testAddress.String())
+	require.NoError(t, err)
+
+	require.NoError(t, spire.Publish(testPriceAAABBB))
+
+	select {
+	case jdp := <-ch:
+		require.NotNil(t, jdp)
+		assert.Equal(t, "AAA/BBB", jdp.Model)
+		assert.Equal(t, testAddress, *jdp.Feed)
+		dp, err := jdp.DataPoint()
+		require.NoError(t, err)
+		assertEqualValue(t, testPriceAAABBB, dp)
+	case <-time.After(time.Second):
+		require.Fail(t, "timeout waiting for data point")
+	}
+
+	// The channel must be closed after the context is canceled.
+	ctxCancel()
+	assert.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClient_Stream_InvalidFeed(t *testing.T) {
+	_, err := spire.Stream(context.Background(), "AAA/BBB", "invalid")
+	assert.Error(t, err)
+}
+
+func TestAgent_HTTP_Errors(t *testing.T) {
+	base := "http://" + agent.srv.Addr().String()
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		status int
+	}{
+		{
+			name:   "missing model",
+			method: http.MethodGet,
+			url:    base + DataPointsPath,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid feed",
+			method: http.MethodGet,
+			url:    base + DataPointsPath + "?model=AAA/BBB&feed=invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid body",
+			method: http.MethodPost,
+			url:    base + DataPointsPath,
+			body:   `{"binary":"0x01"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid method",
+			method: http.MethodDelete,
+			url:    base + DataPointsPath,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			require.NoError(t, err)
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			assert.Equal(t, tt.status, res.StatusCode)
+		})
+	}
+}
+
+func TestAgent_LegacyRPC(t *testing.T) {
+	var err error
+	var resp PullDataPointsResp
+
+	cli, err := rpc.DialHTTP("tcp", agent.srv.Addr().String())
+	require.NoError(t, err)
+	defer cli.Close()
+
+	require.NoError(t, spire.Publish(testPriceAAABBB))
+
+	wait(func() bool {
+		err = cli.Call("API.PullPoints", PullPricesArg{FilterAssetPair: "AAA/BBB"}, &resp)
+		return len(resp.DataPoints) != 0
+	}, time.Second)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.DataPoints, 1)
+}
@@ -0,0 +1,231 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+const LoggerTag = "BRIDGE"
+
+const defaultTTL = 10 * time.Minute
+
+// Bridge relays messages between transports. Every message received from
+// one of the transports is broadcast, unchanged, on all other transports.
+//
+// Because messages are not modified, signatures included in messages, such
+// as data point signatures, are preserved and can be verified by consumers.
+// The transport-level author of a bridged message is the bridge itself.
+//
+// To prevent messages from looping between transports, the bridge keeps
+// hashes of relayed messages and does not relay the same message twice
+// within the TTL.
+type Bridge struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	waitCh chan error
+	log    log.Logger
+
+	transports []transport.Transport
+	topics     []string
+	ttl        time.Duration
+	seen       map[types.Hash]time.Time
+}
+
+// Config is the configuration for the Bridge.
+type Config struct {
+	// Transports is the list of bridged transports. At least two transports
+	// are required. The lifecycle of the transports is not managed by the
+	// bridge.
+	Transports []transport.Transport
+
+	// Topics is the list of relayed topics.
+	Topics []string
+
+	// TTL is the time for which hashes of relayed messages are kept. If
+	// zero, the default value of 10 minutes is used.
+	TTL time.Duration
+
+	// Logger is a current logger interface used by the Bridge.
+	Logger log.Logger
+}
+
+// New returns a new Bridge.
+func New(cfg Config) (*Bridge, error) {
+	if len(cfg.Transports) < 2 {
+		return nil, errors.New("at least two transports are required")
+	}
+	for _, t := range cfg.Transports {
+		if t == nil {
+			return nil, errors.New("transport must not be nil")
+		}
+	}
+	if len(cfg.Topics) == 0 {
+		return nil, errors.New("at least one topic is required")
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Bridge{
+		waitCh:     make(chan error),
+		log:        cfg.Logger.WithField("tag", LoggerTag),
+		transports: cfg.Transports,
+		topics:     cfg.Topics,
+		ttl:        cfg.TTL,
+		seen:       make(map[types.Hash]time.Time),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (b *Bridge) Start(ctx context.Context) error {
+	if b.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	b.log.Info("Starting")
+	b.ctx = ctx
+	for i, t := range b.transports {
+		for _, topic := range b.topics {
+			ch := t.Messages(topic)
+			if ch == nil {
+				return fmt.Errorf("unconfigured topic: %s", topic)
+			}
+			go b.relayRoutine(i, topic, ch)
+		}
+	}
+	go b.gcRoutine()
+	go b.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (b *Bridge) Wait() <-chan error {
+	return b.waitCh
+}
+
+// relayRoutine relays messages received from the transport with the given
+// index to other transports.
+func (b *Bridge) relayRoutine(from int, topic string, ch <-chan transport.ReceivedMessage) {
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			b.relay(from, topic, msg)
+		}
+	}
+}
+
+func (b *Bridge) relay(from int, topic string, msg transport.ReceivedMessage) {
+	if msg.Error != nil {
+		b.log.
+			WithError(msg.Error).
+			WithFields(msg.Fields()).
+			Warn("Unable to receive message")
+		return
+	}
+	data, err := msg.Message.MarshallBinary()
+	if err != nil {
+		b.log.
+			WithError(err).
+			WithFields(msg.Fields()).
+			Error("Unable to marshal message")
+		return
+	}
+	hash := crypto.Keccak256([]byte(topic), data)
+	if !b.markSeen(hash) {
+		b.log.
+			WithField("hash", hash.String()).
+			WithFields(msg.Fields()).
+			Debug("Message already relayed, skipping")
+		return
+	}
+	for i, t := range b.transports {
+		if i == from {
+			continue
+		}
+		if err := t.Broadcast(topic, msg.Message); err != nil {
+			b.log.
+				WithError(err).
+				WithField("hash", hash.String()).
+				WithFields(msg.Fields()).
+				Error("Unable to relay message")
+			continue
+		}
+	}
+	b.log.
+		WithField("hash", hash.String()).
+		WithFields(msg.Fields()).
+		Debug("Message relayed")
+}
+
+// markSeen marks the message hash as seen. It returns false if the hash was
+// already seen.
+func (b *Bridge) markSeen(hash types.Hash) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.seen[hash]; ok {
+		return false
+	}
+	b.seen[hash] = time.Now()
+	return true
+}
+
+// gcRoutine removes expired hashes of relayed messages.
+func (b *Bridge) gcRoutine() {
+	t := time.NewTicker(b.ttl / 2)
+	defer t.Stop()
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-t.C:
+			b.mu.Lock()
+			for hash, seenAt := range b.seen {
+				if time.Since(seenAt) > b.ttl {
+					delete(b.seen, hash)
+				}
+			}
+			b.mu.Unlock()
+		}
+	}
+}
+
+// contextCancelHandler handles context cancellation.
+func (b *Bridge) contextCancelHandler() {
+	defer func() { close(b.waitCh) }()
+	defer b.log.Info("Stopped")
+	<-b.ctx.Done()
+}
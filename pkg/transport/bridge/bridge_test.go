@@ -0,0 +1,111 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bridge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+)
+
+type testMsg struct {
+	Val string
+}
+
+func (t *testMsg) MarshallBinary() ([]byte, error) {
+	return []byte(t.Val), nil
+}
+
+func (t *testMsg) UnmarshallBinary(bytes []byte) error {
+	t.Val = string(bytes)
+	return nil
+}
+
+const testTopic = "test"
+
+// collect returns values of messages received within the given duration.
+func collect(ch <-chan transport.ReceivedMessage, d time.Duration) []string {
+	var vals []string
+	timeout := time.After(d)
+	for {
+		select {
+		case msg := <-ch:
+			vals = append(vals, msg.Message.(*testMsg).Val)
+		case <-timeout:
+			return vals
+		}
+	}
+}
+
+func setup(ctx context.Context, t *testing.T) (*local.Local, *local.Local) {
+	topics := map[string]transport.Message{testTopic: (*testMsg)(nil)}
+	a := local.New([]byte("a"), 10, topics)
+	b := local.New([]byte("b"), 10, topics)
+	require.NoError(t, a.Start(ctx))
+	require.NoError(t, b.Start(ctx))
+	br, err := New(Config{
+		Transports: []transport.Transport{a, b},
+		Topics:     []string{testTopic},
+	})
+	require.NoError(t, err)
+	require.NoError(t, br.Start(ctx))
+
+	// Wait to be sure that the bridge is subscribed.
+	time.Sleep(100 * time.Millisecond)
+	return a, b
+}
+
+func TestBridge(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	a, b := setup(ctx, t)
+	aCh := a.Messages(testTopic)
+	bCh := b.Messages(testTopic)
+
+	// Messages must be relayed in both directions, but not looped back:
+	require.NoError(t, a.Broadcast(testTopic, &testMsg{Val: "foo"}))
+	require.NoError(t, b.Broadcast(testTopic, &testMsg{Val: "bar"}))
+	assert.ElementsMatch(t, []string{"foo", "bar"}, collect(aCh, 200*time.Millisecond))
+	assert.ElementsMatch(t, []string{"foo", "bar"}, collect(bCh, 200*time.Millisecond))
+}
+
+func TestBridge_Deduplication(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	a, b := setup(ctx, t)
+	bCh := b.Messages(testTopic)
+
+	// The same message received twice must be relayed only once:
+	require.NoError(t, a.Broadcast(testTopic, &testMsg{Val: "foo"}))
+	require.NoError(t, a.Broadcast(testTopic, &testMsg{Val: "foo"}))
+	assert.Equal(t, []string{"foo"}, collect(bCh, 200*time.Millisecond))
+}
+
+func TestNew_Validation(t *testing.T) {
+	l := local.New(nil, 0, nil)
+	_, err := New(Config{Transports: []transport.Transport{l}, Topics: []string{testTopic}})
+	assert.Error(t, err)
+	_, err = New(Config{Transports: []transport.Transport{l, l}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,246 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package capture provides tools to record messages received from
+// a transport to a file and to replay them later.
+//
+// Captures are stored as newline-delimited JSON, one Record per line.
+// A capture can be replayed into the local transport to reproduce an incident
+// offline with services such as the datapoint store or the MuSig store, or
+// broadcast to a test network.
+package capture
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/chanutil"
+)
+
+const LoggerTag = "CAPTURE"
+
+// Record is a single message captured from a transport.
+type Record struct {
+	// Topic is the topic on which the message was received.
+	Topic string `json:"topic"`
+
+	// Author is the author of the message.
+	Author types.Bytes `json:"author"`
+
+	// Data is the binary representation of the message.
+	Data []byte `json:"data"`
+
+	// Meta contains information about the message provided by the
+	// transport.
+	Meta transport.Meta `json:"meta"`
+
+	// ReceivedAt is the time at which the message was received.
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// Writer writes records to an underlying writer.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriter returns a new Writer that writes records to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// Write writes a single record. It is safe to call Write concurrently.
+func (w *Writer) Write(rec Record) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(rec)
+}
+
+// Reader reads records from an underlying reader.
+type Reader struct {
+	dec *json.Decoder
+}
+
+// NewReader returns a new Reader that reads records from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{dec: json.NewDecoder(bufio.NewReader(r))}
+}
+
+// Read reads the next record. It returns io.EOF if there are no more records.
+func (r *Reader) Read() (Record, error) {
+	var rec Record
+	if err := r.dec.Decode(&rec); err != nil {
+		return Record{}, err
+	}
+	return rec, nil
+}
+
+// Capturer writes all messages received from a transport on the given topics.
+type Capturer struct {
+	ctx    context.Context
+	waitCh chan error
+	log    log.Logger
+
+	transport transport.Transport
+	topics    []string
+	writer    *Writer
+}
+
+// CapturerConfig is the configuration for the Capturer.
+type CapturerConfig struct {
+	// Transport is the transport from which messages are captured.
+	Transport transport.Transport
+
+	// Topics is the list of captured topics.
+	Topics []string
+
+	// Writer is the writer to which captured messages are written.
+	Writer *Writer
+
+	// Logger is a current logger interface used by the Capturer.
+	Logger log.Logger
+}
+
+// NewCapturer returns a new Capturer.
+func NewCapturer(cfg CapturerConfig) (*Capturer, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if cfg.Writer == nil {
+		return nil, errors.New("writer must not be nil")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Capturer{
+		waitCh:    make(chan error),
+		log:       cfg.Logger.WithField("tag", LoggerTag),
+		transport: cfg.Transport,
+		topics:    cfg.Topics,
+		writer:    cfg.Writer,
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+func (c *Capturer) Start(ctx context.Context) error {
+	if c.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	c.log.Debug("Starting")
+	c.ctx = ctx
+	sink := chanutil.NewFanIn[topicMessage]()
+	for _, topic := range c.topics {
+		ch := c.transport.Messages(topic)
+		if ch == nil {
+			return errors.New("unconfigured topic: " + topic)
+		}
+		if err := sink.Add(withTopic(ctx, topic, ch)); err != nil {
+			return err
+		}
+	}
+	go c.captureRoutine(sink.Chan())
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (c *Capturer) Wait() <-chan error {
+	return c.waitCh
+}
+
+func (c *Capturer) captureRoutine(ch <-chan topicMessage) {
+	defer func() { close(c.waitCh) }()
+	defer c.log.Debug("Stopped")
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			c.capture(msg)
+		}
+	}
+}
+
+func (c *Capturer) capture(msg topicMessage) {
+	if msg.msg.Error != nil {
+		c.log.
+			WithError(msg.msg.Error).
+			WithFields(msg.msg.Fields()).
+			Warn("Unable to receive message, skipping")
+		return
+	}
+	data, err := msg.msg.Message.MarshallBinary()
+	if err != nil {
+		c.log.
+			WithError(err).
+			WithFields(msg.msg.Fields()).
+			Error("Unable to marshal message")
+		return
+	}
+	meta := msg.msg.Meta
+	if meta.Topic == "" {
+		meta.Topic = msg.topic
+	}
+	err = c.writer.Write(Record{
+		Topic:      msg.topic,
+		Author:     msg.msg.Author,
+		Data:       data,
+		Meta:       meta,
+		ReceivedAt: time.Now(),
+	})
+	if err != nil {
+		c.log.
+			WithError(err).
+			WithFields(msg.msg.Fields()).
+			Error("Unable to write message")
+	}
+}
+
+type topicMessage struct {
+	topic string
+	msg   transport.ReceivedMessage
+}
+
+// withTopic annotates messages from the channel with the topic, because not
+// every transport sets the topic in the message metadata.
+func withTopic(ctx context.Context, topic string, ch <-chan transport.ReceivedMessage) <-chan topicMessage {
+	out := make(chan topicMessage)
+	go func() {
+		defer close(out)
+		for msg := range ch {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- topicMessage{topic: topic, msg: msg}:
+			}
+		}
+	}()
+	return out
+}
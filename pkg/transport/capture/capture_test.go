@@ -0,0 +1,210 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/relay"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+var (
+	testFeed = types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+
+	testMessages = transport.MessageMap{
+		messages.DataPointV1MessageName:      (*messages.DataPoint)(nil),
+		messages.MuSigSignatureV1MessageName: (*messages.MuSigSignature)(nil),
+	}
+
+	testDataPoint = &messages.DataPoint{
+		Model: "ETH/USD",
+		Value: datapoint.Point{
+			Value: value.StaticValue{Value: bn.Float(1)},
+			Time:  time.Unix(1234567890, 0),
+			Meta:  map[string]any{"addr": testFeed.String()},
+		},
+		Signature: types.MustSignatureFromBytes(bytes.Repeat([]byte{0x01}, 65)),
+	}
+
+	testSignature = &messages.MuSigSignature{
+		SessionID:        types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone),
+		ComputedAt:       time.Unix(1234567890, 0),
+		MsgType:          "median",
+		MsgBody:          types.MustHashFromHex("0x3333333333333333333333333333333333333333333333333333333333333333", types.PadNone),
+		MsgMeta:          map[string][]byte{"wat": []byte("ETHUSD")},
+		Commitment:       testFeed,
+		Signers:          []types.Address{testFeed},
+		SchnorrSignature: big.NewInt(1),
+	}
+)
+
+func TestCaptureReplay(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	// Capture messages from the source transport:
+	src := local.New(testFeed.Bytes(), 10, testMessages)
+	require.NoError(t, src.Start(ctx))
+
+	// Wait to be sure that the transport is ready.
+	time.Sleep(100 * time.Millisecond)
+
+	buf := &syncBuffer{}
+	capturer, err := NewCapturer(CapturerConfig{
+		Transport: src,
+		Topics:    testMessages.Keys(),
+		Writer:    NewWriter(buf),
+	})
+	require.NoError(t, err)
+	require.NoError(t, capturer.Start(ctx))
+
+	require.NoError(t, src.Broadcast(messages.DataPointV1MessageName, testDataPoint))
+	require.NoError(t, src.Broadcast(messages.MuSigSignatureV1MessageName, testSignature))
+	require.Eventually(t, func() bool {
+		return bytes.Count(buf.Bytes(), []byte("\n")) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// Replay captured messages to the datapoint and MuSig stores:
+	dst := local.New(nil, 10, testMessages)
+	require.NoError(t, dst.Start(ctx))
+
+	pointStore, err := store.New(store.Config{
+		Storage:    store.NewMemoryStorage(),
+		Transport:  dst,
+		Models:     []string{"ETH/USD"},
+		Recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, pointStore.Start(ctx))
+
+	muSigStore := relay.NewMuSigStore(relay.MuSigStoreConfig{
+		Transport:        dst,
+		ScribeDataModels: []string{"ETHUSD"},
+		Logger:           null.New(),
+	})
+	require.NoError(t, muSigStore.Start(ctx))
+
+	// Wait to be sure that the stores are subscribed.
+	time.Sleep(100 * time.Millisecond)
+
+	n, err := Replay(ctx, ReplayConfig{
+		Reader:    NewReader(bytes.NewReader(buf.Bytes())),
+		Transport: dst,
+		Messages:  testMessages,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	require.Eventually(t, func() bool {
+		points, err := pointStore.Latest(ctx, "ETH/USD")
+		return err == nil && len(points) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return len(muSigStore.SignaturesByDataModel("ETHUSD")) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, testSignature.SessionID, muSigStore.SignaturesByDataModel("ETHUSD")[0].SessionID)
+}
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+func TestReplay_Speed(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	buf := &bytes.Buffer{}
+	w := NewWriter(buf)
+	data, err := testDataPoint.MarshallBinary()
+	require.NoError(t, err)
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.Write(Record{
+			Topic:      messages.DataPointV1MessageName,
+			Author:     testFeed.Bytes(),
+			Data:       data,
+			ReceivedAt: now.Add(time.Duration(i) * 200 * time.Millisecond),
+		}))
+	}
+
+	dst := local.New(nil, 10, testMessages)
+	require.NoError(t, dst.Start(ctx))
+
+	// At the double speed, the replay should take about 200ms instead
+	// of 400ms:
+	startedAt := time.Now()
+	n, err := Replay(ctx, ReplayConfig{
+		Reader:    NewReader(bytes.NewReader(buf.Bytes())),
+		Transport: dst,
+		Messages:  testMessages,
+		Speed:     2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.GreaterOrEqual(t, time.Since(startedAt), 200*time.Millisecond)
+	assert.Less(t, time.Since(startedAt), 400*time.Millisecond)
+}
+
+func TestReplay_UnsupportedTopic(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, NewWriter(buf).Write(Record{Topic: "unknown", Data: []byte{0x01}}))
+
+	dst := local.New(nil, 10, testMessages)
+	require.NoError(t, dst.Start(ctx))
+
+	n, err := Replay(ctx, ReplayConfig{
+		Reader:    NewReader(bytes.NewReader(buf.Bytes())),
+		Transport: dst,
+		Messages:  testMessages,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
@@ -0,0 +1,134 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+)
+
+// ReplayConfig is the configuration for the Replay function.
+type ReplayConfig struct {
+	// Reader is the reader from which records are read.
+	Reader *Reader
+
+	// Transport is the transport to which messages are broadcast. If the
+	// transport is the local transport, messages are broadcast on behalf of
+	// their original authors. Other transports use their own identity.
+	Transport transport.Transport
+
+	// Messages is the map of topics and message types used to decode
+	// records. Records with topics that are not on the map are skipped.
+	Messages transport.MessageMap
+
+	// Speed is the replay speed relative to the original speed, e.g. 2 replays
+	// messages twice as fast. If zero, messages are replayed without delays.
+	Speed float64
+
+	// Logger is a current logger interface used by the Replay function.
+	Logger log.Logger
+}
+
+// Replay broadcasts all records from the reader using the given transport.
+// It returns the number of replayed messages.
+func Replay(ctx context.Context, cfg ReplayConfig) (int, error) {
+	if cfg.Reader == nil {
+		return 0, errors.New("reader must not be nil")
+	}
+	if cfg.Transport == nil {
+		return 0, errors.New("transport must not be nil")
+	}
+	if cfg.Speed < 0 {
+		return 0, errors.New("speed must not be negative")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	logger := cfg.Logger.WithField("tag", LoggerTag)
+	var (
+		count     int
+		startedAt time.Time
+		firstAt   time.Time
+	)
+	for {
+		rec, err := cfg.Reader.Read()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("unable to read record: %w", err)
+		}
+		typ, ok := cfg.Messages[rec.Topic]
+		if !ok {
+			logger.
+				WithField("topic", rec.Topic).
+				Warn("Unsupported topic, skipping")
+			continue
+		}
+		msg := reflect.New(reflect.TypeOf(typ).Elem()).Interface().(transport.Message)
+		if err := msg.UnmarshallBinary(rec.Data); err != nil {
+			logger.
+				WithError(err).
+				WithField("topic", rec.Topic).
+				WithField("messageID", rec.Meta.MessageID).
+				Warn("Unable to decode message, skipping")
+			continue
+		}
+		if count == 0 {
+			startedAt, firstAt = time.Now(), rec.ReceivedAt
+		}
+		if cfg.Speed > 0 {
+			offset := time.Duration(float64(rec.ReceivedAt.Sub(firstAt)) / cfg.Speed)
+			if err := sleepUntil(ctx, startedAt.Add(offset)); err != nil {
+				return count, err
+			}
+		} else if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+		t := cfg.Transport
+		if l, ok := t.(*local.Local); ok {
+			t = l.WithAuthor(rec.Author)
+		}
+		if err := t.Broadcast(rec.Topic, msg); err != nil {
+			return count, fmt.Errorf("unable to broadcast message: %w", err)
+		}
+		count++
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -24,6 +24,10 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
 )
 
+// peerScoreKey is the peerstore metadata key under which the last known
+// peer score is stored.
+const peerScoreKey = "oracle-suite/score"
+
 // PeerScoring configures peer scoring parameters used in a pubsub system.
 func PeerScoring(
 	params *pubsub.PeerScoreParams,
@@ -36,6 +40,12 @@ func PeerScoring(
 			pubsub.WithPeerScore(params, thresholds),
 			pubsub.WithPeerScoreInspect(func(m map[peer.ID]*pubsub.PeerScoreSnapshot) {
 				for id, ps := range m {
+					if err := n.peerstore.Put(id, peerScoreKey, ps.Score); err != nil {
+						n.tsLog.get().
+							WithError(err).
+							WithField("peerID", id).
+							Warn("Unable to store peer score")
+					}
 					n.tsLog.get().
 						WithField("peerID", id).
 						WithField("score", ps).
@@ -0,0 +1,320 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
+)
+
+const (
+	defaultPeerstoreMaxPeers     = 500
+	defaultPeerstoreTTL          = 7 * 24 * time.Hour
+	defaultPeerstoreSaveInterval = 5 * time.Minute
+
+	// maxConcurrentRedials is the maximum number of peers dialed at the same
+	// time after the node starts.
+	maxConcurrentRedials = 10
+)
+
+// PeerstoreConfig is a configuration for the PersistentPeerstore option.
+type PeerstoreConfig struct {
+	// Path is the path to the file in which known peers are saved.
+	Path string
+	// MaxPeers is the maximum number of peers saved to the file. Peers with
+	// the highest score are kept. If zero, 500 peers are kept.
+	MaxPeers int
+	// TTL is the time after which peers that were not seen are removed from
+	// the file. If zero, peers are kept for a week.
+	TTL time.Duration
+	// SaveInterval is the interval at which peers are saved. Peers are also
+	// saved when the node stops. If zero, peers are saved every 5 minutes.
+	SaveInterval time.Duration
+}
+
+// storedPeer is a peer saved in the peerstore file.
+type storedPeer struct {
+	ID       peer.ID   `json:"id"`
+	Addrs    []string  `json:"addrs"`
+	Score    float64   `json:"score"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type peerstoreFile struct {
+	Peers []storedPeer `json:"peers"`
+}
+
+type persistentPeerstore struct {
+	mu    sync.Mutex
+	cfg   PeerstoreConfig
+	peers map[peer.ID]storedPeer
+}
+
+// PersistentPeerstore saves known peers, their addresses and scores to a
+// file, so they can be redialed after a restart without relying on the
+// bootstrap nodes.
+//
+// Saved scores are not restored into the pubsub peer scoring, peers start
+// with a fresh score after a restart. They are only used to decide which
+// peers are kept in the file and in which order they are redialed. Peers
+// with a negative score are not redialed.
+//
+// Only peers the node was connected to are saved. Peers that were not seen
+// for longer than the TTL are removed, and the number of saved peers is
+// limited to MaxPeers.
+func PersistentPeerstore(cfg PeerstoreConfig) Options {
+	return func(n *Node) error {
+		if cfg.MaxPeers == 0 {
+			cfg.MaxPeers = defaultPeerstoreMaxPeers
+		}
+		if cfg.TTL == 0 {
+			cfg.TTL = defaultPeerstoreTTL
+		}
+		if cfg.SaveInterval == 0 {
+			cfg.SaveInterval = defaultPeerstoreSaveInterval
+		}
+		ps := &persistentPeerstore{cfg: cfg, peers: make(map[peer.ID]storedPeer)}
+		if err := ps.load(); err != nil {
+			n.tsLog.get().
+				WithError(err).
+				WithField("path", cfg.Path).
+				Warn("Unable to load peerstore, starting with an empty one")
+		}
+		for _, p := range ps.peers {
+			n.peerstore.AddAddrs(p.ID, parseAddrs(p.Addrs), peerstore.AddressTTL)
+		}
+		n.tsLog.get().
+			WithField("path", cfg.Path).
+			WithField("count", len(ps.peers)).
+			Info("Peerstore loaded")
+		save := func() {
+			ps.update(n)
+			if err := ps.save(); err != nil {
+				n.tsLog.get().
+					WithError(err).
+					WithField("path", cfg.Path).
+					Warn("Unable to save peerstore")
+			}
+		}
+		n.AddNodeEventHandler(sets.NodeEventHandlerFunc(func(event interface{}) {
+			switch event.(type) {
+			case sets.NodeStartedEvent:
+				go ps.redial(n)
+				go func() {
+					t := time.NewTicker(cfg.SaveInterval)
+					defer t.Stop()
+					for {
+						select {
+						case <-n.ctx.Done():
+							return
+						case <-t.C:
+							save()
+						}
+					}
+				}()
+			case sets.NodeStoppingEvent:
+				save()
+			}
+		}))
+		return nil
+	}
+}
+
+// redial connects to saved peers, starting with the highest score. Peers
+// with a negative score are skipped.
+func (p *persistentPeerstore) redial(n *Node) {
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, maxConcurrentRedials)
+	)
+	for _, sp := range p.list() {
+		if sp.Score < 0 || sp.ID == n.host.ID() {
+			continue
+		}
+		if n.host.Network().Connectedness(sp.ID) == network.Connected {
+			continue
+		}
+		select {
+		case <-n.ctx.Done():
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(sp storedPeer) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := n.host.Connect(n.ctx, peer.AddrInfo{ID: sp.ID, Addrs: parseAddrs(sp.Addrs)})
+			if err != nil {
+				n.tsLog.get().
+					WithError(err).
+					WithField("peerID", sp.ID.String()).
+					Debug("Unable to connect to the saved peer")
+			}
+		}(sp)
+	}
+	wg.Wait()
+}
+
+// update updates saved peers using connected peers and their scores.
+//
+// Scores of saved peers that are no longer connected are updated as well,
+// so a peer that was penalized before it disconnected is not redialed.
+func (p *persistentPeerstore) update(n *Node) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	for id, sp := range p.peers {
+		if score, ok := lastPeerScore(n, id); ok {
+			sp.Score = score
+			p.peers[id] = sp
+		}
+	}
+	for _, id := range n.host.Network().Peers() {
+		if n.host.Network().Connectedness(id) != network.Connected {
+			continue
+		}
+		addrs := n.peerstore.Addrs(id)
+		if len(addrs) == 0 {
+			continue
+		}
+		sp := storedPeer{ID: id, LastSeen: now, Score: p.peers[id].Score}
+		for _, addr := range addrs {
+			sp.Addrs = append(sp.Addrs, addr.String())
+		}
+		p.peers[id] = sp
+	}
+	p.prune(now)
+}
+
+// lastPeerScore returns the last known pubsub score of the peer, if any.
+func lastPeerScore(n *Node, id peer.ID) (float64, bool) {
+	score, err := n.peerstore.Get(id, peerScoreKey)
+	if err != nil {
+		return 0, false
+	}
+	f, ok := score.(float64)
+	return f, ok
+}
+
+// prune removes expired peers and peers with the lowest score above
+// the MaxPeers limit.
+func (p *persistentPeerstore) prune(now time.Time) {
+	for id, sp := range p.peers {
+		if now.Sub(sp.LastSeen) > p.cfg.TTL {
+			delete(p.peers, id)
+		}
+	}
+	if len(p.peers) <= p.cfg.MaxPeers {
+		return
+	}
+	for _, sp := range p.sorted()[p.cfg.MaxPeers:] {
+		delete(p.peers, sp.ID)
+	}
+}
+
+// list returns saved peers sorted by score.
+func (p *persistentPeerstore) list() []storedPeer {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sorted()
+}
+
+// sorted returns saved peers sorted by score and then by the time they
+// were last seen, in descending order.
+func (p *persistentPeerstore) sorted() []storedPeer {
+	peers := make([]storedPeer, 0, len(p.peers))
+	for _, sp := range p.peers {
+		peers = append(peers, sp)
+	}
+	sort.Slice(peers, func(i, j int) bool {
+		if peers[i].Score != peers[j].Score {
+			return peers[i].Score > peers[j].Score
+		}
+		return peers[i].LastSeen.After(peers[j].LastSeen)
+	})
+	return peers
+}
+
+// load reads saved peers from the file. A missing file is not an error.
+func (p *persistentPeerstore) load() error {
+	b, err := os.ReadFile(p.cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var f peerstoreFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, sp := range f.Peers {
+		if sp.ID.Validate() != nil || len(sp.Addrs) == 0 {
+			continue
+		}
+		p.peers[sp.ID] = sp
+	}
+	p.prune(time.Now())
+	return nil
+}
+
+// save writes saved peers to the file. The file is replaced atomically,
+// so it is never left partially written.
+func (p *persistentPeerstore) save() error {
+	b, err := json.Marshal(peerstoreFile{Peers: p.list()})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.cfg.Path), filepath.Base(p.cfg.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p.cfg.Path)
+}
+
+func parseAddrs(strs []string) []multiaddr.Multiaddr {
+	var addrs []multiaddr.Multiaddr
+	for _, s := range strs {
+		addr, err := multiaddr.NewMultiaddr(s)
+		if err != nil {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
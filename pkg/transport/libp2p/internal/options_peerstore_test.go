@@ -0,0 +1,156 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNode_PersistentPeerstore(t *testing.T) {
+	// This test checks if peers are saved when the node stops and redialed
+	// when the node with the same peerstore file starts again.
+
+	peers, err := getNodeInfo(3)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	path := filepath.Join(t.TempDir(), "peers.json")
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+
+	// Connect to the n1 and stop the node, peers should be saved:
+	n0ctx, n0ctxCancel := context.WithCancel(ctx)
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		PersistentPeerstore(PeerstoreConfig{Path: path}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(n0ctx))
+	require.NoError(t, n0.Connect(peers[1].PeerAddrs[0]))
+	n0ctxCancel()
+	<-n0.Wait()
+
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	// A new node should connect to the n1 using the saved peerstore:
+	n2, err := NewNode(
+		PeerPrivKey(peers[2].PrivKey),
+		ListenAddrs(peers[2].ListenAddrs),
+		PersistentPeerstore(PeerstoreConfig{Path: path}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n2.Start(ctx))
+	waitFor(t, func() bool {
+		return n2.Host().Network().Connectedness(peers[1].ID) == network.Connected
+	})
+}
+
+func TestPersistentPeerstore_prune(t *testing.T) {
+	now := time.Now()
+	ps := &persistentPeerstore{
+		cfg: PeerstoreConfig{MaxPeers: 2, TTL: time.Hour},
+		peers: map[peer.ID]storedPeer{
+			"a": {ID: "a", Score: 1, LastSeen: now},
+			"b": {ID: "b", Score: 2, LastSeen: now},
+			"c": {ID: "c", Score: 3, LastSeen: now.Add(-2 * time.Hour)}, // expired
+			"d": {ID: "d", Score: -1, LastSeen: now},
+		},
+	}
+	ps.prune(now)
+	list := ps.list()
+	require.Len(t, list, 2)
+	assert.Equal(t, peer.ID("b"), list[0].ID)
+	assert.Equal(t, peer.ID("a"), list[1].ID)
+}
+
+func TestPersistentPeerstore_saveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "peers.json")
+	cfg := PeerstoreConfig{Path: path, MaxPeers: 10, TTL: time.Hour}
+	peers, err := getNodeInfo(1)
+	require.NoError(t, err)
+
+	ps := &persistentPeerstore{cfg: cfg, peers: map[peer.ID]storedPeer{
+		peers[0].ID: {
+			ID:       peers[0].ID,
+			Addrs:    []string{peers[0].ListenAddrs[0].String()},
+			Score:    5,
+			LastSeen: time.Now(),
+		},
+	}}
+	require.NoError(t, ps.save())
+
+	loaded := &persistentPeerstore{cfg: cfg, peers: map[peer.ID]storedPeer{}}
+	require.NoError(t, loaded.load())
+	require.Len(t, loaded.peers, 1)
+	assert.Equal(t, 5.0, loaded.peers[peers[0].ID].Score)
+	assert.Equal(t, []string{peers[0].ListenAddrs[0].String()}, loaded.peers[peers[0].ID].Addrs)
+
+	// Missing file is not an error:
+	missing := &persistentPeerstore{cfg: PeerstoreConfig{Path: path + ".missing"}, peers: map[peer.ID]storedPeer{}}
+	require.NoError(t, missing.load())
+	assert.Empty(t, missing.peers)
+}
+
+func TestPersistentPeerstore_update(t *testing.T) {
+	// A peer that was penalized before it disconnected must not be
+	// redialed, so its score must be updated even if it is not connected.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+	require.NoError(t, n0.Peerstore().Put(peers[1].ID, peerScoreKey, -5.0))
+
+	ps := &persistentPeerstore{
+		cfg: PeerstoreConfig{MaxPeers: 10, TTL: time.Hour},
+		peers: map[peer.ID]storedPeer{
+			peers[1].ID: {
+				ID:       peers[1].ID,
+				Addrs:    []string{peers[1].ListenAddrs[0].String()},
+				Score:    1,
+				LastSeen: time.Now(),
+			},
+		},
+	}
+	ps.update(n0)
+	assert.Equal(t, -5.0, ps.peers[peers[1].ID].Score)
+}
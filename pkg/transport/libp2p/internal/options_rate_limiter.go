@@ -44,6 +44,20 @@ type RateLimiterConfig struct {
 	// RelayBurstSize is a burst value in bytes for a messages relayed by
 	// a singe peer.
 	RelayBurstSize int
+	// TopicLimits are limits of the number of messages on a topic that can
+	// be created by a single peer. Topics that are not in the map are
+	// limited only by the number of bytes.
+	TopicLimits map[string]TopicRateLimit
+}
+
+// TopicRateLimit is a limit of the number of messages on a topic.
+type TopicRateLimit struct {
+	// MessagesPerSecond is the maximum rate of messages that can be created
+	// by a single peer.
+	MessagesPerSecond float64
+	// BurstSize is a burst value in messages for a messages created from
+	// a singe peer.
+	BurstSize int
 }
 
 type rateLimiter struct {
@@ -119,6 +133,12 @@ func RateLimiter(cfg RateLimiterConfig) Options {
 		relayRL := newRateLimiter(cfg.RelayBytesPerSecond, cfg.RelayBurstSize)
 		// Rate limiter for message authors:
 		msgRL := newRateLimiter(cfg.BytesPerSecond, cfg.BurstSize)
+		// Rate limiters for message authors on topics, these count
+		// messages instead of bytes:
+		topicRLs := make(map[string]*rateLimiter, len(cfg.TopicLimits))
+		for topic, l := range cfg.TopicLimits {
+			topicRLs[topic] = newRateLimiter(l.MessagesPerSecond, l.BurstSize)
+		}
 		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
 			if n.Host().ID() == id {
 				return pubsub.ValidationAccept
@@ -150,6 +170,18 @@ func RateLimiter(cfg RateLimiterConfig) Options {
 					Debug("The message has been rejected, rate limit for message author exceeded")
 				return pubsub.ValidationReject
 			}
+			if topicRL, ok := topicRLs[topic]; ok && !topicRL.allow(msg.GetFrom(), 1) {
+				n.tsLog.get().
+					WithFields(log.Fields{
+						"topic":                topic,
+						"peerID":               msg.GetFrom().String(),
+						"peerAddr":             ethkey.PeerIDToAddress(msg.GetFrom()).String(),
+						"receivedFromPeerID":   msg.ReceivedFrom.String(),
+						"receivedFromPeerAddr": ethkey.PeerIDToAddress(msg.ReceivedFrom).String(),
+					}).
+					Debug("The message has been rejected, topic rate limit for message author exceeded")
+				return pubsub.ValidationReject
+			}
 			return pubsub.ValidationAccept
 		})
 		n.AddNodeEventHandler(sets.NodeEventHandlerFunc(func(event interface{}) {
@@ -163,6 +195,10 @@ func RateLimiter(cfg RateLimiterConfig) Options {
 							return
 						case <-t.C:
 							relayRL.gc()
+							msgRL.gc()
+							for _, rl := range topicRLs {
+								rl.gc()
+							}
 						}
 					}
 				}()
@@ -148,3 +148,65 @@ func TestNode_RateLimiter_PeerBurst(t *testing.T) {
 	// Only one message should arrive, second one exceeds the peer limit:
 	assert.Equal(t, 1, (<-msgsCh)[n1.Host().ID()])
 }
+
+func TestNode_RateLimiter_TopicLimit(t *testing.T) {
+	// This test checks if topic limits works correctly. The byte limits are
+	// high enough to accept all messages, but the topic limit allows only
+	// one message per second. We will try to send two messages, only the
+	// first one should be accepted.
+
+	peers, err := getNodeInfo(2)
+	require.NoError(t, err)
+
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	n0, err := NewNode(
+		PeerPrivKey(peers[0].PrivKey),
+		ListenAddrs(peers[0].ListenAddrs),
+		RateLimiter(RateLimiterConfig{
+			BytesPerSecond:      1024,
+			BurstSize:           1024,
+			RelayBytesPerSecond: 1024,
+			RelayBurstSize:      1024,
+			TopicLimits: map[string]TopicRateLimit{
+				"test": {MessagesPerSecond: 1, BurstSize: 1},
+			},
+		}),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n0.Start(ctx))
+	time.Sleep(time.Second)
+
+	n1, err := NewNode(
+		PeerPrivKey(peers[1].PrivKey),
+		ListenAddrs(peers[1].ListenAddrs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, n1.Start(ctx))
+	time.Sleep(time.Second)
+
+	require.NoError(t, n1.Connect(peers[0].PeerAddrs[0]))
+	_, err = n0.Subscribe("test")
+	require.NoError(t, err)
+	_, err = n1.Subscribe("test")
+	require.NoError(t, err)
+
+	s1, err := n0.Subscription("test")
+	require.NoError(t, err)
+	s2, err := n1.Subscription("test")
+	require.NoError(t, err)
+
+	// Wait for the peers to connect to each other:
+	waitFor(t, func() bool {
+		return len(n0.PubSub().ListPeers("test")) > 0 && len(n1.PubSub().ListPeers("test")) > 0
+	})
+
+	// Send messages:
+	msgsCh := countMessages(s1, 2*time.Second)
+	require.NoError(t, s2.Publish([]byte("a")))
+	require.NoError(t, s2.Publish([]byte("b"))) // exceeds limit
+
+	// Only one message should arrive, second one exceeds the topic limit:
+	assert.Equal(t, 1, (<-msgsCh)[n1.Host().ID()])
+}
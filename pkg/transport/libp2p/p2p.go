@@ -20,6 +20,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"time"
 
 	cryptoETH "github.com/defiweb/go-eth/crypto"
@@ -31,12 +32,12 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/crypto/ethkey"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal"
-	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/chanutil"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 )
@@ -67,6 +68,7 @@ const minAssetPairs = 10                 // below that, score becomes negative
 const maxAssetPairs = 100                // it limits the maximum possible score only, not the number of supported pairs
 const minEventsPerSecond = 0.1           // below that, score becomes negative
 const maxEventsPerSecond = 1             // it limits the maximum possible score only, not the number of events
+const maxRelays = 5                      // maximum number of relays that start MuSig sessions for the same asset pair
 const maxInvalidMsgsPerHour float64 = 60 // per topic
 
 // Timeout has to be a little longer because signing messages using
@@ -128,14 +130,39 @@ type Config struct {
 	// these addresses will be accepted.
 	AuthorAllowlist []types.Address
 
+	// BridgeAllowlist is a list of bridges allowed to relay messages signed
+	// by authors from the AuthorAllowlist. Bridges are not counted as feeds.
+	// Messages from bridges are accepted only on the transport.BridgeTopics.
+	BridgeAllowlist []types.Address
+
 	// Discovery indicates whenever peer discovery should be enabled.
 	// If discovery is disabled, then DirectPeersAddrs must be used
 	// to connect to the network. Always enabled in bootstrap mode.
 	Discovery bool
 
+	// PeerstorePath is a path to the file in which known peers, their
+	// addresses and scores are saved. Saved peers are redialed after
+	// a restart, except for peers with a negative score. Saved scores are
+	// not restored into the peer scoring. If empty, peers are not saved.
+	// Ignored in bootstrap mode.
+	PeerstorePath string
+
+	// PeerstoreMaxPeers is the maximum number of peers saved in the
+	// peerstore file. If zero, the default value is used.
+	PeerstoreMaxPeers int
+
+	// PeerstoreTTL is the time after which peers that were not seen are
+	// removed from the peerstore file. If zero, the default value is used.
+	PeerstoreTTL time.Duration
+
 	// Signer used to verify price messages. Ignored in bootstrap mode.
 	Signer wallet.Key
 
+	// Recoverers are used to verify signatures of data point messages.
+	// Data points not supported by any of the recoverers are not verified.
+	// Ignored in bootstrap mode.
+	Recoverers []datapoint.Recoverer
+
 	// Logger is a custom logger instance. If not provided then null
 	// logger is used.
 	Logger log.Logger
@@ -213,6 +240,11 @@ func New(cfg Config) (*P2P, error) {
 			WithField("addr", addr.String()).
 			Info("Feed")
 	}
+	for _, addr := range cfg.BridgeAllowlist {
+		logger.
+			WithField("addr", addr.String()).
+			Info("Bridge")
+	}
 	for _, addr := range cfg.BootstrapAddrs {
 		logger.
 			WithField("addr", addr).
@@ -221,30 +253,21 @@ func New(cfg Config) (*P2P, error) {
 
 	switch cfg.Mode {
 	case ClientMode:
-		priceTopicScoreParams, err := calculatePriceTopicScoreParams(cfg)
-		if err != nil {
-			return nil, fmt.Errorf("P2P transport error: invalid price topic scoring parameters: %w", err)
-		}
-		eventTopicScoreParams, err := calculateEventTopicScoreParams(cfg)
+		topicScoreParams, err := calculateTopicScoreParams(cfg)
 		if err != nil {
-			return nil, fmt.Errorf("P2P transport error: invalid event topic scoring parameters: %w", err)
+			return nil, fmt.Errorf("P2P transport error: invalid topic scoring parameters: %w", err)
 		}
 		opts = append(opts,
 			internal.MessageLogger(),
 			internal.RateLimiter(rateLimiterConfig(cfg)),
-			internal.PeerScoring(peerScoreParams, thresholds, func(topic string) *pubsub.TopicScoreParams {
-				if topic == messages.PriceV0MessageName || topic == messages.PriceV1MessageName { //nolint:staticcheck
-					return priceTopicScoreParams
-				}
-				if topic == messages.EventV1MessageName {
-					return eventTopicScoreParams
-				}
-				return nil
+			internal.PeerScoring(peerScoreParams, calculateThresholds(cfg, topicScoreParams), func(topic string) *pubsub.TopicScoreParams {
+				return topicScoreParams[topic]
 			}),
 			messageValidator(cfg.Topics, logger), // must be registered before any other validator
-			feedValidator(cfg.AuthorAllowlist, logger),
+			feedValidator(cfg.AuthorAllowlist, cfg.BridgeAllowlist, logger),
 			eventValidator(logger),
-			priceValidator(logger, cryptoETH.ECRecoverer),
+			priceValidator(logger, cryptoETH.ECRecoverer, cfg.AuthorAllowlist, cfg.BridgeAllowlist),
+			dataPointValidator(logger, cfg.Recoverers, cfg.AuthorAllowlist, cfg.BridgeAllowlist),
 		)
 		if cfg.MessagePrivKey != nil {
 			opts = append(opts, internal.MessagePrivKey(cfg.MessagePrivKey))
@@ -252,6 +275,13 @@ func New(cfg Config) (*P2P, error) {
 		if cfg.Discovery {
 			opts = append(opts, internal.Discovery(bootstrapAddrs))
 		}
+		if cfg.PeerstorePath != "" {
+			opts = append(opts, internal.PersistentPeerstore(internal.PeerstoreConfig{
+				Path:     cfg.PeerstorePath,
+				MaxPeers: cfg.PeerstoreMaxPeers,
+				TTL:      cfg.PeerstoreTTL,
+			}))
+		}
 	case BootstrapMode:
 		opts = append(opts,
 			internal.DisablePubSub(),
@@ -376,10 +406,21 @@ func strsToMaddrs(addrs []string) ([]core.Multiaddr, error) {
 func rateLimiterConfig(cfg Config) internal.RateLimiterConfig {
 	bytesPerSecond := maxBytesPerSecond
 	burstSize := maxBytesPerSecond * priceUpdateInterval.Seconds()
+	// Messages created during a single price update interval may be sent
+	// at once, so the burst size for every topic is equal to the number of
+	// messages expected during that interval.
+	topicLimits := make(map[string]internal.TopicRateLimit, len(topicRates))
+	for topic, r := range topicRates {
+		topicLimits[topic] = internal.TopicRateLimit{
+			MessagesPerSecond: r.maxMessagesPerSecond,
+			BurstSize:         int(math.Max(1, math.Ceil(r.maxMessagesPerSecond*priceUpdateInterval.Seconds()))),
+		}
+	}
 	return internal.RateLimiterConfig{
 		BytesPerSecond:      maxBytesPerSecond / float64(len(cfg.AuthorAllowlist)),
 		BurstSize:           int(burstSize / float64(len(cfg.AuthorAllowlist))),
 		RelayBytesPerSecond: bytesPerSecond,
 		RelayBurstSize:      int(burstSize),
+		TopicLimits:         topicLimits,
 	}
 }
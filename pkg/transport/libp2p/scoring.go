@@ -17,11 +17,14 @@ package libp2p
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
 // Peer scoring:
@@ -30,17 +33,10 @@ import (
 const decayInterval = time.Minute
 const decayToZero = 0.01
 
-var thresholds = &pubsub.PeerScoreThresholds{
-	// -4000 is sum of P₃ and P₃b for the "price" and "event" topics. It should
-	// be equal to the lowest score a silent peer can get without receiving any
-	// penalties other than P₃ and P₃b. Because only very few peers can produce
-	// messages, some honest peers may have a score equal to this number.
-	GossipThreshold:             -4000,
-	PublishThreshold:            -4000,
-	GraylistThreshold:           -4000,
-	AcceptPXThreshold:           0,
-	OpportunisticGraftThreshold: 0,
-}
+// minScoreThreshold is the score threshold used in addition to P₃ and P₃b
+// penalties of the subscribed topics. It leaves room for other penalties,
+// like the IP colocation factor.
+const minScoreThreshold float64 = -1000
 
 var peerScoreParams = &pubsub.PeerScoreParams{
 	AppSpecificScore:            func(id peer.ID) float64 { return 0 },
@@ -56,61 +52,192 @@ var peerScoreParams = &pubsub.PeerScoreParams{
 	Topics:                      make(map[string]*pubsub.TopicScoreParams),
 }
 
-func calculatePriceTopicScoreParams(cfg Config) (*pubsub.TopicScoreParams, error) {
-	var maxPeers = float64(pubsub.GossipSubDhi)
-	// Minimum and maximum expected number of feeds connected to the network:
-	var minFeedCount = float64(len(cfg.AuthorAllowlist)) / 2 // assume that 50% of feeds are offline
-	var maxFeedCount = float64(len(cfg.AuthorAllowlist))
-	// Minimum and maximum expected number of messages to be received from a single peer in a mesh:
-	var minMsgsPerSecond = (minFeedCount * minAssetPairs) / maxPeers / priceUpdateInterval.Seconds()
-	var maxMsgsPerSecond = (maxFeedCount * maxAssetPairs) / priceUpdateInterval.Seconds()
-
-	//nolint:gomnd
-	return (&scoreParams{
-		p1Score:              500,
-		p2Score:              500,
-		p3Score:              -1000,
-		p3bScore:             -1000,
-		p4Score:              -1000,
-		p1Length:             15 * time.Minute,
-		p2Length:             15 * time.Minute,
-		p3Length:             15 * time.Minute,
-		p3bLength:            15 * time.Minute,
-		p4Length:             time.Hour,
-		minMessagesPerSecond: minMsgsPerSecond,
-		maxMessagesPerSecond: maxMsgsPerSecond,
-		maxInvalidMessages:   maxInvalidMsgsPerHour,
-	}).calculate()
+// topicRate describes the expected rate of messages created by a single
+// author on a topic.
+type topicRate struct {
+	// minMessagesPerSecond is the expected minimum rate of messages. Below
+	// that, the score of a peer becomes negative, unless the topic is
+	// sporadic.
+	minMessagesPerSecond float64
+
+	// maxMessagesPerSecond limits the maximum possible score and the number
+	// of messages accepted from a single author.
+	maxMessagesPerSecond float64
+
+	// scoreLength is the time after which the P₁, P₂ and P₃ scores reach
+	// their maximum values.
+	scoreLength time.Duration
+
+	// sporadic topics do not have a steady flow of messages, so peers are
+	// not penalized for not delivering them (P₃ and P₃b).
+	sporadic bool
 }
 
-func calculateEventTopicScoreParams(cfg Config) (*pubsub.TopicScoreParams, error) {
-	// NOTE: The scoring parameters for events are just guesses at the moment, we will have to update them when we
-	// know how many events we can expect.
+// topicRates contains expected rates of messages for every topic in the
+// transport.AllMessagesMap.
+//
+//nolint:gomnd
+var topicRates = map[string]topicRate{
+	messages.PriceV0MessageName: { //nolint:staticcheck
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+	},
+	messages.PriceV1MessageName: { //nolint:staticcheck
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+	},
+	messages.DataPointV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+	},
+	messages.DataPointV2MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	// Batches are sent at most once per price update interval, but only by
+	// feeds that have batching enabled.
+	messages.DataPointBatchV1MessageName: {
+		minMessagesPerSecond: 1 / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	// NOTE: The rates for events are just guesses at the moment, we will
+	// have to update them when we know how many events we can expect.
+	messages.EventV1MessageName: {
+		minMessagesPerSecond: minEventsPerSecond,
+		maxMessagesPerSecond: maxEventsPerSecond,
+		scoreLength:          120 * time.Minute,
+	},
+	messages.GreetV1MessageName: {
+		minMessagesPerSecond: 1 / time.Hour.Seconds(),
+		maxMessagesPerSecond: 1 / time.Minute.Seconds(),
+		scoreLength:          120 * time.Minute,
+		sporadic:             true,
+	},
+	// MuSig sessions are started by relays, at most once per price update
+	// interval for every asset pair and relay. Feeds send one commitment
+	// and one partial signature per session.
+	messages.MuSigStartV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.MuSigTerminateV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.MuSigCommitmentV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxRelays * maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.MuSigPartialSignatureV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxRelays * maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.MuSigSignatureV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.MuSigOptimisticSignatureV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+	messages.RelayIntentV1MessageName: {
+		minMessagesPerSecond: minAssetPairs / priceUpdateInterval.Seconds(),
+		maxMessagesPerSecond: maxAssetPairs / priceUpdateInterval.Seconds(),
+		scoreLength:          15 * time.Minute,
+		sporadic:             true,
+	},
+}
 
+// calculateTopicScoreParams calculates score parameters for all topics in
+// the topicRates map.
+func calculateTopicScoreParams(cfg Config) (map[string]*pubsub.TopicScoreParams, error) {
 	var maxPeers = float64(pubsub.GossipSubDhi)
 	// Minimum and maximum expected number of feeds connected to the network:
-	var minFeedCount = float64(len(cfg.AuthorAllowlist)) / 2 // assume that 50% of feeds are offline
-	var maxFeedCount = float64(len(cfg.AuthorAllowlist))
-	// Minimum and maximum expected number of messages to be received from a single peer in a mesh:
-	var minMsgsPerSecond = minFeedCount * minEventsPerSecond / maxPeers
-	var maxMsgsPerSecond = maxFeedCount * maxEventsPerSecond
-
-	//nolint:gomnd
-	return (&scoreParams{
-		p1Score:              500,
-		p2Score:              500,
-		p3Score:              -1000,
-		p3bScore:             -1000,
-		p4Score:              -1000,
-		p1Length:             120 * time.Minute,
-		p2Length:             120 * time.Minute,
-		p3Length:             120 * time.Minute,
-		p3bLength:            15 * time.Minute,
-		p4Length:             time.Hour,
-		minMessagesPerSecond: minMsgsPerSecond,
-		maxMessagesPerSecond: maxMsgsPerSecond,
-		maxInvalidMessages:   maxInvalidMsgsPerHour,
-	}).calculate()
+	var minFeedCount = feedCount(cfg) / 2 // assume that 50% of feeds are offline
+	var maxFeedCount = feedCount(cfg)
+
+	params := make(map[string]*pubsub.TopicScoreParams, len(topicRates))
+	for topic, r := range topicRates {
+		//nolint:gomnd
+		sp := &scoreParams{
+			p1Score:   500,
+			p2Score:   500,
+			p3Score:   -1000,
+			p3bScore:  -1000,
+			p4Score:   -1000,
+			p1Length:  r.scoreLength,
+			p2Length:  r.scoreLength,
+			p3Length:  r.scoreLength,
+			p3bLength: 15 * time.Minute,
+			p4Length:  time.Hour,
+			// Minimum and maximum expected number of messages to be received from a single peer in a mesh:
+			minMessagesPerSecond: minFeedCount * r.minMessagesPerSecond / maxPeers,
+			maxMessagesPerSecond: maxFeedCount * r.maxMessagesPerSecond,
+			maxInvalidMessages:   maxInvalidMsgsPerHour,
+		}
+		if r.sporadic {
+			sp.p3Score = 0
+			sp.p3bScore = 0
+		}
+		p, err := sp.calculate()
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %w", topic, err)
+		}
+		params[topic] = p
+	}
+	return params, nil
+}
+
+// calculateThresholds calculates score thresholds for the subscribed
+// topics.
+//
+// The lowest threshold is the sum of P₃ and P₃b for all subscribed topics
+// with a steady flow of messages. It should be equal to the lowest score a
+// silent peer can get without receiving any penalties other than P₃ and
+// P₃b. Because only very few peers can produce messages, some honest peers
+// may have a score equal to this number.
+func calculateThresholds(cfg Config, params map[string]*pubsub.TopicScoreParams) *pubsub.PeerScoreThresholds {
+	threshold := minScoreThreshold
+	for topic := range cfg.Topics {
+		p, ok := params[topic]
+		if !ok {
+			continue
+		}
+		threshold += p.MeshMessageDeliveriesWeight * math.Pow(p.MeshMessageDeliveriesThreshold, 2)
+		threshold += p.MeshFailurePenaltyWeight * math.Pow(p.MeshMessageDeliveriesThreshold, 2)
+	}
+	return &pubsub.PeerScoreThresholds{
+		GossipThreshold:             threshold,
+		PublishThreshold:            threshold,
+		GraylistThreshold:           threshold,
+		AcceptPXThreshold:           0,
+		OpportunisticGraftThreshold: 0,
+	}
+}
+
+// feedCount returns the number of feeds used to calculate expected message
+// rates. At least one feed is assumed.
+func feedCount(cfg Config) float64 {
+	return math.Max(1, float64(len(cfg.AuthorAllowlist)))
 }
 
 // scoreParams helps to calculate score parameters for libp2p's PubSub.
@@ -20,8 +20,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/defiweb/go-eth/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
 func TestScoreParams_calculate(t *testing.T) {
@@ -63,3 +67,54 @@ func TestScoreParams_calculate(t *testing.T) {
 	assert.InDelta(t, p.maxMessagesPerSecond, pc.MeshMessageDeliveriesCap/p.p3Length.Seconds(), 0.01)
 	assert.InDelta(t, p.maxInvalidMessages, decayToZero*math.Pow(pc.InvalidMessageDeliveriesDecay, p.p4Length.Seconds()/decayInterval.Seconds()*-1), 0.01)
 }
+
+func TestCalculateTopicScoreParams(t *testing.T) {
+	for _, feeds := range []int{0, 1, 20} {
+		cfg := Config{AuthorAllowlist: make([]types.Address, feeds)}
+		params, err := calculateTopicScoreParams(cfg)
+		require.NoError(t, err)
+
+		limits := rateLimiterConfig(cfg).TopicLimits
+		for topic := range transport.AllMessagesMap {
+			p, ok := params[topic]
+			require.True(t, ok, "missing score params for %s", topic)
+			assert.Greater(t, p.TimeInMeshWeight, 0.0, topic)
+			assert.Greater(t, p.FirstMessageDeliveriesWeight, 0.0, topic)
+			assert.False(t, math.IsInf(p.FirstMessageDeliveriesWeight, 0), topic)
+			for _, d := range []float64{p.FirstMessageDeliveriesDecay, p.MeshMessageDeliveriesDecay, p.InvalidMessageDeliveriesDecay} {
+				assert.Greater(t, d, 0.0, topic)
+				assert.Less(t, d, 1.0, topic)
+			}
+			assert.Less(t, p.InvalidMessageDeliveriesWeight, 0.0, topic)
+			if topicRates[topic].sporadic {
+				assert.Zero(t, p.MeshMessageDeliveriesWeight, topic)
+				assert.Zero(t, p.MeshFailurePenaltyWeight, topic)
+			} else {
+				assert.Less(t, p.MeshMessageDeliveriesWeight, 0.0, topic)
+			}
+
+			l, ok := limits[topic]
+			require.True(t, ok, "missing rate limit for %s", topic)
+			assert.Greater(t, l.MessagesPerSecond, 0.0, topic)
+			assert.GreaterOrEqual(t, l.BurstSize, 1, topic)
+		}
+	}
+}
+
+func TestCalculateThresholds(t *testing.T) {
+	cfg := Config{
+		AuthorAllowlist: make([]types.Address, 10),
+		Topics: map[string]transport.Message{
+			messages.DataPointV1MessageName:       (*messages.DataPoint)(nil),
+			messages.MuSigCommitmentV1MessageName: (*messages.MuSigCommitment)(nil),
+		},
+	}
+	params, err := calculateTopicScoreParams(cfg)
+	require.NoError(t, err)
+
+	// Only P₃ and P₃b of the data point topic lower the threshold, the
+	// MuSig topic is sporadic.
+	th := calculateThresholds(cfg, params)
+	assert.InDelta(t, minScoreThreshold-2000, th.GossipThreshold, 0.01)
+	assert.InDelta(t, minScoreThreshold-2000, th.GraylistThreshold, 0.01)
+}
@@ -18,6 +18,8 @@ package libp2p
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -26,11 +28,13 @@ import (
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/crypto/ethkey"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/sliceutil"
 )
 
 func messageValidator(topics map[string]transport.Message, logger log.Logger) internal.Options {
@@ -61,14 +65,15 @@ func messageValidator(topics map[string]transport.Message, logger log.Logger) in
 	}
 }
 
-func feedValidator(feeds []types.Address, logger log.Logger) internal.Options {
+func feedValidator(feeds, bridges []types.Address, logger log.Logger) internal.Options {
 	return func(n *internal.Node) error {
 		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, psMsg *pubsub.Message) pubsub.ValidationResult {
 			from := ethkey.PeerIDToAddress(psMsg.GetFrom())
-			if !feedAllowed(from, feeds) {
+			if !authorAllowed(topic, from, feeds, bridges) {
 				logger.
 					WithField("peerID", psMsg.GetFrom().String()).
 					WithField("peerAddr", from.String()).
+					WithField("topic", topic).
 					Warn("Message ignored, feed is not allowed to send messages")
 				return pubsub.ValidationIgnore
 			}
@@ -78,6 +83,15 @@ func feedValidator(feeds []types.Address, logger log.Logger) internal.Options {
 	}
 }
 
+// authorAllowed checks if the author may send messages on the topic. Feeds
+// may send messages on any topic, bridges only on the transport.BridgeTopics.
+func authorAllowed(topic string, author types.Address, feeds, bridges []types.Address) bool {
+	if feedAllowed(author, feeds) {
+		return true
+	}
+	return feedAllowed(author, bridges) && sliceutil.Contains(transport.BridgeTopics, topic)
+}
+
 func feedAllowed(addr types.Address, feeds []types.Address) bool {
 	for _, f := range feeds {
 		if f == addr {
@@ -87,6 +101,16 @@ func feedAllowed(addr types.Address, feeds []types.Address) bool {
 	return false
 }
 
+// signerAllowed checks if a message signed by the signer may be sent by the
+// author. Feeds may only send messages signed by themselves, bridges may also
+// relay messages signed by any of the feeds.
+func signerAllowed(signer, author types.Address, feeds, bridges []types.Address) bool {
+	if signer == author {
+		return true
+	}
+	return feedAllowed(author, bridges) && feedAllowed(signer, feeds)
+}
+
 // eventValidator adds a validator for event messages.
 func eventValidator(logger log.Logger) internal.Options {
 	return func(n *internal.Node) error {
@@ -116,7 +140,10 @@ func eventValidator(logger log.Logger) internal.Options {
 
 // priceValidator adds a validator for price messages. The validator checks if
 // the price message is valid, and if the price is not older than 5 min.
-func priceValidator(logger log.Logger, recoverer crypto.Recoverer) internal.Options {
+//
+// Prices must be signed by the author of the message, unless the author is
+// one of the bridges, in which case prices must be signed by one of the feeds.
+func priceValidator(logger log.Logger, recoverer crypto.Recoverer, feeds, bridges []types.Address) internal.Options {
 	return func(n *internal.Node) error {
 		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, psMsg *pubsub.Message) pubsub.ValidationResult {
 			p, ok := psMsg.ValidatorData.(*messages.Price)
@@ -145,8 +172,9 @@ func priceValidator(logger log.Logger, recoverer crypto.Recoverer) internal.Opti
 					Warn("Price message rejected, invalid signature")
 				return pubsub.ValidationReject
 			}
-			// The libp2p message MUST be created by the same person who signs the price message.
-			if *priceFrom != peerAddr {
+			// The libp2p message MUST be created by the same person who signs the price message,
+			// or relayed by a bridge:
+			if !signerAllowed(*priceFrom, peerAddr, feeds, bridges) {
 				logger.
 					WithField("from", *priceFrom).
 					WithFields(fields).
@@ -171,3 +199,207 @@ func priceValidator(logger log.Logger, recoverer crypto.Recoverer) internal.Opti
 		return nil
 	}
 }
+
+// dataPointValidator adds a validator for data point messages. The validator
+// checks if the data point is signed by the author of the message, and if
+// the data point is not older than 5 min.
+//
+// If none of the recoverers supports the data point, its signature cannot
+// be verified here and the data point is accepted.
+//
+// Data points relayed by one of the bridges must be signed by one of
+// the feeds.
+//
+// Data points in the v2 format are validated signature by signature, and
+// must carry at least one signature.
+//
+// Data point batches are validated point by point. A batch is rejected if
+// any of its data points would be rejected, and ignored if any of them
+// would be ignored.
+func dataPointValidator(logger log.Logger, recoverers []datapoint.Recoverer, feeds, bridges []types.Address) internal.Options {
+	return func(n *internal.Node) error {
+		n.AddValidator(func(ctx context.Context, topic string, id peer.ID, psMsg *pubsub.Message) pubsub.ValidationResult {
+			return validateDataPoint(ctx, logger, recoverers, feeds, bridges, psMsg)
+		})
+		return nil
+	}
+}
+
+// validateDataPoint validates a data point, data point v2 or data point batch
+// message.
+func validateDataPoint(
+	ctx context.Context,
+	logger log.Logger,
+	recoverers []datapoint.Recoverer,
+	feeds, bridges []types.Address,
+	psMsg *pubsub.Message,
+) pubsub.ValidationResult {
+
+	peerAddr := ethkey.PeerIDToAddress(psMsg.GetFrom())
+	peerFields := log.Fields{
+		"peerAddr": peerAddr.String(),
+		"peerID":   psMsg.GetFrom().String(),
+	}
+	switch msg := psMsg.ValidatorData.(type) {
+	case *messages.DataPoint:
+		return validateDataPointMessage(ctx, logger, recoverers, feeds, bridges, peerAddr, peerFields, msg)
+	case *messages.DataPointV2:
+		if len(msg.Signatures) == 0 || len(msg.Signatures) > messages.MaxDataPointSignatures {
+			logger.
+				WithFields(peerFields).
+				WithFields(msg.LogFields()).
+				Warn("Data point message rejected, invalid number of signatures")
+			return pubsub.ValidationReject
+		}
+		return validateDataPointMessages(ctx, logger, recoverers, feeds, bridges, peerAddr, peerFields, msg.DataPoints())
+	case *messages.DataPointBatch:
+		if len(msg.DataPoints) == 0 || len(msg.DataPoints) > messages.MaxDataPointBatchSize {
+			logger.
+				WithFields(peerFields).
+				WithField("size", len(msg.DataPoints)).
+				Warn("Data point batch message rejected, invalid batch size")
+			return pubsub.ValidationReject
+		}
+		return validateDataPointMessages(ctx, logger, recoverers, feeds, bridges, peerAddr, peerFields, msg.DataPoints)
+	}
+	return pubsub.ValidationAccept
+}
+
+// validateDataPointMessages validates multiple data points. The result is
+// reject if any of the data points is rejected, and ignore if any of them
+// is ignored.
+func validateDataPointMessages(
+	ctx context.Context,
+	logger log.Logger,
+	recoverers []datapoint.Recoverer,
+	feeds, bridges []types.Address,
+	peerAddr types.Address,
+	peerFields log.Fields,
+	dps []*messages.DataPoint,
+) pubsub.ValidationResult {
+
+	res := pubsub.ValidationAccept
+	for _, dp := range dps {
+		switch validateDataPointMessage(ctx, logger, recoverers, feeds, bridges, peerAddr, peerFields, dp) {
+		case pubsub.ValidationReject:
+			return pubsub.ValidationReject
+		case pubsub.ValidationIgnore:
+			res = pubsub.ValidationIgnore
+		}
+	}
+	return res
+}
+
+// validateDataPointMessage validates a single data point.
+func validateDataPointMessage(
+	ctx context.Context,
+	logger log.Logger,
+	recoverers []datapoint.Recoverer,
+	feeds, bridges []types.Address,
+	peerAddr types.Address,
+	peerFields log.Fields,
+	dp *messages.DataPoint,
+) pubsub.ValidationResult {
+
+	if dp == nil {
+		return pubsub.ValidationReject
+	}
+	fields := log.Fields{
+		"model":           dp.Model,
+		"signatureScheme": dp.SignatureScheme.String(),
+	}
+	for k, v := range peerFields {
+		fields[k] = v
+	}
+	// Check if a data point signature is valid and extract author's address.
+	// Data points that cannot be verified are not propagated. Signature
+	// schemes, values and models that are not supported by this node may be
+	// supported by other nodes, so such data points are only ignored:
+	dpFrom, err := recoverDataPointSigner(ctx, recoverers, dp)
+	if errors.Is(err, errUnsupportedSignatureScheme) ||
+		errors.Is(err, errUnsupportedDataPoint) ||
+		errors.Is(err, datapoint.ErrUnsupportedModel) {
+		logger.
+			WithError(err).
+			WithFields(fields).
+			WithFields(dp.Value.LogFields()).
+			Warn("Data point message ignored, unable to verify signature")
+		return pubsub.ValidationIgnore
+	}
+	if err != nil {
+		logger.
+			WithError(err).
+			WithFields(fields).
+			WithFields(dp.Value.LogFields()).
+			Warn("Data point message rejected, invalid signature")
+		return pubsub.ValidationReject
+	}
+	// The libp2p message MUST be created by the same person who signs the data point,
+	// or relayed by a bridge:
+	if !signerAllowed(*dpFrom, peerAddr, feeds, bridges) {
+		logger.
+			WithField("from", dpFrom.String()).
+			WithFields(fields).
+			WithFields(dp.Value.LogFields()).
+			Warn("Data point message rejected, the message and data point signatures do not match")
+		return pubsub.ValidationReject
+	}
+	// Check when data point was created, ignore if older than 5 min, reject if older than 10 min:
+	if time.Since(dp.Value.Time) > 5*time.Minute {
+		if time.Since(dp.Value.Time) > 10*time.Minute {
+			logger.
+				WithFields(fields).
+				WithFields(dp.Value.LogFields()).
+				Warn("Data point message rejected, the data point is older than 10 min")
+			return pubsub.ValidationReject
+		}
+		logger.
+			WithFields(fields).
+			WithFields(dp.Value.LogFields()).
+			Warn("Data point message ignored, the data point is older than 5 min")
+		return pubsub.ValidationIgnore
+	}
+	// Data points from the future would be preferred over valid ones, reject if newer by more than 5 min:
+	if time.Until(dp.Value.Time) > 5*time.Minute {
+		logger.
+			WithFields(fields).
+			WithFields(dp.Value.LogFields()).
+			Warn("Data point message rejected, the data point is from the future")
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// errUnsupportedSignatureScheme is returned by recoverDataPointSigner if
+// none of the recoverers supports the signature scheme of the data point.
+var errUnsupportedSignatureScheme = errors.New("unsupported signature scheme")
+
+// errUnsupportedDataPoint is returned by recoverDataPointSigner if none of
+// the recoverers for the signature scheme supports the data point value.
+var errUnsupportedDataPoint = errors.New("unsupported data point value")
+
+// recoverDataPointSigner recovers the address of the data point signer
+// using the first recoverer that supports the data point. If none of the
+// recoverers supports the data point, errUnsupportedSignatureScheme or
+// errUnsupportedDataPoint is returned.
+func recoverDataPointSigner(ctx context.Context, recoverers []datapoint.Recoverer, dp *messages.DataPoint) (*types.Address, error) {
+	err := errUnsupportedSignatureScheme
+	for _, r := range recoverers {
+		if r.Scheme() != dp.SignatureScheme {
+			continue
+		}
+		if !r.Supports(ctx, dp.Value) {
+			err = errUnsupportedDataPoint
+			continue
+		}
+		from, err := r.Recover(ctx, dp.Model, dp.Value, dp.Signature)
+		if err != nil {
+			return nil, err
+		}
+		if from == nil {
+			return nil, fmt.Errorf("unable to recover %s signature", dp.SignatureScheme)
+		}
+		return from, nil
+	}
+	return nil, err
+}
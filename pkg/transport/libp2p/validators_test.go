@@ -0,0 +1,349 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package libp2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/crypto"
+	"github.com/defiweb/go-eth/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubPB "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/signer"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/crypto/ethkey"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestAuthorAllowed(t *testing.T) {
+	var (
+		feed   = types.MustAddressFromHex("0x1111111111111111111111111111111111111111")
+		other  = types.MustAddressFromHex("0x2222222222222222222222222222222222222222")
+		bridge = types.MustAddressFromHex("0x3333333333333333333333333333333333333333")
+	)
+	tests := []struct {
+		name   string
+		topic  string
+		author types.Address
+		want   bool
+	}{
+		{name: "feed, data point", topic: messages.DataPointV1MessageName, author: feed, want: true},
+		{name: "feed, event", topic: messages.EventV1MessageName, author: feed, want: true},
+		{name: "bridge, data point", topic: messages.DataPointV1MessageName, author: bridge, want: true},
+		{name: "bridge, data point batch", topic: messages.DataPointBatchV1MessageName, author: bridge, want: true},
+		{name: "bridge, price", topic: messages.PriceV1MessageName, author: bridge, want: true},
+		{name: "bridge, event", topic: messages.EventV1MessageName, author: bridge, want: false},
+		{name: "bridge, greet", topic: messages.GreetV1MessageName, author: bridge, want: false},
+		{name: "bridge, musig", topic: messages.MuSigStartV1MessageName, author: bridge, want: false},
+		{name: "unknown author", topic: messages.DataPointV1MessageName, author: other, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, authorAllowed(tt.topic, tt.author, []types.Address{feed}, []types.Address{bridge}))
+		})
+	}
+}
+
+func TestValidateDataPoint(t *testing.T) {
+	const (
+		feed   = "0x1111111111111111111111111111111111111111"
+		other  = "0x2222222222222222222222222222222222222222"
+		bridge = "0x3333333333333333333333333333333333333333"
+	)
+	tests := []struct {
+		name       string
+		from       string
+		signer     string
+		scheme     datapoint.SignatureScheme
+		time       time.Time
+		recoverers []datapoint.Recoverer
+		want       pubsub.ValidationResult
+	}{
+		{
+			name:       "valid",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationAccept,
+		},
+		{
+			name:       "signer differs from author",
+			from:       feed,
+			signer:     other,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationReject,
+		},
+		{
+			name:       "relayed by bridge",
+			from:       bridge,
+			signer:     feed,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationAccept,
+		},
+		{
+			name:       "relayed by bridge, signer is not a feed",
+			from:       bridge,
+			signer:     other,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationReject,
+		},
+		{
+			name:       "no recoverer for scheme",
+			from:       feed,
+			signer:     other,
+			scheme:     datapoint.SignatureSchemeEIP712,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationIgnore,
+		},
+		{
+			name:       "no EIP-712 domains configured",
+			from:       feed,
+			signer:     feed,
+			scheme:     datapoint.SignatureSchemeEIP712,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{signer.NewEIP712Recoverer(crypto.ECRecoverer, nil)},
+			want:       pubsub.ValidationIgnore,
+		},
+		{
+			name:       "unsupported model",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&unsupportedModelRecoverer{}},
+			want:       pubsub.ValidationIgnore,
+		},
+		{
+			name:       "unsupported value",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now(),
+			recoverers: []datapoint.Recoverer{&unsupportedRecoverer{}},
+			want:       pubsub.ValidationIgnore,
+		},
+		{
+			name:       "older than 5 min",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now().Add(-6 * time.Minute),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationIgnore,
+		},
+		{
+			name:       "older than 10 min",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now().Add(-11 * time.Minute),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationReject,
+		},
+		{
+			name:       "from the future",
+			from:       feed,
+			signer:     feed,
+			time:       time.Now().Add(6 * time.Minute),
+			recoverers: []datapoint.Recoverer{&mocks.Recoverer{}},
+			want:       pubsub.ValidationReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			psMsg := &pubsub.Message{
+				Message: &pubsubPB.Message{
+					From: []byte(ethkey.AddressToPeerID(types.MustAddressFromHex(tt.from))),
+				},
+				ValidatorData: &messages.DataPoint{
+					Model: "ETH/USD",
+					Value: datapoint.Point{
+						Value: value.StaticValue{Value: bn.Float(1)},
+						Time:  tt.time,
+						Meta:  map[string]any{"addr": tt.signer},
+					},
+					SignatureScheme: tt.scheme,
+				},
+			}
+			got := validateDataPoint(
+				context.Background(),
+				null.New(),
+				tt.recoverers,
+				[]types.Address{types.MustAddressFromHex(feed)},
+				[]types.Address{types.MustAddressFromHex(bridge)},
+				psMsg,
+			)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateDataPoint_Batch(t *testing.T) {
+	const (
+		feed  = "0x1111111111111111111111111111111111111111"
+		other = "0x2222222222222222222222222222222222222222"
+	)
+	newDataPoint := func(signer string, t time.Time) *messages.DataPoint {
+		return &messages.DataPoint{
+			Model: "ETH/USD",
+			Value: datapoint.Point{
+				Value: value.StaticValue{Value: bn.Float(1)},
+				Time:  t,
+				Meta:  map[string]any{"addr": signer},
+			},
+		}
+	}
+	tests := []struct {
+		name       string
+		dataPoints []*messages.DataPoint
+		want       pubsub.ValidationResult
+	}{
+		{
+			name: "valid",
+			dataPoints: []*messages.DataPoint{
+				newDataPoint(feed, time.Now()),
+				newDataPoint(feed, time.Now()),
+			},
+			want: pubsub.ValidationAccept,
+		},
+		{
+			name:       "empty",
+			dataPoints: []*messages.DataPoint{},
+			want:       pubsub.ValidationReject,
+		},
+		{
+			name:       "too large",
+			dataPoints: make([]*messages.DataPoint, messages.MaxDataPointBatchSize+1),
+			want:       pubsub.ValidationReject,
+		},
+		{
+			name: "one invalid signer",
+			dataPoints: []*messages.DataPoint{
+				newDataPoint(feed, time.Now()),
+				newDataPoint(other, time.Now()),
+			},
+			want: pubsub.ValidationReject,
+		},
+		{
+			name: "one older than 5 min",
+			dataPoints: []*messages.DataPoint{
+				newDataPoint(feed, time.Now().Add(-6*time.Minute)),
+				newDataPoint(feed, time.Now()),
+			},
+			want: pubsub.ValidationIgnore,
+		},
+		{
+			name: "ignored and rejected",
+			dataPoints: []*messages.DataPoint{
+				newDataPoint(feed, time.Now().Add(-6*time.Minute)),
+				newDataPoint(feed, time.Now().Add(6*time.Minute)),
+			},
+			want: pubsub.ValidationReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			psMsg := &pubsub.Message{
+				Message: &pubsubPB.Message{
+					From: []byte(ethkey.AddressToPeerID(types.MustAddressFromHex(feed))),
+				},
+				ValidatorData: &messages.DataPointBatch{DataPoints: tt.dataPoints},
+			}
+			got := validateDataPoint(
+				context.Background(),
+				null.New(),
+				[]datapoint.Recoverer{&mocks.Recoverer{}},
+				[]types.Address{types.MustAddressFromHex(feed)},
+				nil,
+				psMsg,
+			)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateDataPoint_V2(t *testing.T) {
+	const (
+		feed  = "0x1111111111111111111111111111111111111111"
+		other = "0x2222222222222222222222222222222222222222"
+	)
+	tests := []struct {
+		name       string
+		signer     string
+		time       time.Time
+		signatures int
+		want       pubsub.ValidationResult
+	}{
+		{name: "valid", signer: feed, time: time.Now(), signatures: 2, want: pubsub.ValidationAccept},
+		{name: "no signatures", signer: feed, time: time.Now(), signatures: 0, want: pubsub.ValidationReject},
+		{name: "too many signatures", signer: feed, time: time.Now(), signatures: messages.MaxDataPointSignatures + 1, want: pubsub.ValidationReject},
+		{name: "invalid signer", signer: other, time: time.Now(), signatures: 1, want: pubsub.ValidationReject},
+		{name: "older than 5 min", signer: feed, time: time.Now().Add(-6 * time.Minute), signatures: 1, want: pubsub.ValidationIgnore},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			psMsg := &pubsub.Message{
+				Message: &pubsubPB.Message{
+					From: []byte(ethkey.AddressToPeerID(types.MustAddressFromHex(feed))),
+				},
+				ValidatorData: &messages.DataPointV2{
+					Model: "ETH/USD",
+					Value: datapoint.Point{
+						Value: value.StaticValue{Value: bn.Float(1)},
+						Time:  tt.time,
+						Meta:  map[string]any{"addr": tt.signer},
+					},
+					Signatures: make([]messages.DataPointSignature, tt.signatures),
+				},
+			}
+			got := validateDataPoint(
+				context.Background(),
+				null.New(),
+				[]datapoint.Recoverer{&mocks.Recoverer{}},
+				[]types.Address{types.MustAddressFromHex(feed)},
+				nil,
+				psMsg,
+			)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type unsupportedRecoverer struct {
+	mocks.Recoverer
+}
+
+func (r *unsupportedRecoverer) Supports(_ context.Context, _ datapoint.Point) bool {
+	return false
+}
+
+type unsupportedModelRecoverer struct {
+	mocks.Recoverer
+}
+
+func (r *unsupportedModelRecoverer) Recover(_ context.Context, model string, _ datapoint.Point, _ types.Signature) (*types.Address, error) {
+	return nil, fmt.Errorf("%s: %w", model, datapoint.ErrUnsupportedModel)
+}
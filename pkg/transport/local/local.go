@@ -143,6 +143,11 @@ func (l *Local) unmarshallRoutine(sub *subscription) {
 			return
 		}
 		l.mu.RLock()
+		if l.subs == nil {
+			// Transport is closed, the msgCh channel is no longer valid.
+			l.mu.RUnlock()
+			return
+		}
 		msg := reflect.New(sub.typ).Interface().(transport.Message)
 		err := msg.UnmarshallBinary(rawMsg.data)
 		sub.msgCh <- transport.ReceivedMessage{
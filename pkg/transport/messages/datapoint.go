@@ -17,6 +17,8 @@ package messages
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/defiweb/go-eth/types"
 
@@ -28,6 +30,11 @@ import (
 )
 
 const DataPointV1MessageName = "data_point/v1"
+const DataPointBatchV1MessageName = "data_point_batch/v1"
+
+// MaxDataPointBatchSize is the maximum number of data points in a single
+// DataPointBatch message.
+const MaxDataPointBatchSize = 256
 
 type DataPoint struct {
 	// Model of the data point.
@@ -36,7 +43,11 @@ type DataPoint struct {
 	// Value is a binary representation of the data point.
 	Value datapoint.Point `json:"value"`
 
+	// Signature is the feed signature of the data point.
 	Signature types.Signature `json:"signature"`
+
+	// SignatureScheme is the scheme used to create the signature.
+	SignatureScheme datapoint.SignatureScheme `json:"signatureScheme"`
 }
 
 func (d *DataPoint) Marshall() ([]byte, error) {
@@ -53,15 +64,11 @@ func (d *DataPoint) Unmarshall(b []byte) error {
 
 // MarshallBinary implements the transport.Message interface.
 func (d *DataPoint) MarshallBinary() ([]byte, error) {
-	value, err := d.Value.MarshalBinary()
+	msg, err := d.toProtobuf()
 	if err != nil {
 		return nil, err
 	}
-	return proto.Marshal(&pb.DataPointMessage{
-		Model:     d.Model,
-		Value:     value,
-		Signature: d.Signature.Bytes(),
-	})
+	return proto.Marshal(msg)
 }
 
 // UnmarshallBinary implements the transport.Message interface.
@@ -70,6 +77,23 @@ func (d *DataPoint) UnmarshallBinary(data []byte) error {
 	if err := proto.Unmarshal(data, msg); err != nil {
 		return err
 	}
+	return d.fromProtobuf(msg)
+}
+
+func (d *DataPoint) toProtobuf() (*pb.DataPointMessage, error) {
+	value, err := d.Value.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DataPointMessage{
+		Model:           d.Model,
+		Value:           value,
+		Signature:       d.Signature.Bytes(),
+		SignatureScheme: uint32(d.SignatureScheme),
+	}, nil
+}
+
+func (d *DataPoint) fromProtobuf(msg *pb.DataPointMessage) error {
 	err := d.Value.UnmarshalBinary(msg.Value)
 	if err != nil {
 		return err
@@ -80,6 +104,7 @@ func (d *DataPoint) UnmarshallBinary(data []byte) error {
 	}
 	d.Model = msg.Model
 	d.Signature = sig
+	d.SignatureScheme = datapoint.SignatureScheme(msg.SignatureScheme)
 	return nil
 }
 
@@ -88,11 +113,61 @@ func (d *DataPoint) LogFields() log.Fields {
 		return nil
 	}
 	f := log.Fields{
-		"model":     d.Model,
-		"signature": d.Signature.String(),
+		"model":           d.Model,
+		"signature":       d.Signature.String(),
+		"signatureScheme": d.SignatureScheme.String(),
 	}
 	for k, v := range d.Value.LogFields() {
 		f[k] = v
 	}
 	return f
 }
+
+// DataPointBatch is a message that carries multiple data points signed by
+// the same feed. Sending data points in a batch reduces the number of
+// messages that have to be signed, validated and gossiped separately.
+type DataPointBatch struct {
+	// DataPoints is the list of data points in the batch.
+	DataPoints []*DataPoint `json:"dataPoints"`
+}
+
+// MarshallBinary implements the transport.Message interface.
+func (d *DataPointBatch) MarshallBinary() ([]byte, error) {
+	if len(d.DataPoints) > MaxDataPointBatchSize {
+		return nil, fmt.Errorf("batch must not contain more than %d data points", MaxDataPointBatchSize)
+	}
+	msg := &pb.DataPointBatchMessage{
+		DataPoints: make([]*pb.DataPointMessage, len(d.DataPoints)),
+	}
+	for i, dp := range d.DataPoints {
+		if dp == nil {
+			return nil, errors.New("batch must not contain nil data points")
+		}
+		pbDP, err := dp.toProtobuf()
+		if err != nil {
+			return nil, err
+		}
+		msg.DataPoints[i] = pbDP
+	}
+	return proto.Marshal(msg)
+}
+
+// UnmarshallBinary implements the transport.Message interface.
+func (d *DataPointBatch) UnmarshallBinary(data []byte) error {
+	msg := &pb.DataPointBatchMessage{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	if len(msg.DataPoints) > MaxDataPointBatchSize {
+		return fmt.Errorf("batch must not contain more than %d data points", MaxDataPointBatchSize)
+	}
+	d.DataPoints = make([]*DataPoint, len(msg.DataPoints))
+	for i, pbDP := range msg.DataPoints {
+		dp := &DataPoint{}
+		if err := dp.fromProtobuf(pbDP); err != nil {
+			return err
+		}
+		d.DataPoints[i] = dp
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func TestDataPoint_MarshallBinary(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme datapoint.SignatureScheme
+	}{
+		{name: "legacy", scheme: datapoint.SignatureSchemeLegacy},
+		{name: "eip712", scheme: datapoint.SignatureSchemeEIP712},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &DataPoint{
+				Model: "AAA/BBB",
+				Value: datapoint.Point{
+					Value: value.Tick{
+						Pair:  value.Pair{Base: "AAA", Quote: "BBB"},
+						Price: bn.Float(42),
+					},
+					Time: time.Unix(1234567890, 0),
+				},
+				Signature:       types.MustSignatureFromBytes(bytes.Repeat([]byte{0x01}, 65)),
+				SignatureScheme: tt.scheme,
+			}
+			bin, err := msg.MarshallBinary()
+			require.NoError(t, err)
+
+			var dec DataPoint
+			require.NoError(t, dec.UnmarshallBinary(bin))
+			assert.Equal(t, msg.Model, dec.Model)
+			assert.Equal(t, msg.Signature, dec.Signature)
+			assert.Equal(t, tt.scheme, dec.SignatureScheme)
+			assert.Equal(t, "42", dec.Value.Value.(value.Tick).Price.String())
+		})
+	}
+}
+
+func TestDataPointBatch_MarshallBinary(t *testing.T) {
+	newDataPoint := func(model string, price float64) *DataPoint {
+		return &DataPoint{
+			Model: model,
+			Value: datapoint.Point{
+				Value: value.Tick{
+					Pair:  value.Pair{Base: "AAA", Quote: "BBB"},
+					Price: bn.Float(price),
+				},
+				Time: time.Unix(1234567890, 0),
+			},
+			Signature:       types.MustSignatureFromBytes(bytes.Repeat([]byte{0x01}, 65)),
+			SignatureScheme: datapoint.SignatureSchemeEIP712,
+		}
+	}
+	msg := &DataPointBatch{
+		DataPoints: []*DataPoint{
+			newDataPoint("AAA/BBB", 42),
+			newDataPoint("CCC/DDD", 1337),
+		},
+	}
+	bin, err := msg.MarshallBinary()
+	require.NoError(t, err)
+
+	var dec DataPointBatch
+	require.NoError(t, dec.UnmarshallBinary(bin))
+	require.Len(t, dec.DataPoints, 2)
+	assert.Equal(t, "AAA/BBB", dec.DataPoints[0].Model)
+	assert.Equal(t, "CCC/DDD", dec.DataPoints[1].Model)
+	assert.Equal(t, "1337", dec.DataPoints[1].Value.Value.(value.Tick).Price.String())
+	assert.Equal(t, datapoint.SignatureSchemeEIP712, dec.DataPoints[1].SignatureScheme)
+}
+
+func TestDataPointBatch_MarshallBinary_TooLarge(t *testing.T) {
+	msg := &DataPointBatch{DataPoints: make([]*DataPoint, MaxDataPointBatchSize+1)}
+	_, err := msg.MarshallBinary()
+	require.Error(t, err)
+}
+
+func FuzzDataPoint_UnmarshallBinary(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_ = (&DataPoint{}).UnmarshallBinary(data)
+	})
+}
+
+func FuzzDataPointBatch_UnmarshallBinary(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_ = (&DataPointBatch{}).UnmarshallBinary(data)
+	})
+}
@@ -0,0 +1,251 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages/pb"
+)
+
+const DataPointV2MessageName = "data_point/v2"
+
+// MaxDataPointSignatures is the maximum number of signatures in a single
+// DataPointV2 message.
+const MaxDataPointSignatures = 8
+
+// DataPointV2 is the second version of the data point message.
+//
+// Unlike DataPoint, it preserves the sub-second precision of timestamps,
+// explicitly identifies the scheme of every signature and may carry
+// multiple signatures of the same data point, e.g. a legacy and an EIP-712
+// signature.
+type DataPointV2 struct {
+	// Model of the data point.
+	Model string `json:"model"`
+
+	// Value is the data point.
+	Value datapoint.Point `json:"value"`
+
+	// Signatures is the list of feed signatures of the data point.
+	Signatures []DataPointSignature `json:"signatures"`
+
+	// Compact indicates that the sub points are omitted from the binary
+	// representation of the message. Sub points are not signed, so they
+	// can be dropped to reduce the message size.
+	Compact bool `json:"compact"`
+}
+
+// DataPointSignature is a signature of a data point together with the
+// scheme used to create it.
+type DataPointSignature struct {
+	// Signature is the feed signature of the data point.
+	Signature types.Signature `json:"signature"`
+
+	// SignatureScheme is the scheme used to create the signature.
+	SignatureScheme datapoint.SignatureScheme `json:"signatureScheme"`
+}
+
+// NewDataPointV2 creates a DataPointV2 message from DataPoint messages.
+// All messages must be signatures of the same data point.
+func NewDataPointV2(msgs ...*DataPoint) (*DataPointV2, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("at least one data point is required")
+	}
+	d := &DataPointV2{
+		Model: msgs[0].Model,
+		Value: msgs[0].Value,
+	}
+	for _, msg := range msgs {
+		if msg.Model != d.Model {
+			return nil, fmt.Errorf("data point models do not match: %s and %s", d.Model, msg.Model)
+		}
+		d.Signatures = append(d.Signatures, DataPointSignature{
+			Signature:       msg.Signature,
+			SignatureScheme: msg.SignatureScheme,
+		})
+	}
+	return d, nil
+}
+
+// DataPoints returns a DataPoint message for every signature.
+func (d *DataPointV2) DataPoints() []*DataPoint {
+	msgs := make([]*DataPoint, len(d.Signatures))
+	for i, sig := range d.Signatures {
+		msgs[i] = &DataPoint{
+			Model:           d.Model,
+			Value:           d.Value,
+			Signature:       sig.Signature,
+			SignatureScheme: sig.SignatureScheme,
+		}
+	}
+	return msgs
+}
+
+func (d *DataPointV2) Marshall() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+func (d *DataPointV2) Unmarshall(b []byte) error {
+	return json.Unmarshal(b, d)
+}
+
+// MarshallBinary implements the transport.Message interface.
+func (d *DataPointV2) MarshallBinary() ([]byte, error) {
+	if len(d.Signatures) > MaxDataPointSignatures {
+		return nil, fmt.Errorf("data point must not have more than %d signatures", MaxDataPointSignatures)
+	}
+	point, err := pointToProtobufV2(d.Value, d.Compact)
+	if err != nil {
+		return nil, err
+	}
+	msg := &pb.DataPointV2Message{
+		Model:      d.Model,
+		Point:      point,
+		Signatures: make([]*pb.DataPointV2Message_Signature, len(d.Signatures)),
+		Compact:    d.Compact,
+	}
+	for i, sig := range d.Signatures {
+		msg.Signatures[i] = &pb.DataPointV2Message_Signature{
+			SignatureScheme: uint32(sig.SignatureScheme),
+			Signature:       sig.Signature.Bytes(),
+		}
+	}
+	return proto.Marshal(msg)
+}
+
+// UnmarshallBinary implements the transport.Message interface.
+func (d *DataPointV2) UnmarshallBinary(data []byte) error {
+	msg := &pb.DataPointV2Message{}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return err
+	}
+	if msg.Point == nil {
+		return errors.New("data point is missing")
+	}
+	if len(msg.Signatures) > MaxDataPointSignatures {
+		return fmt.Errorf("data point must not have more than %d signatures", MaxDataPointSignatures)
+	}
+	point, err := pointFromProtobufV2(msg.Point)
+	if err != nil {
+		return err
+	}
+	sigs := make([]DataPointSignature, len(msg.Signatures))
+	for i, pbSig := range msg.Signatures {
+		sig, err := types.SignatureFromBytes(pbSig.Signature)
+		if err != nil {
+			return err
+		}
+		sigs[i] = DataPointSignature{
+			Signature:       sig,
+			SignatureScheme: datapoint.SignatureScheme(pbSig.SignatureScheme),
+		}
+	}
+	d.Model = msg.Model
+	d.Value = point
+	d.Signatures = sigs
+	d.Compact = msg.Compact
+	return nil
+}
+
+func (d *DataPointV2) LogFields() log.Fields {
+	if d == nil {
+		return nil
+	}
+	schemes := make([]string, len(d.Signatures))
+	for i, sig := range d.Signatures {
+		schemes[i] = sig.SignatureScheme.String()
+	}
+	f := log.Fields{
+		"model":            d.Model,
+		"signatureSchemes": schemes,
+		"compact":          d.Compact,
+	}
+	for k, v := range d.Value.LogFields() {
+		f[k] = v
+	}
+	return f
+}
+
+func pointToProtobufV2(p datapoint.Point, compact bool) (*pb.DataPointV2Message_Point, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	val, err := value.MarshalBinary(p.Value)
+	if err != nil {
+		return nil, err
+	}
+	msg := &pb.DataPointV2Message_Point{
+		Value:         val,
+		TimestampNano: p.Time.UnixNano(),
+		Meta:          make(map[string][]byte, len(p.Meta)),
+	}
+	if !compact {
+		msg.SubPoints = make([]*pb.DataPointV2Message_Point, len(p.SubPoints))
+		for i, sp := range p.SubPoints {
+			msg.SubPoints[i], err = pointToProtobufV2(sp, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	for k, v := range p.Meta {
+		msg.Meta[k], err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+func pointFromProtobufV2(msg *pb.DataPointV2Message_Point) (datapoint.Point, error) {
+	val, err := value.UnmarshalBinary(msg.Value)
+	if err != nil {
+		return datapoint.Point{}, err
+	}
+	p := datapoint.Point{
+		Value:     val,
+		Time:      time.Unix(0, msg.TimestampNano),
+		SubPoints: make([]datapoint.Point, len(msg.SubPoints)),
+		Meta:      make(map[string]any, len(msg.Meta)),
+	}
+	for i, sp := range msg.SubPoints {
+		if sp == nil {
+			return datapoint.Point{}, errors.New("sub point is missing")
+		}
+		p.SubPoints[i], err = pointFromProtobufV2(sp)
+		if err != nil {
+			return datapoint.Point{}, err
+		}
+	}
+	for k, v := range msg.Meta {
+		var m any
+		if err := json.Unmarshal(v, &m); err != nil {
+			return datapoint.Point{}, err
+		}
+		p.Meta[k] = m
+	}
+	return p, nil
+}
@@ -0,0 +1,130 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint"
+	"github.com/chronicleprotocol/oracle-suite/pkg/datapoint/value"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/bn"
+)
+
+func testDataPointV2() *DataPointV2 {
+	tick := func(price float64) value.Tick {
+		return value.Tick{
+			Pair:  value.Pair{Base: "AAA", Quote: "BBB"},
+			Price: bn.Float(price),
+		}
+	}
+	return &DataPointV2{
+		Model: "AAA/BBB",
+		Value: datapoint.Point{
+			Value: tick(42),
+			Time:  time.Unix(1234567890, 123456789),
+			SubPoints: []datapoint.Point{
+				{Value: tick(41), Time: time.Unix(1234567890, 1)},
+				{Value: tick(43), Time: time.Unix(1234567890, 2)},
+			},
+			Meta: map[string]any{"type": "median"},
+		},
+		Signatures: []DataPointSignature{
+			{
+				Signature:       types.MustSignatureFromBytes(bytes.Repeat([]byte{0x01}, 65)),
+				SignatureScheme: datapoint.SignatureSchemeLegacy,
+			},
+			{
+				Signature:       types.MustSignatureFromBytes(bytes.Repeat([]byte{0x02}, 65)),
+				SignatureScheme: datapoint.SignatureSchemeEIP712,
+			},
+		},
+	}
+}
+
+func TestDataPointV2_MarshallBinary(t *testing.T) {
+	tests := []struct {
+		name    string
+		compact bool
+	}{
+		{name: "full", compact: false},
+		{name: "compact", compact: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := testDataPointV2()
+			msg.Compact = tt.compact
+			bin, err := msg.MarshallBinary()
+			require.NoError(t, err)
+
+			var dec DataPointV2
+			require.NoError(t, dec.UnmarshallBinary(bin))
+			assert.Equal(t, msg.Model, dec.Model)
+			assert.Equal(t, msg.Signatures, dec.Signatures)
+			assert.Equal(t, tt.compact, dec.Compact)
+			assert.True(t, msg.Value.Time.Equal(dec.Value.Time))
+			assert.Equal(t, "42", dec.Value.Value.(value.Tick).Price.String())
+			assert.Equal(t, "median", dec.Value.Meta["type"])
+			if tt.compact {
+				assert.Empty(t, dec.Value.SubPoints)
+			} else {
+				require.Len(t, dec.Value.SubPoints, 2)
+				assert.True(t, msg.Value.SubPoints[1].Time.Equal(dec.Value.SubPoints[1].Time))
+			}
+		})
+	}
+}
+
+func TestDataPointV2_MarshallBinary_TooManySignatures(t *testing.T) {
+	msg := testDataPointV2()
+	msg.Signatures = make([]DataPointSignature, MaxDataPointSignatures+1)
+	_, err := msg.MarshallBinary()
+	require.Error(t, err)
+}
+
+func TestNewDataPointV2(t *testing.T) {
+	msg := testDataPointV2()
+	dps := msg.DataPoints()
+	require.Len(t, dps, 2)
+	assert.Equal(t, datapoint.SignatureSchemeEIP712, dps[1].SignatureScheme)
+	assert.Equal(t, msg.Signatures[1].Signature, dps[1].Signature)
+
+	v2, err := NewDataPointV2(dps...)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Model, v2.Model)
+	assert.Equal(t, msg.Signatures, v2.Signatures)
+
+	dps[1].Model = "CCC/DDD"
+	_, err = NewDataPointV2(dps...)
+	require.Error(t, err)
+
+	_, err = NewDataPointV2()
+	require.Error(t, err)
+}
+
+func FuzzDataPointV2_UnmarshallBinary(f *testing.F) {
+	bin, err := testDataPointV2().MarshallBinary()
+	require.NoError(f, err)
+	f.Add(bin)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_ = (&DataPointV2{}).UnmarshallBinary(data)
+	})
+}
@@ -211,11 +211,11 @@ func (m *MuSigSignature) toProtobuf() *pb.MuSigSignatureMessage {
 		ComputedAtTimestamp: m.ComputedAt.Unix(),
 		MsgType:             m.MsgType,
 		MsgBody:             m.MsgBody.Bytes(),
+		MsgMeta:             m.MsgMeta,
 		Commitment:          m.Commitment.Bytes(),
 		Signers:             make([][]byte, len(m.Signers)),
 		SchnorrSignature:    m.SchnorrSignature.Bytes(),
 	}
-	m.Signers = make([]types.Address, len(msg.Signers))
 	for i, signer := range m.Signers {
 		msg.Signers[i] = signer.Bytes()
 	}
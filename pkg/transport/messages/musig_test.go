@@ -0,0 +1,53 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuSigSignature_Marshalling(t *testing.T) {
+	sig := &MuSigSignature{
+		SessionID:  types.MustHashFromHex("0x1111111111111111111111111111111111111111111111111111111111111111", types.PadNone),
+		ComputedAt: time.Unix(1234567890, 0),
+		MsgType:    "scribe",
+		MsgBody:    types.MustHashFromHex("0x2222222222222222222222222222222222222222222222222222222222222222", types.PadNone),
+		MsgMeta: map[string][]byte{
+			"wat": []byte("ETH/USD"),
+			"val": {1, 2, 3},
+			"age": {4, 5, 6},
+		},
+		Commitment: types.MustAddressFromHex("0x3333333333333333333333333333333333333333"),
+		Signers: []types.Address{
+			types.MustAddressFromHex("0x4444444444444444444444444444444444444444"),
+			types.MustAddressFromHex("0x5555555555555555555555555555555555555555"),
+		},
+		SchnorrSignature: big.NewInt(42),
+	}
+
+	b, err := sig.MarshallBinary()
+	require.NoError(t, err)
+
+	got := &MuSigSignature{}
+	require.NoError(t, got.UnmarshallBinary(b))
+	assert.Equal(t, sig, got)
+}
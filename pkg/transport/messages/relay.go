@@ -0,0 +1,70 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messages
+
+import (
+	"time"
+
+	"github.com/defiweb/go-eth/types"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages/pb"
+)
+
+const RelayIntentV1MessageName = "relay_intent/v1"
+
+// RelayIntent is sent by a relay that is about to update a contract, so
+// other relays can back off and avoid sending duplicate transactions.
+type RelayIntent struct {
+	// ContractAddress is the address of the contract to be updated.
+	ContractAddress types.Address `json:"contract_address"`
+
+	// DataModel is the data model of the contract.
+	DataModel string `json:"data_model"`
+
+	// Age is the time of the last contract update. Together with the
+	// contract address, it identifies the update round.
+	Age time.Time `json:"age"`
+
+	// Timestamp is the time when the intent was sent.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MarshallBinary implements the transport.Message interface.
+func (m *RelayIntent) MarshallBinary() ([]byte, error) {
+	return proto.Marshal(&pb.RelayIntentMessage{
+		ContractAddress: m.ContractAddress.Bytes(),
+		DataModel:       m.DataModel,
+		Age:             m.Age.Unix(),
+		Timestamp:       m.Timestamp.Unix(),
+	})
+}
+
+// UnmarshallBinary implements the transport.Message interface.
+func (m *RelayIntent) UnmarshallBinary(data []byte) (err error) {
+	msg := pb.RelayIntentMessage{}
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+	m.ContractAddress, err = types.AddressFromBytes(msg.ContractAddress)
+	if err != nil {
+		return err
+	}
+	m.DataModel = msg.DataModel
+	m.Age = time.Unix(msg.Age, 0)
+	m.Timestamp = time.Unix(msg.Timestamp, 0)
+	return nil
+}
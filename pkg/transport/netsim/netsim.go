@@ -0,0 +1,363 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package netsim provides a transport wrapper that simulates adverse
+// network conditions, such as latency, message loss, reordering, duplication
+// and network partitions. It is intended to be used in tests.
+package netsim
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+)
+
+// Latency is a distribution of message delivery delays.
+type Latency interface {
+	// Sample returns a random delay using the given source of randomness.
+	Sample(r *rand.Rand) time.Duration
+}
+
+// ConstantLatency delays every message by the same duration.
+type ConstantLatency time.Duration
+
+// Sample implements the Latency interface.
+func (l ConstantLatency) Sample(_ *rand.Rand) time.Duration {
+	return time.Duration(l)
+}
+
+// UniformLatency delays messages by a duration uniformly distributed
+// between Min and Max.
+type UniformLatency struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Sample implements the Latency interface.
+func (l UniformLatency) Sample(r *rand.Rand) time.Duration {
+	if l.Max <= l.Min {
+		return l.Min
+	}
+	return l.Min + time.Duration(r.Int63n(int64(l.Max-l.Min)))
+}
+
+// NormalLatency delays messages by a normally distributed duration.
+// Negative samples are truncated to zero.
+type NormalLatency struct {
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+// Sample implements the Latency interface.
+func (l NormalLatency) Sample(r *rand.Rand) time.Duration {
+	d := l.Mean + time.Duration(r.NormFloat64()*float64(l.StdDev))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Conditions describes network conditions for a topic.
+//
+// Messages are delayed independently of each other, so a latency
+// distribution with a non-zero variance causes messages to be reordered.
+type Conditions struct {
+	// Latency is the distribution of message delays. If nil, messages are
+	// delivered without delay.
+	Latency Latency
+
+	// DropRate is the probability that a message is dropped.
+	DropRate float64
+
+	// DuplicateRate is the probability that a message is delivered twice.
+	// The duplicate is delayed independently of the original message.
+	DuplicateRate float64
+}
+
+// Partition describes a period of time during which messages from the
+// given authors are not delivered.
+//
+// Partitions are applied only to received messages. To simulate
+// a symmetric partition between two nodes, it must be configured on both
+// of them.
+type Partition struct {
+	// Start is the time since the simulator was started at which the
+	// partition begins.
+	Start time.Duration
+
+	// End is the time since the simulator was started at which the
+	// partition ends.
+	End time.Duration
+
+	// Authors is the list of message authors isolated from the node.
+	Authors [][]byte
+}
+
+// Config is the configuration for the Simulator.
+type Config struct {
+	// Transport is the wrapped transport.
+	Transport transport.Transport
+
+	// Conditions are the default conditions for all topics.
+	Conditions Conditions
+
+	// Topics are conditions for specific topics. They override the
+	// default conditions.
+	Topics map[string]Conditions
+
+	// Partitions is the schedule of network partitions.
+	Partitions []Partition
+
+	// Seed is the seed for the random number generator. Given the same seed
+	// and the same sequence of messages on a topic, the simulator makes
+	// the same decisions about message delays, losses and duplicates.
+	Seed int64
+}
+
+// Simulator is a transport wrapper that simulates network conditions for
+// received messages. Broadcast messages are passed to the wrapped transport
+// unchanged.
+//
+// The simulator does not start the wrapped transport, so multiple simulators
+// may wrap the same transport, e.g. instances of the local transport
+// created using the WithAuthor method, to simulate multiple nodes. The
+// wrapped transport must be started separately.
+type Simulator struct {
+	mu        sync.Mutex
+	ctx       context.Context
+	waitCh    chan error
+	doneCh    chan struct{}
+	startedAt time.Time
+
+	t   transport.Transport
+	cfg Config
+}
+
+// New returns a new Simulator.
+func New(cfg Config) (*Simulator, error) {
+	if cfg.Transport == nil {
+		return nil, errors.New("transport must not be nil")
+	}
+	if err := validateConditions(cfg.Conditions); err != nil {
+		return nil, err
+	}
+	for _, c := range cfg.Topics {
+		if err := validateConditions(c); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range cfg.Partitions {
+		if p.End < p.Start {
+			return nil, errors.New("partition must not end before it starts")
+		}
+	}
+	return &Simulator{
+		waitCh: make(chan error),
+		doneCh: make(chan struct{}),
+		t:      cfg.Transport,
+		cfg:    cfg,
+	}, nil
+}
+
+// Start implements the supervisor.Service interface.
+//
+// Partition schedules are relative to the time at which the simulator is
+// started.
+func (s *Simulator) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	s.ctx = ctx
+	s.startedAt = time.Now()
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *Simulator) Wait() <-chan error {
+	return s.waitCh
+}
+
+// Broadcast implements the transport.Transport interface.
+func (s *Simulator) Broadcast(topic string, message transport.Message) error {
+	return s.t.Broadcast(topic, message)
+}
+
+// Messages implements the transport.Transport interface.
+func (s *Simulator) Messages(topic string) <-chan transport.ReceivedMessage {
+	in := s.t.Messages(topic)
+	if in == nil {
+		return nil
+	}
+	out := make(chan transport.ReceivedMessage)
+	go s.deliveryRoutine(topic, in, out)
+	return out
+}
+
+// deliveryRoutine reads messages from the wrapped transport and delivers
+// them to the out channel according to the topic conditions.
+func (s *Simulator) deliveryRoutine(topic string, in <-chan transport.ReceivedMessage, out chan transport.ReceivedMessage) {
+	defer close(out)
+	var (
+		cond  = s.conditions(topic)
+		rnd   = rand.New(rand.NewSource(s.cfg.Seed ^ topicSeed(topic))) //nolint:gosec
+		queue = &deliveryQueue{}
+		timer = time.NewTimer(0)
+		seq   uint64
+	)
+	defer timer.Stop()
+	<-timer.C
+	for {
+		var timerCh <-chan time.Time
+		if queue.Len() > 0 {
+			timer.Reset(time.Until((*queue)[0].at))
+			timerCh = timer.C
+		}
+		select {
+		case <-s.doneCh:
+			return
+		case msg, ok := <-in:
+			if !ok {
+				return
+			}
+			if s.partitioned(msg.Author) || rnd.Float64() < cond.DropRate {
+				break
+			}
+			copies := 1
+			if rnd.Float64() < cond.DuplicateRate {
+				copies = 2
+			}
+			for i := 0; i < copies; i++ {
+				var delay time.Duration
+				if cond.Latency != nil {
+					delay = cond.Latency.Sample(rnd)
+				}
+				seq++
+				heap.Push(queue, delivery{at: time.Now().Add(delay), seq: seq, msg: msg})
+			}
+		case <-timerCh:
+			for queue.Len() > 0 && !(*queue)[0].at.After(time.Now()) {
+				d := heap.Pop(queue).(delivery)
+				select {
+				case <-s.doneCh:
+					return
+				case out <- d.msg:
+				}
+			}
+			continue
+		}
+		// Stop the timer before it is reset in the next iteration.
+		if timerCh != nil && !timer.Stop() {
+			<-timer.C
+		}
+	}
+}
+
+// conditions returns the conditions for the given topic.
+func (s *Simulator) conditions(topic string) Conditions {
+	if c, ok := s.cfg.Topics[topic]; ok {
+		return c
+	}
+	return s.cfg.Conditions
+}
+
+// partitioned returns true if messages from the given author must not be
+// delivered because of an active partition.
+func (s *Simulator) partitioned(author []byte) bool {
+	s.mu.Lock()
+	startedAt := s.startedAt
+	s.mu.Unlock()
+	if startedAt.IsZero() {
+		return false
+	}
+	elapsed := time.Since(startedAt)
+	for _, p := range s.cfg.Partitions {
+		if elapsed < p.Start || elapsed >= p.End {
+			continue
+		}
+		for _, a := range p.Authors {
+			if bytes.Equal(a, author) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contextCancelHandler handles context cancellation.
+func (s *Simulator) contextCancelHandler() {
+	defer func() { close(s.waitCh) }()
+	<-s.ctx.Done()
+	close(s.doneCh)
+}
+
+func validateConditions(c Conditions) error {
+	if c.DropRate < 0 || c.DropRate > 1 {
+		return errors.New("drop rate must be between 0 and 1")
+	}
+	if c.DuplicateRate < 0 || c.DuplicateRate > 1 {
+		return errors.New("duplicate rate must be between 0 and 1")
+	}
+	return nil
+}
+
+// topicSeed derives a seed from the topic name, so every topic uses
+// a different sequence of random numbers.
+func topicSeed(topic string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(topic))
+	return int64(h.Sum64())
+}
+
+type delivery struct {
+	at  time.Time
+	seq uint64
+	msg transport.ReceivedMessage
+}
+
+// deliveryQueue is a priority queue of messages ordered by delivery time.
+type deliveryQueue []delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x any) { *q = append(*q, x.(delivery)) }
+
+func (q *deliveryQueue) Pop() any {
+	old := *q
+	d := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return d
+}
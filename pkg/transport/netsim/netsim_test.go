@@ -0,0 +1,197 @@
+//  Copyright (C) 2021-2023 Chronicle Labs, Inc.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package netsim
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+)
+
+type testMsg struct {
+	Val string
+}
+
+func (t *testMsg) MarshallBinary() ([]byte, error) {
+	return []byte(t.Val), nil
+}
+
+func (t *testMsg) UnmarshallBinary(bytes []byte) error {
+	t.Val = string(bytes)
+	return nil
+}
+
+const testTopic = "test"
+
+// setup starts a local transport and a simulator that wraps it.
+func setup(ctx context.Context, t *testing.T, cfg Config) (*local.Local, *Simulator) {
+	l := local.New([]byte("test"), 100, map[string]transport.Message{testTopic: (*testMsg)(nil)})
+	require.NoError(t, l.Start(ctx))
+	cfg.Transport = l
+	s, err := New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.Start(ctx))
+	return l, s
+}
+
+// collect returns values of messages received within the given duration.
+func collect(ch <-chan transport.ReceivedMessage, d time.Duration) []string {
+	var vals []string
+	timeout := time.After(d)
+	for {
+		select {
+		case msg := <-ch:
+			vals = append(vals, msg.Message.(*testMsg).Val)
+		case <-timeout:
+			return vals
+		}
+	}
+}
+
+// broadcast sends n messages with consecutive numbers as values.
+func broadcast(t *testing.T, tr transport.Transport, n int) {
+	for i := 0; i < n; i++ {
+		require.NoError(t, tr.Broadcast(testTopic, &testMsg{Val: strconv.Itoa(i)}))
+	}
+}
+
+func TestSimulator_Passthrough(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{})
+	ch := s.Messages(testTopic)
+	broadcast(t, l, 3)
+
+	assert.Equal(t, []string{"0", "1", "2"}, collect(ch, 100*time.Millisecond))
+}
+
+func TestSimulator_Drop(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{
+		Topics: map[string]Conditions{testTopic: {DropRate: 1}},
+	})
+	ch := s.Messages(testTopic)
+	broadcast(t, l, 3)
+
+	assert.Empty(t, collect(ch, 100*time.Millisecond))
+}
+
+func TestSimulator_Duplicate(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{
+		Conditions: Conditions{DuplicateRate: 1},
+	})
+	ch := s.Messages(testTopic)
+	broadcast(t, l, 2)
+
+	assert.Equal(t, []string{"0", "0", "1", "1"}, collect(ch, 100*time.Millisecond))
+}
+
+func TestSimulator_Latency(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{
+		Conditions: Conditions{Latency: ConstantLatency(200 * time.Millisecond)},
+	})
+	ch := s.Messages(testTopic)
+	broadcast(t, l, 1)
+
+	assert.Empty(t, collect(ch, 100*time.Millisecond))
+	assert.Equal(t, []string{"0"}, collect(ch, 200*time.Millisecond))
+}
+
+func TestSimulator_Reorder(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{
+		Conditions: Conditions{Latency: UniformLatency{Min: 0, Max: 200 * time.Millisecond}},
+		Seed:       1,
+	})
+	ch := s.Messages(testTopic)
+	broadcast(t, l, 10)
+
+	vals := collect(ch, 300*time.Millisecond)
+	assert.Len(t, vals, 10)
+	assert.NotEqual(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, vals)
+}
+
+func TestSimulator_Deterministic(t *testing.T) {
+	run := func(seed int64) []string {
+		ctx, ctxCancel := context.WithCancel(context.Background())
+		defer ctxCancel()
+
+		l, s := setup(ctx, t, Config{
+			Conditions: Conditions{DropRate: 0.5, DuplicateRate: 0.5},
+			Seed:       seed,
+		})
+		ch := s.Messages(testTopic)
+		broadcast(t, l, 20)
+		return collect(ch, 100*time.Millisecond)
+	}
+	assert.Equal(t, run(1), run(1))
+	assert.NotEqual(t, run(1), run(2))
+}
+
+func TestSimulator_Partition(t *testing.T) {
+	ctx, ctxCancel := context.WithCancel(context.Background())
+	defer ctxCancel()
+
+	l, s := setup(ctx, t, Config{
+		Partitions: []Partition{{
+			Start:   0,
+			End:     200 * time.Millisecond,
+			Authors: [][]byte{[]byte("isolated")},
+		}},
+	})
+	ch := s.Messages(testTopic)
+	isolated := l.WithAuthor([]byte("isolated"))
+
+	// During the partition, only messages from other authors are delivered:
+	require.NoError(t, isolated.Broadcast(testTopic, &testMsg{Val: "a"}))
+	require.NoError(t, l.Broadcast(testTopic, &testMsg{Val: "b"}))
+	assert.Equal(t, []string{"b"}, collect(ch, 100*time.Millisecond))
+
+	// After the partition ends, messages are delivered again:
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, isolated.Broadcast(testTopic, &testMsg{Val: "c"}))
+	assert.Equal(t, []string{"c"}, collect(ch, 100*time.Millisecond))
+}
+
+func TestNew_Validation(t *testing.T) {
+	l := local.New(nil, 0, nil)
+	_, err := New(Config{})
+	assert.Error(t, err)
+	_, err = New(Config{Transport: l, Conditions: Conditions{DropRate: 2}})
+	assert.Error(t, err)
+	_, err = New(Config{Transport: l, Topics: map[string]Conditions{testTopic: {DuplicateRate: -1}}})
+	assert.Error(t, err)
+	_, err = New(Config{Transport: l, Partitions: []Partition{{Start: time.Second}}})
+	assert.Error(t, err)
+}
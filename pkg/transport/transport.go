@@ -108,10 +108,24 @@ func (mm MessageMap) SelectByTopic(topics ...string) (MessageMap, error) {
 	return maputil.Select(mm, topics)
 }
 
+// BridgeTopics is a list of topics on which bridges are allowed to send
+// messages. A bridge becomes the author of relayed messages on the transport
+// level, so only messages that carry the signatures of the feeds that
+// created them may be relayed.
+var BridgeTopics = []string{
+	messages.PriceV0MessageName,
+	messages.PriceV1MessageName,
+	messages.DataPointV1MessageName,
+	messages.DataPointV2MessageName,
+	messages.DataPointBatchV1MessageName,
+}
+
 var AllMessagesMap = MessageMap{
 	messages.PriceV0MessageName:                    (*messages.Price)(nil), //nolint:staticcheck
 	messages.PriceV1MessageName:                    (*messages.Price)(nil), //nolint:staticcheck
 	messages.DataPointV1MessageName:                (*messages.DataPoint)(nil),
+	messages.DataPointV2MessageName:                (*messages.DataPointV2)(nil),
+	messages.DataPointBatchV1MessageName:           (*messages.DataPointBatch)(nil),
 	messages.GreetV1MessageName:                    (*messages.Greet)(nil),
 	messages.EventV1MessageName:                    (*messages.Event)(nil),
 	messages.MuSigStartV1MessageName:               (*messages.MuSigInitialize)(nil),
@@ -120,4 +134,5 @@ var AllMessagesMap = MessageMap{
 	messages.MuSigPartialSignatureV1MessageName:    (*messages.MuSigPartialSignature)(nil),
 	messages.MuSigSignatureV1MessageName:           (*messages.MuSigSignature)(nil),
 	messages.MuSigOptimisticSignatureV1MessageName: (*messages.MuSigOptimisticSignature)(nil),
+	messages.RelayIntentV1MessageName:              (*messages.RelayIntent)(nil),
 }
@@ -40,6 +40,8 @@ func TestMessageMap_Keys(t *testing.T) {
 			mm:   AllMessagesMap,
 			want: []string{
 				"data_point/v1",
+				"data_point/v2",
+				"data_point_batch/v1",
 				"event/v1",
 				"greet/v1",
 				"musig_commitment/v1",
@@ -50,6 +52,7 @@ func TestMessageMap_Keys(t *testing.T) {
 				"musig_terminate/v1",
 				"price/v0",
 				"price/v1",
+				"relay_intent/v1",
 			},
 		},
 	}
@@ -82,6 +85,8 @@ func TestMessageMap_SelectByTopic(t *testing.T) {
 			mm:   AllMessagesMap,
 			topics: []string{
 				"data_point/v1",
+				"data_point/v2",
+				"data_point_batch/v1",
 				"event/v1",
 				"greet/v1",
 				"musig_commitment/v1",
@@ -92,6 +97,7 @@ func TestMessageMap_SelectByTopic(t *testing.T) {
 				"musig_terminate/v1",
 				"price/v0",
 				"price/v1",
+				"relay_intent/v1",
 			},
 			want: AllMessagesMap,
 		},
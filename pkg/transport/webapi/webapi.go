@@ -56,6 +56,17 @@ const (
 	// consumePath is the URL path for the consume endpoint.
 	consumePath = "/consume"
 
+	// pullPath is the URL path for the pull endpoint.
+	pullPath = "/pull"
+
+	// signatureHeader is the HTTP header that contains the URL signature
+	// of the message pack served by the pull endpoint.
+	signatureHeader = "X-Webapi-Signature"
+
+	// maxPullBodySize is the maximum size of the response body of the pull
+	// endpoint.
+	maxPullBodySize = 10 * 1024 * 1024
+
 	// messageChanSize is the size of the message channel. It is used to buffer
 	// messages before they are consumed.
 	messageChanSize = 10000
@@ -131,6 +142,16 @@ const (
 // The HTTP server returns HTTP 200 OK response if the request is valid.
 // Otherwise, it returns 429 Too Many Requests response if producer sends
 // messages too often or 400 Bad Request response for any other error.
+//
+// Optionally, message producers may serve the last flushed message pack
+// on the /pull endpoint, and consumers may periodically fetch it using
+// the GET method. This allows consumers that are not reachable by producers,
+// e.g. behind NAT, or consumers that have just started, to receive messages.
+// The response body is the same as the body of the POST request, and the URL
+// signature is sent in the X-Webapi-Signature header as a query string. The
+// consumer verifies pulled message packs in the same way as pushed ones.
+// A consumer may use both modes at the same time, the same message pack is
+// delivered only once.
 type WebAPI struct {
 	mu     sync.RWMutex
 	ctx    context.Context
@@ -138,21 +159,29 @@ type WebAPI struct {
 
 	// State fields:
 	messagePack *pb.MessagePack                                        // Message pack to be sent on next flush.
+	pullPack    []byte                                                 // Last flushed message pack served on the pull endpoint.
+	pullQuery   string                                                 // URL signature of the pullPack.
+	pullTime    time.Time                                              // Time of the last flush.
+	lastReqsMu  sync.Mutex                                             // Mutex for lastReqs.
 	lastReqs    map[types.Address]time.Time                            // Last timestamp received from each producer.
 	msgCh       map[string]chan transport.ReceivedMessage              // Channels for received messages.
 	msgChFO     map[string]*chanutil.FanOut[transport.ReceivedMessage] // Fan-out channels for received messages.
 
 	// Configuration fields:
-	addressBook  AddressBook
-	topics       map[string]transport.Message
-	allowlist    []types.Address
-	flushTicker  *timeutil.Ticker
-	signer       wallet.Key
-	client       *http.Client
-	server       *httpserver.HTTPServer
-	rand         io.Reader
-	maxClockSkew time.Duration
-	log          log.Logger
+	addressBook     AddressBook
+	pullAddressBook AddressBook
+	topics          map[string]transport.Message
+	allowlist       []types.Address
+	bridges         []types.Address
+	flushTicker     *timeutil.Ticker
+	pullTicker      *timeutil.Ticker
+	servePull       bool
+	signer          wallet.Key
+	client          *http.Client
+	server          *httpserver.HTTPServer
+	rand            io.Reader
+	maxClockSkew    time.Duration
+	log             log.Logger
 
 	// Internal fields:
 	recover crypto.Recoverer
@@ -179,9 +208,14 @@ type Config struct {
 	Topics map[string]transport.Message
 
 	// AuthorAllowlist is a list of allowed message authors. Only messages from
-	// these addresses will be accepted.
+	// these addresses, or from BridgeAllowlist, will be accepted.
 	AuthorAllowlist []types.Address
 
+	// BridgeAllowlist is a list of bridges that are allowed to relay messages
+	// signed by authors from the AuthorAllowlist. Messages from bridges are
+	// accepted only on the transport.BridgeTopics.
+	BridgeAllowlist []types.Address
+
 	// FlushTicker specifies how often the producer will flush messages
 	// to the consumers. If FlushTicker is nil, default ticker with 1 minute
 	// interval is used.
@@ -193,6 +227,27 @@ type Config struct {
 	// If not provided, message broadcast will not be available.
 	Signer wallet.Key
 
+	// ServePull enables the pull endpoint that serves the last flushed
+	// message pack to consumers. Requires Signer.
+	//
+	// The endpoint is not authenticated nor rate limited, the message pack
+	// is served to anyone who requests it.
+	ServePull bool
+
+	// PullAddressBook is an optional address book that provides the list
+	// of message producers from which messages are pulled. If nil, messages
+	// are not pulled.
+	PullAddressBook AddressBook
+
+	// PullTicker specifies how often messages are pulled from producers.
+	// Cannot be nil if PullAddressBook is not nil. The interval must not
+	// be shorter than the FlushTicker interval, producers do not update
+	// the message pack more often than that.
+	//
+	// Pulled message packs are read in full, up to 10 MB, before their
+	// signature is verified.
+	PullTicker *timeutil.Ticker
+
 	// Timeout is a timeout for HTTP requests.
 	//
 	// If timeout is zero, default value will be used (60 seconds).
@@ -233,6 +288,15 @@ func New(cfg Config) (*WebAPI, error) {
 	if cfg.FlushTicker.Duration() > 0 && cfg.Timeout >= cfg.FlushTicker.Duration() {
 		return nil, errors.New("timeout must be less or equal to flush interval")
 	}
+	if cfg.ServePull && cfg.Signer == nil {
+		return nil, errors.New("signer must be provided to serve pull endpoint")
+	}
+	if cfg.PullAddressBook != nil && cfg.PullTicker == nil {
+		return nil, errors.New("pull interval must be provided")
+	}
+	if cfg.PullTicker != nil && cfg.PullTicker.Duration() < cfg.FlushTicker.Duration() {
+		return nil, errors.New("pull interval must be greater or equal to flush interval")
+	}
 	if cfg.Timeout == 0 {
 		cfg.Timeout = defaultTimeout
 	}
@@ -260,23 +324,27 @@ func New(cfg Config) (*WebAPI, error) {
 		client = &http.Client{Timeout: cfg.Timeout}
 	}
 	w := &WebAPI{
-		waitCh:       make(chan error),
-		topics:       maputil.Copy(cfg.Topics),
-		allowlist:    sliceutil.Copy(cfg.AuthorAllowlist),
-		addressBook:  cfg.AddressBook,
-		flushTicker:  cfg.FlushTicker,
-		client:       client,
-		server:       server,
-		signer:       cfg.Signer,
-		lastReqs:     make(map[types.Address]time.Time),
-		msgCh:        make(map[string]chan transport.ReceivedMessage),
-		msgChFO:      make(map[string]*chanutil.FanOut[transport.ReceivedMessage]),
-		maxClockSkew: cfg.MaxClockSkew,
-		rand:         cfg.Rand,
-		log:          cfg.Logger.WithField("tag", LoggerTag),
-		recover:      crypto.ECRecoverer,
-	}
-	w.server.SetHandler(http.HandlerFunc(w.consumeHandler))
+		waitCh:          make(chan error),
+		topics:          maputil.Copy(cfg.Topics),
+		allowlist:       sliceutil.Copy(cfg.AuthorAllowlist),
+		bridges:         sliceutil.Copy(cfg.BridgeAllowlist),
+		addressBook:     cfg.AddressBook,
+		pullAddressBook: cfg.PullAddressBook,
+		flushTicker:     cfg.FlushTicker,
+		pullTicker:      cfg.PullTicker,
+		servePull:       cfg.ServePull,
+		client:          client,
+		server:          server,
+		signer:          cfg.Signer,
+		lastReqs:        make(map[types.Address]time.Time),
+		msgCh:           make(map[string]chan transport.ReceivedMessage),
+		msgChFO:         make(map[string]*chanutil.FanOut[transport.ReceivedMessage]),
+		maxClockSkew:    cfg.MaxClockSkew,
+		rand:            cfg.Rand,
+		log:             cfg.Logger.WithField("tag", LoggerTag),
+		recover:         crypto.ECRecoverer,
+	}
+	w.server.SetHandler(http.HandlerFunc(w.handler))
 	return w, nil
 }
 
@@ -299,6 +367,10 @@ func (w *WebAPI) Start(ctx context.Context) error {
 	}
 	w.flushTicker.Start(ctx)
 	go w.flushRoutine(ctx)
+	if w.pullAddressBook != nil {
+		w.pullTicker.Start(ctx)
+		go w.pullRoutine(ctx)
+	}
 	go w.contextCancelHandler()
 	return nil
 }
@@ -364,6 +436,17 @@ func (w *WebAPI) flushMessages(ctx context.Context, t time.Time) error {
 	if err != nil {
 		return err
 	}
+	if w.servePull {
+		// Store the message pack, so it can be served on the pull endpoint
+		// until the next flush.
+		url, err := signURL(pullPath, t, w.signer, w.rand)
+		if err != nil {
+			return err
+		}
+		w.pullPack = bin
+		w.pullQuery = url[strings.Index(url, "?")+1:]
+		w.pullTime = t
+	}
 	cons, err := w.addressBook.Consumers(ctx)
 	if err != nil {
 		return err
@@ -418,6 +501,49 @@ func (w *WebAPI) doHTTPRequest(ctx context.Context, addr string, data []byte, t
 	defer res.Body.Close()
 }
 
+// handler routes incoming requests to the consume and pull handlers.
+func (w *WebAPI) handler(res http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == pullPath {
+		w.pullHandler(res, req)
+		return
+	}
+	w.consumeHandler(res, req)
+}
+
+// pullHandler serves the last flushed message pack to consumers.
+//
+// Request must be a GET request to the /pull path. If there is no message
+// pack flushed within the last flush interval, the handler returns
+// HTTP 204 No Content response.
+func (w *WebAPI) pullHandler(res http.ResponseWriter, req *http.Request) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.ctx.Err() != nil || !w.servePull {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if req.Method != http.MethodGet {
+		w.log.
+			WithFields(log.Fields{
+				"method": req.Method,
+				"addr":   req.RemoteAddr,
+				"url":    req.URL.String(),
+			}).
+			Warn("Invalid request method")
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if w.pullPack == nil || time.Since(w.pullTime) > w.flushTicker.Duration() {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	res.Header().Set("Content-Type", "application/x-protobuf")
+	res.Header().Set("Content-Encoding", "gzip")
+	res.Header().Set(signatureHeader, w.pullQuery)
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write(w.pullPack)
+}
+
 // consumeHandler handles incoming messages from consumers.
 //
 // Request must be a POST request to the /consume path with a protobuf-encoded
@@ -494,13 +620,27 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 	fields["author"] = requestAuthor
 	fields["timestamp"] = timestamp
 
+	res.WriteHeader(w.handleMessagePack(fields, *requestAuthor, timestamp, req.Body))
+}
+
+// handleMessagePack reads and verifies the gzipped protobuf-encoded
+// MessagePack received from the producer and sends its messages to the
+// msgCh channels.
+// The author and timestamp are recovered from the URL signature.
+//
+// It returns the HTTP status code that describes the result.
+//
+// The w.mu mutex must be read-locked by the caller.
+//
+//nolint:funlen
+func (w *WebAPI) handleMessagePack(fields log.Fields, requestAuthor types.Address, timestamp time.Time, r io.Reader) int {
 	// Verify if the feed is allowed to send messages.
-	if !sliceutil.Contains(w.allowlist, *requestAuthor) {
+	isFeed := sliceutil.Contains(w.allowlist, requestAuthor)
+	if !isFeed && !sliceutil.Contains(w.bridges, requestAuthor) {
 		w.log.
 			WithFields(fields).
 			Warn("Feed not allowed to send messages")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 
 	// Message timestamp must be within the allowed time window:
@@ -510,44 +650,42 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 		w.log.
 			WithFields(fields).
 			Warn("Timestamp too far in the future")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 	if timestamp.Before(currentTimestamp.Add(-w.flushTicker.Duration() - w.maxClockSkew)) {
 		w.log.
 			WithFields(fields).
 			Warn("Timestamp too far in the past")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 
 	// Message timestamp must be newer than the last received message by
 	// flushInterval - maxClockSkew.
-	if timestamp.Before(w.lastReqs[*requestAuthor].Add(w.flushTicker.Duration() - w.maxClockSkew)) {
+	w.lastReqsMu.Lock()
+	if timestamp.Before(w.lastReqs[requestAuthor].Add(w.flushTicker.Duration() - w.maxClockSkew)) {
+		w.lastReqsMu.Unlock()
 		w.log.
 			WithFields(fields).
 			Warn("Too many messages received in a short time")
-		res.WriteHeader(http.StatusTooManyRequests)
-		return
+		return http.StatusTooManyRequests
 	}
-	w.lastReqs[*requestAuthor] = timestamp
+	w.lastReqs[requestAuthor] = timestamp
+	w.lastReqsMu.Unlock()
 
-	// Read the request body.
-	body, err := io.ReadAll(req.Body)
+	// Read the message pack.
+	body, err := io.ReadAll(r)
 	if err != nil {
 		w.log.WithFields(fields).
 			WithError(err).
 			Warn("Unable to read request body")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 	body, err = gzipDecompress(body)
 	if err != nil {
 		w.log.WithFields(fields).
 			WithError(err).
 			Warn("Unable to decompress request body")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 
 	// Unmarshal protobuf-encoded MessagePack.
@@ -557,8 +695,7 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 			WithFields(fields).
 			WithError(err).
 			Warn("Unable to decode protobuf message")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 
 	// Verify the message signature and verify that the message signer
@@ -570,16 +707,14 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 			WithFields(fields).
 			WithError(err).
 			Warn("Invalid message pack signature")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
-	if *messagePackSigner != *requestAuthor {
+	if *messagePackSigner != requestAuthor {
 		w.log.
 			WithFields(fields).
 			WithField("signer", messagePackSigner).
 			Warn("Message signer does not match request author")
-		res.WriteHeader(http.StatusBadRequest)
-		return
+		return http.StatusBadRequest
 	}
 
 	// Send messages from the MessagePack to the msgCh channel.
@@ -588,6 +723,13 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 		if !ok {
 			continue // Ignore messages for unknown topics.
 		}
+		if !isFeed && !sliceutil.Contains(transport.BridgeTopics, topic) {
+			w.log.
+				WithFields(fields).
+				WithField("topic", topic).
+				Warn("Bridge not allowed to send messages on the topic")
+			continue
+		}
 		for _, bin := range msgs.Data {
 			ref := reflect.TypeOf(typ).Elem()
 			msg := reflect.New(ref).Interface().(transport.Message)
@@ -615,6 +757,110 @@ func (w *WebAPI) consumeHandler(res http.ResponseWriter, req *http.Request) {
 			}
 		}
 	}
+	return http.StatusOK
+}
+
+// pullMessages fetches the last flushed message packs from all producers
+// in the pull address book.
+func (w *WebAPI) pullMessages(ctx context.Context) {
+	prods, err := w.pullAddressBook.Consumers(ctx)
+	if err != nil {
+		w.log.
+			WithError(err).
+			Error("Failed to fetch producer addresses")
+		return
+	}
+	for _, addr := range prods {
+		if !strings.Contains(addr, "://") {
+			addr = "http://" + addr
+		}
+		go w.doPullRequest(ctx, addr)
+	}
+}
+
+// doPullRequest fetches the last flushed message pack from the producer
+// with the given address and handles it in the same way as pushed message
+// packs.
+func (w *WebAPI) doPullRequest(ctx context.Context, addr string) {
+	fields := log.Fields{"addr": addr}
+
+	w.log.WithFields(fields).Debug("Pulling messages from producer")
+
+	// Prepare the request.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(addr, "/")+pullPath, nil)
+	if err != nil {
+		w.log.WithFields(fields).WithError(err).Error("Failed to create request")
+		return
+	}
+	// Setting the Accept-Encoding header disables the transparent
+	// decompression of the response body by the HTTP client.
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	// Send the request.
+	res, err := w.client.Do(req)
+	if err != nil {
+		w.log.WithFields(fields).WithError(err).Error("Failed to pull messages from producer")
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNoContent {
+		return // Nothing to pull.
+	}
+	if res.StatusCode != http.StatusOK {
+		w.log.
+			WithFields(fields).
+			WithField("status", res.StatusCode).
+			Warn("Unexpected response status")
+		return
+	}
+
+	// Only responses with the protobuf content type and the gzip content
+	// encoding are allowed.
+	if h := res.Header.Get("Content-Type"); h != "application/x-protobuf" {
+		w.log.
+			WithFields(fields).
+			WithField("content-type", h).
+			Warn("Invalid content type")
+		return
+	}
+	if h := res.Header.Get("Content-Encoding"); h != "gzip" {
+		w.log.
+			WithFields(fields).
+			WithField("content-encoding", h).
+			Warn("Invalid response encoding")
+		return
+	}
+
+	// Verify the URL signature.
+	requestAuthor, timestamp, err := verifyURL(pullPath+"?"+res.Header.Get(signatureHeader), w.recover)
+	if err != nil {
+		w.log.
+			WithFields(fields).
+			WithError(err).
+			Warn("Invalid request signature")
+		return
+	}
+	fields["author"] = requestAuthor
+	fields["timestamp"] = timestamp
+
+	// Skip message packs that have already been received, either by
+	// a previous pull or by a push from the producer.
+	w.lastReqsMu.Lock()
+	last := w.lastReqs[*requestAuthor]
+	w.lastReqsMu.Unlock()
+	if !timestamp.After(last) {
+		w.log.
+			WithFields(fields).
+			Debug("Message pack already received")
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.ctx.Err() != nil {
+		return
+	}
+	w.handleMessagePack(fields, *requestAuthor, timestamp, io.LimitReader(res.Body, maxPullBodySize))
 }
 
 // flushRoutine periodically sends the buffered messages to the
@@ -637,6 +883,21 @@ func (w *WebAPI) flushRoutine(ctx context.Context) {
 	}
 }
 
+// pullRoutine periodically pulls messages from the producers. The first
+// pull is done immediately after the start, so newly started consumers do
+// not have to wait for the next flush.
+func (w *WebAPI) pullRoutine(ctx context.Context) {
+	w.pullMessages(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.pullTicker.TickCh():
+			w.pullMessages(ctx)
+		}
+	}
+}
+
 // contextCancelHandler handles context cancellation.
 func (w *WebAPI) contextCancelHandler() {
 	defer func() { close(w.waitCh) }()
@@ -64,6 +64,7 @@ func (a *addressBook) Consumers(ctx context.Context) ([]string, error) {
 func Test_WebAPI(t *testing.T) {
 	address1 := types.MustAddressFromHex("0x1234567890123456789012345678901234567890")
 	address2 := types.MustAddressFromHex("0x2345678901234567890123456789012345678901")
+	address3 := types.MustAddressFromHex("0x3456789012345678901234567890123456789012")
 
 	tests := []struct {
 		test func(T *testing.T, l *logMocks.Logger, s *mocks.Key, r *mocks.Recoverer, p, c *WebAPI)
@@ -147,6 +148,34 @@ func Test_WebAPI(t *testing.T) {
 				}, time.Second, time.Millisecond*100)
 			},
 		},
+		{
+			// Bridge not allowed to send messages on the topic.
+			test: func(t *testing.T, l *logMocks.Logger, s *mocks.Key, r *mocks.Recoverer, p, c *WebAPI) {
+				tm := time.Now()
+
+				// Prepare mocks:
+				msgSig := []byte("testdata")
+				urlSig := []byte(fmt.Sprintf("%d30313233343536373839616263646566", tm.Unix()))
+				s.On("SignMessage", msgSig).Return(&fakeSignature, nil).Once()
+				s.On("SignMessage", urlSig).Return(&fakeSignature, nil).Once()
+				r.On("RecoverMessage", msgSig, fakeSignature).Return(&address3, nil).Once()
+				r.On("RecoverMessage", urlSig, fakeSignature).Return(&address3, nil).Once()
+
+				// Send message:
+				require.NoError(t, p.Broadcast("test", &message{data: []byte("data")}))
+				p.flushTicker.TickAt(tm)
+
+				// Wait for error log:
+				assert.Eventually(t, func() bool {
+					for _, m := range l.Mock().Calls {
+						if m.Method == "Warn" && m.Arguments[0].([]any)[0] == "Bridge not allowed to send messages on the topic" {
+							return true
+						}
+					}
+					return false
+				}, time.Second, time.Millisecond*100)
+			},
+		},
 		{
 			// Message too old.
 			test: func(t *testing.T, l *logMocks.Logger, s *mocks.Key, r *mocks.Recoverer, p, c *WebAPI) {
@@ -348,6 +377,7 @@ func Test_WebAPI(t *testing.T) {
 			cons, err := New(Config{
 				Topics:          map[string]transport.Message{"test": (*message)(nil)},
 				AuthorAllowlist: []types.Address{address1},
+				BridgeAllowlist: []types.Address{address3},
 				AddressBook:     ab,
 				Signer:          signer,
 				Timeout:         0,
@@ -450,3 +480,109 @@ func Test_signURL(t *testing.T) {
 	assert.Equal(t, &address, retAddress)
 	assert.Equal(t, tm.Unix(), retTime.Unix())
 }
+
+func Test_WebAPI_Pull(t *testing.T) {
+	address1 := types.MustAddressFromHex("0x1234567890123456789012345678901234567890")
+
+	tests := []struct {
+		name string
+		push bool
+	}{
+		{name: "pull", push: false},
+		{name: "push and pull", push: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ctxCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer ctxCancel()
+
+			// Dependencies:
+			consSrv := httpserver.New(&http.Server{Addr: "127.0.0.1:0"})
+			prodSrv := httpserver.New(&http.Server{Addr: "127.0.0.1:0"})
+			pullTick := timeutil.NewTicker(60 * time.Second)
+			prodTick := timeutil.NewTicker(60 * time.Second)
+			rand := bytes.NewReader([]byte(strings.Repeat("0123456789abcdef", 32)))
+			consAB := &addressBook{addresses: []string{}}
+			prodAB := &addressBook{addresses: []string{}}
+			signer := &mocks.Key{}
+			recoverer := &mocks.Recoverer{}
+			logger := logMocks.New()
+			logger.Mock().On("WithError", mock.Anything).Return(logger)
+			logger.Mock().On("WithField", mock.Anything, mock.Anything).Return(logger)
+			logger.Mock().On("WithFields", mock.Anything).Return(logger)
+			logger.Mock().On("Warn", mock.Anything).Return()
+			logger.Mock().On("Info", mock.Anything)
+			logger.Mock().On("Debug", mock.Anything)
+
+			// WebAPI instance for a producer.
+			prod, err := New(Config{
+				Topics:          map[string]transport.Message{"test": (*message)(nil)},
+				AuthorAllowlist: []types.Address{address1},
+				AddressBook:     consAB,
+				Signer:          signer,
+				ServePull:       true,
+				FlushTicker:     prodTick,
+				Server:          prodSrv,
+				Rand:            rand,
+				Logger:          logger,
+			})
+			require.NoError(t, err)
+
+			// WebAPI instance for a consumer.
+			cons, err := New(Config{
+				Topics:          map[string]transport.Message{"test": (*message)(nil)},
+				AuthorAllowlist: []types.Address{address1},
+				AddressBook:     &addressBook{},
+				PullAddressBook: prodAB,
+				PullTicker:      pullTick,
+				FlushTicker:     timeutil.NewTicker(60 * time.Second),
+				Server:          consSrv,
+				Logger:          logger,
+			})
+			require.NoError(t, err)
+
+			prod.recover = recoverer
+			cons.recover = recoverer
+
+			// Start transport. The consumer pulls messages immediately after
+			// start, so the producer address must be known before.
+			require.NoError(t, prod.Start(ctx))
+			prodAB.addresses = []string{prodSrv.Addr().String()}
+			require.NoError(t, cons.Start(ctx))
+			if tt.push {
+				consAB.addresses = []string{"http://" + consSrv.Addr().String()}
+			}
+
+			// Prepare mocks:
+			tm := time.Now()
+			msgSig := []byte("testdata")
+			urlSig := []byte(fmt.Sprintf("%d30313233343536373839616263646566", tm.Unix()))
+			signer.On("SignMessage", msgSig).Return(&fakeSignature, nil).Once()
+			signer.On("SignMessage", urlSig).Return(&fakeSignature, nil)
+			recoverer.On("RecoverMessage", msgSig, fakeSignature).Return(&address1, nil)
+			recoverer.On("RecoverMessage", urlSig, fakeSignature).Return(&address1, nil)
+
+			// Flush message:
+			ch := cons.Messages("test")
+			require.NoError(t, prod.Broadcast("test", &message{data: []byte("data")}))
+			prodTick.TickAt(tm)
+			require.Eventually(t, func() bool {
+				prod.mu.RLock()
+				defer prod.mu.RUnlock()
+				return prod.pullPack != nil
+			}, time.Second, 10*time.Millisecond)
+
+			// Pull messages twice, the message pack must be delivered only once:
+			pullTick.Tick()
+			msg := <-ch
+			assert.Equal(t, []byte("data"), msg.Message.(*message).data)
+			assert.Equal(t, address1.Bytes(), msg.Author)
+			pullTick.Tick()
+			select {
+			case <-ch:
+				t.Fatal("message pack delivered twice")
+			case <-time.After(200 * time.Millisecond):
+			}
+		})
+	}
+}
@@ -12,9 +12,11 @@ type Ticker struct {
 	mu  sync.Mutex
 	ctx context.Context
 
-	d time.Duration
-	t *time.Ticker
-	c chan time.Time
+	d      time.Duration
+	align  bool
+	offset time.Duration
+	t      *time.Ticker
+	c      chan time.Time
 }
 
 // NewTicker returns a new Ticker instance.
@@ -24,6 +26,17 @@ func NewTicker(d time.Duration) *Ticker {
 	return &Ticker{d: d, c: make(chan time.Time)}
 }
 
+// NewAlignedTicker returns a new Ticker instance which ticks are aligned to
+// multiples of d since the Unix epoch, shifted by the offset. For example,
+// an aligned ticker with d equal to 1h and offset equal to 5m ticks at five
+// minutes past every full hour.
+//
+// If d is 0, the ticker will not be started and only manual ticks will be
+// possible.
+func NewAlignedTicker(d, offset time.Duration) *Ticker {
+	return &Ticker{d: d, align: true, offset: offset, c: make(chan time.Time)}
+}
+
 // Start starts the ticker.
 func (t *Ticker) Start(ctx context.Context) {
 	t.mu.Lock()
@@ -43,6 +56,17 @@ func (t *Ticker) Duration() time.Duration {
 	return t.d
 }
 
+// Aligned returns true if ticks are aligned to multiples of the ticker
+// duration.
+func (t *Ticker) Aligned() bool {
+	return t.align
+}
+
+// Offset returns the offset of aligned ticks.
+func (t *Ticker) Offset() time.Duration {
+	return t.offset
+}
+
 // Tick sends a tick to the ticker channel.
 // Ticker must be started before calling this method.
 func (t *Ticker) Tick() {
@@ -69,6 +93,10 @@ func (t *Ticker) ticker() {
 	if t.d == 0 {
 		return
 	}
+	if t.align {
+		t.alignedTicker()
+		return
+	}
 	t.t = time.NewTicker(t.d)
 	for {
 		select {
@@ -84,3 +112,29 @@ func (t *Ticker) ticker() {
 		}
 	}
 }
+
+func (t *Ticker) alignedTicker() {
+	for {
+		timer := time.NewTimer(time.Until(nextAligned(time.Now(), t.d, t.offset)))
+		select {
+		case <-t.ctx.Done():
+			t.mu.Lock()
+			timer.Stop()
+			t.ctx = nil
+			t.mu.Unlock()
+			return
+		case tm := <-timer.C:
+			t.c <- tm
+		}
+	}
+}
+
+// nextAligned returns the first time after now that is a multiple of d
+// since the Unix epoch, shifted by the offset.
+func nextAligned(now time.Time, d, offset time.Duration) time.Time {
+	next := now.Add(-offset).Truncate(d).Add(d).Add(offset)
+	for !next.After(now) {
+		next = next.Add(d)
+	}
+	return next
+}
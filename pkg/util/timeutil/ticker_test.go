@@ -28,3 +28,54 @@ func TestTicker(t *testing.T) {
 	elapsed := time.Since(start)
 	assert.True(t, elapsed >= 100*time.Millisecond)
 }
+
+func TestNextAligned(t *testing.T) {
+	tests := []struct {
+		now      time.Time
+		d        time.Duration
+		offset   time.Duration
+		expected time.Time
+	}{
+		{
+			now:      time.Unix(3599, 0),
+			d:        time.Hour,
+			expected: time.Unix(3600, 0),
+		},
+		{
+			now:      time.Unix(3600, 0),
+			d:        time.Hour,
+			expected: time.Unix(7200, 0),
+		},
+		{
+			now:      time.Unix(3600, 0),
+			d:        time.Hour,
+			offset:   5 * time.Minute,
+			expected: time.Unix(3900, 0),
+		},
+		{
+			now:      time.Unix(4000, 0),
+			d:        time.Hour,
+			offset:   5 * time.Minute,
+			expected: time.Unix(7500, 0),
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, nextAligned(tt.now, tt.d, tt.offset))
+	}
+}
+
+func TestAlignedTicker(t *testing.T) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancelCtx()
+
+	ticker := NewAlignedTicker(10*time.Millisecond, 0)
+	ticker.Start(ctx)
+
+	var prev time.Time
+	for n := 0; n < 3; n++ {
+		tm := <-ticker.TickCh()
+		assert.True(t, tm.After(prev))
+		prev = tm
+	}
+	assert.True(t, ticker.Aligned())
+}